@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 
 	"mangahub/internal/core"
@@ -20,9 +22,11 @@ import (
 	udpProtocol "mangahub/internal/protocols/udp"
 	wsProtocol "mangahub/internal/protocols/websocket"
 	"mangahub/internal/repository"
+	"mangahub/pkg/cache"
 	"mangahub/pkg/config"
 	"mangahub/pkg/database"
 	"mangahub/pkg/logger"
+	"mangahub/pkg/models"
 )
 
 func main() {
@@ -75,16 +79,46 @@ func main() {
 	activityRepo := repository.NewActivityRepository(pool)
 	statsRepo := repository.NewStatsRepository(pool)
 	notificationRepo := repository.NewNotificationRepository(pool)
+	apiKeyRepo := repository.NewAPIKeyRepository(pool)
+	jobRepo := repository.NewJobRepository(pool)
+	leaderboardRepo := repository.NewLeaderboardRepository(pool)
+	dailyStatsRepo := repository.NewDailyStatsRepository(pool)
+	outboxRepo := repository.NewOutboxRepository(pool)
 
 	logger.Info("Initialized all repositories")
 
 	// Initialize core services
 	authSvc := core.NewAuthService(userRepo, cfg.JWT.Secret, cfg.JWT.Issuer, cfg.JWT.Expiration)
-	mangaSvc := core.NewMangaService(mangaRepo)
-	commentSvc := core.NewCommentService(commentRepo, userRepo)
-	chatSvc := core.NewChatService(chatRepo, userRepo)
+	// Services publish domain events after their writes commit; the stats,
+	// UDP and chat room subscribers hand them to the outbox relay
+	events := core.NewEventBus()
+	mangaSvc := core.NewMangaService(mangaRepo, events)
+	commentSvc := core.NewCommentService(commentRepo, userRepo, events)
+	chatSvc := core.NewChatService(chatRepo, userRepo, events)
 	activitySvc := core.NewActivityService(activityRepo)
-	statsSvc := core.NewStatsService(statsRepo, mangaRepo)
+	core.SubscribeActivity(events, activitySvc)
+	core.SubscribeStats(events, outboxRepo)
+	core.SubscribeNotifications(events, outboxRepo)
+	core.SubscribeChatRooms(events, outboxRepo)
+	scoring, err := core.NewScoringEngine(models.ScoringFormula{
+		CommentWeight: cfg.Scoring.CommentWeight,
+		LikeWeight:    cfg.Scoring.LikeWeight,
+		ChatWeight:    cfg.Scoring.ChatWeight,
+		UpdateWeight:  cfg.Scoring.UpdateWeight,
+		HalfLife:      cfg.Scoring.HalfLife,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure scoring: %v", err)
+	}
+	statsSvc := core.NewStatsService(statsRepo, mangaRepo, leaderboardRepo, dailyStatsRepo, scoring)
+	apiKeySvc := core.NewAPIKeyService(apiKeyRepo, userRepo)
+	schedulerSvc, err := core.NewSchedulerService(jobRepo, statsRepo, dailyStatsRepo, scoring, core.SchedulerConfig{
+		DecaySchedule:   cfg.Scheduler.DecaySchedule,
+		RebuildSchedule: cfg.Scheduler.RebuildSchedule,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure job scheduler: %v", err)
+	}
 
 	logger.Info("Initialized all core services")
 
@@ -98,15 +132,43 @@ func main() {
 		chatSvc,
 		activitySvc,
 		statsSvc,
+		apiKeySvc,
+		schedulerSvc,
 	)
 
 	// 2. gRPC Search Server
-	grpcServer := grpc.NewServer()
-	grpcSearchSvc := grpcProtocol.NewMangaServiceServer(pool, mangaRepo, statsRepo)
+	grpcAuth := grpcProtocol.NewAuthInterceptor(authSvc, apiKeySvc, cfg.GRPC.RequireAuth)
+	grpcServer := grpc.NewServer(grpcProtocol.ServerOptions(grpcAuth)...)
+	grpcSearchSvc := grpcProtocol.NewMangaServiceServer(
+		pool,
+		mangaRepo,
+		statsRepo,
+		mangaSvc,
+		commentSvc,
+		chatSvc,
+		activitySvc,
+		statsSvc,
+	)
 	pb.RegisterMangaServiceServer(grpcServer, grpcSearchSvc)
 
-	// 3. WebSocket Chat Server
-	wsHub := wsProtocol.NewHub(chatRepo, activityRepo)
+	// 3. WebSocket Chat Server (rooms shared across replicas by the backplane)
+	var chatBackplane repository.ChatBackplane
+	var redisPubSub *cache.RedisPubSub
+	switch cfg.WebSocket.Backplane {
+	case "postgres":
+		chatBackplane = repository.NewPostgresChatBackplane(pool)
+	case "redis":
+		redisPubSub, err = cache.NewRedisPubSub(&cfg.Redis, "mangahub:chat_events")
+		if err != nil {
+			log.Fatalf("Failed to connect chat backplane to Redis: %v", err)
+		}
+		chatBackplane = redisPubSub
+	case "none", "":
+		logger.Info("Chat backplane disabled; rooms are local to this instance")
+	default:
+		log.Fatalf("Unknown websocket.backplane %q (want postgres, redis or none)", cfg.WebSocket.Backplane)
+	}
+	wsHub := wsProtocol.NewHub(chatSvc, chatRepo, chatBackplane)
 	wsHandler := wsProtocol.NewHandler(
 		wsHub,
 		authSvc,
@@ -117,20 +179,81 @@ func main() {
 		[]string{"*"},
 	)
 
+	// gRPC chat streaming shares the WebSocket hub's rooms
+	pb.RegisterChatServiceServer(grpcServer, grpcProtocol.NewChatServiceServer(wsHub, mangaRepo))
+
+	// Standard grpc.health.v1 (tied to DB health) and server reflection
+	grpcHealth := grpcProtocol.RegisterStandardServices(grpcServer, pool)
+
 	// Register WebSocket routes on HTTP server
 	httpServer.Router().GET("/ws/manga/:manga_id", wsHandler.HandleWebSocket)
 	httpServer.Router().GET("/ws/manga/:manga_id/status", wsHandler.GetRoomStatus)
 
+	// REST/JSON gateway for the gRPC API (e.g. GET /gateway/v1/manga/:id)
+	gatewayCtx, cancelGateway := context.WithCancel(context.Background())
+	defer cancelGateway()
+	if cfg.GRPC.Gateway {
+		gatewayHost := cfg.GRPC.Host
+		if gatewayHost == "" || gatewayHost == "0.0.0.0" {
+			gatewayHost = "127.0.0.1"
+		}
+		gateway, err := grpcProtocol.NewGateway(gatewayCtx, fmt.Sprintf("%s:%d", gatewayHost, cfg.GRPC.Port))
+		if err != nil {
+			logger.Errorf("gRPC gateway disabled: %v", err)
+		} else {
+			httpServer.Router().Any("/gateway/*path", gin.WrapH(http.StripPrefix("/gateway", gateway)))
+			logger.Info("gRPC gateway mounted at /gateway")
+		}
+	}
+
 	// 4. UDP Notification Server
-	udpServer := udpProtocol.NewServer(cfg.UDP.Host, cfg.UDP.Port, notificationRepo)
+	udpServer := udpProtocol.NewServer(cfg.UDP.Host, cfg.UDP.Port, notificationRepo, udpProtocol.DeliveryConfig{
+		Mode:           cfg.UDP.Mode,
+		MulticastGroup: cfg.UDP.MulticastGroup,
+		SubscriberTTL:  cfg.UDP.SubscriberTTL,
+		MaxSubscribers: cfg.UDP.MaxSubscribers,
+	})
 
 	// 5. TCP Stats Aggregator Server
-	tcpServer := tcpProtocol.NewServer(cfg.TCP.Host, cfg.TCP.Port, statsRepo, activityRepo)
+	tcpServer := tcpProtocol.NewServer(cfg.TCP.Host, cfg.TCP.Port, statsRepo, activityRepo, scoring, tcpProtocol.BatchConfig{
+		Size:          cfg.TCP.BatchSize,
+		FlushInterval: cfg.TCP.FlushInterval,
+		DedupeWindow:  cfg.TCP.DedupeWindow,
+	}, tcpProtocol.ProtocolConfig{
+		SharedSecret: cfg.TCP.SharedSecret,
+		MaxFrameSize: cfg.TCP.MaxFrameSize,
+	})
 
 	// CROSS-PROTOCOL INTEGRATION: Wire up server references
-	tcpAddr := fmt.Sprintf("%s:%d", cfg.TCP.Host, cfg.TCP.Port)
-	httpServer.SetCrossProtocolServers(udpServer, tcpAddr)
-	wsHub.SetStatsAddr(tcpAddr)
+	// Side effects reach the outbox with the write that causes them or from
+	// an event subscriber; the relay delivers stats events through one pooled
+	// stats client
+	var statsClient *tcpProtocol.StatsClient
+	if os.Getenv("ENABLE_TCP") != "false" {
+		statsClient = tcpProtocol.NewStatsClient(fmt.Sprintf("%s:%d", cfg.TCP.Host, cfg.TCP.Port), tcpProtocol.ClientConfig{
+			PoolSize:  cfg.TCP.ClientPool,
+			QueueSize: cfg.TCP.RetryQueue,
+			ClientID:  "mangahub-server",
+			Secret:    cfg.TCP.SharedSecret,
+		})
+	}
+	httpServer.SetCrossProtocolServers(udpServer)
+
+	outboxRelay := core.NewOutboxRelay(outboxRepo, core.OutboxConfig{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
+		Retention:    cfg.Outbox.Retention,
+	})
+	if statsClient != nil {
+		outboxRelay.Register(models.OutboxStats, statsClient.DeliverOutbox)
+	} else {
+		// Without the aggregator, stats are written straight to the database
+		outboxRelay.Register(models.OutboxStats, core.StatsWriter(statsRepo, scoring))
+	}
+	outboxRelay.Register(models.OutboxNotification, udpServer.DeliverOutbox)
+	outboxRelay.Register(models.OutboxChat, wsHub.DeliverOutbox)
 
 	logger.Info("Cross-protocol event flows configured")
 
@@ -164,6 +287,7 @@ func main() {
 			return
 		}
 		logger.Info(fmt.Sprintf("Starting gRPC server on %s", grpcAddr))
+		grpcHealth.Start()
 		if err := grpcServer.Serve(listener); err != nil {
 			logger.Errorf("gRPC server error (non-fatal): %v", err)
 		}
@@ -203,6 +327,16 @@ func main() {
 		logger.Info("TCP server disabled (ENABLE_TCP=false)")
 	}
 
+	// Start scheduled stats jobs (admins can still trigger them when disabled)
+	if cfg.Scheduler.Enabled {
+		schedulerSvc.Start()
+	} else {
+		logger.Info("Job scheduler disabled (scheduler.enabled=false)")
+	}
+
+	// Deliver outbox events once the servers they feed are starting
+	outboxRelay.Start()
+
 	logger.Info("All protocol servers started successfully")
 	logger.Info("Press Ctrl+C to shutdown")
 
@@ -221,7 +355,12 @@ func main() {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Stop gRPC server
+	// Stop scheduled jobs (waits for a running job to finish)
+	schedulerSvc.Stop()
+	logger.Info("Job scheduler stopped")
+
+	// Stop gRPC server (report NOT_SERVING first so probes drain traffic)
+	grpcHealth.Shutdown()
 	grpcServer.GracefulStop()
 	logger.Info("gRPC server stopped")
 
@@ -229,7 +368,20 @@ func main() {
 	udpServer.Stop()
 	logger.Info("UDP server stopped")
 
-	// Stop TCP server
+	// Stop the outbox relay; undelivered events are picked up on restart
+	outboxRelay.Stop()
+	logger.Info("Outbox relay stopped")
+
+	// Stop the chat hub (disconnects clients and leaves the backplane)
+	wsHub.Stop()
+	if redisPubSub != nil {
+		redisPubSub.Close()
+	}
+
+	// Stop TCP server (close the stats client first so it stops retrying)
+	if statsClient != nil {
+		statsClient.Close()
+	}
 	tcpServer.Stop()
 	logger.Info("TCP server stopped")
 
@@ -7,10 +7,18 @@
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
 -- Drop tables if exist (for clean migrations)
+DROP TABLE IF EXISTS outbox_events CASCADE;
+DROP TABLE IF EXISTS manga_stats_daily CASCADE;
+DROP TABLE IF EXISTS job_runs CASCADE;
+DROP TABLE IF EXISTS api_keys CASCADE;
+DROP TABLE IF EXISTS stats_processed_events CASCADE;
 DROP TABLE IF EXISTS manga_stats CASCADE;
 DROP TABLE IF EXISTS notifications CASCADE;
 DROP TABLE IF EXISTS activity_feed CASCADE;
+DROP TABLE IF EXISTS chat_mentions CASCADE;
+DROP TABLE IF EXISTS chat_reactions CASCADE;
 DROP TABLE IF EXISTS chat_messages CASCADE;
+DROP TABLE IF EXISTS comment_likes CASCADE;
 DROP TABLE IF EXISTS comments CASCADE;
 DROP TABLE IF EXISTS manga_genres CASCADE;
 DROP TABLE IF EXISTS genres CASCADE;
@@ -121,6 +129,20 @@ CREATE INDEX idx_comments_user_id ON comments(user_id);
 CREATE INDEX idx_comments_created_at ON comments(created_at DESC);
 CREATE INDEX idx_comments_like_count ON comments(like_count DESC);
 
+-- One row per like, so likes are scored when they are made
+CREATE TABLE comment_likes (
+  id TEXT PRIMARY KEY,
+  comment_id TEXT NOT NULL,
+  manga_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE,
+  FOREIGN KEY (manga_id) REFERENCES manga(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_comment_likes_manga_created_at ON comment_likes(manga_id, created_at DESC);
+
 -- ============================================
 -- 6. CHAT MESSAGES (WEBSOCKET)
 -- ============================================
@@ -131,14 +153,41 @@ CREATE TABLE chat_messages (
   user_id TEXT NOT NULL,
   content TEXT NOT NULL,
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  edited_at TIMESTAMP,
+  reply_to TEXT,
   FOREIGN KEY (manga_id) REFERENCES manga(id) ON DELETE CASCADE,
-  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (reply_to) REFERENCES chat_messages(id) ON DELETE SET NULL
 );
 
-CREATE INDEX idx_chat_messages_manga_id ON chat_messages(manga_id);
+-- Keyset pagination of a room's history by (created_at, id)
+CREATE INDEX idx_chat_messages_manga_created ON chat_messages(manga_id, created_at DESC, id DESC);
 CREATE INDEX idx_chat_messages_user_id ON chat_messages(user_id);
 CREATE INDEX idx_chat_messages_created_at ON chat_messages(created_at DESC);
 
+-- One row per user and emoji on a message
+CREATE TABLE chat_reactions (
+  message_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  emoji TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (message_id, user_id, emoji),
+  FOREIGN KEY (message_id) REFERENCES chat_messages(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Users mentioned by @username in a message, or whose message it replies to
+CREATE TABLE chat_mentions (
+  message_id TEXT NOT NULL,
+  user_id TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (message_id, user_id),
+  FOREIGN KEY (message_id) REFERENCES chat_messages(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_chat_mentions_user_created ON chat_mentions(user_id, created_at DESC);
+
 -- ============================================
 -- 7. ACTIVITY FEED (HOME)
 -- ============================================
@@ -161,13 +210,33 @@ CREATE INDEX idx_activity_feed_created_at ON activity_feed(created_at DESC);
 -- 8. NOTIFICATIONS (UDP BROADCAST LOG)
 -- ============================================
 
+-- type, manga_id and title let every UDP server rebuild the full notification
+-- from the row; rows from scripts default to 'system'
 CREATE TABLE notifications (
   id TEXT PRIMARY KEY,
   message TEXT NOT NULL,
+  type TEXT NOT NULL DEFAULT 'system',
+  manga_id TEXT,
+  title TEXT,
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
-CREATE INDEX idx_notifications_created_at ON notifications(created_at DESC);
+CREATE INDEX idx_notifications_created_at ON notifications(created_at, id);
+
+-- Every insert is announced on the 'notifications' channel with the row ID,
+-- so UDP servers broadcast it without polling
+CREATE OR REPLACE FUNCTION notify_notification_inserted() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('notifications', NEW.id);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notifications_notify_trigger ON notifications;
+CREATE TRIGGER notifications_notify_trigger
+  AFTER INSERT ON notifications
+  FOR EACH ROW
+  EXECUTE FUNCTION notify_notification_inserted();
 
 -- ============================================
 -- 9. MANGA STATS (TCP AGGREGATION)
@@ -187,8 +256,97 @@ CREATE INDEX idx_manga_stats_weekly_score ON manga_stats(weekly_score DESC);
 CREATE INDEX idx_manga_stats_comment_count ON manga_stats(comment_count DESC);
 CREATE INDEX idx_manga_stats_updated_at ON manga_stats(updated_at DESC);
 
+-- Stats event IDs already counted, so replays and outbox redeliveries are
+-- skipped across restarts; rows older than tcp.dedupe_window are purged
+CREATE TABLE stats_processed_events (
+  event_id TEXT PRIMARY KEY,
+  processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stats_processed_events_processed_at ON stats_processed_events(processed_at);
+
+-- ============================================
+-- 10. API KEYS (SERVICE / SCRIPT ACCESS)
+-- ============================================
+
+CREATE TABLE api_keys (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  prefix TEXT NOT NULL,
+  key_hash TEXT UNIQUE NOT NULL,
+  scopes TEXT[] NOT NULL DEFAULT '{}',
+  created_by TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  last_used_at TIMESTAMP,
+  revoked_at TIMESTAMP,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_api_keys_created_by ON api_keys(created_by);
+CREATE INDEX idx_api_keys_created_at ON api_keys(created_at DESC);
+
+-- ============================================
+-- 11. SCHEDULED JOB RUNS
+-- ============================================
+
+-- Latest run per background job (score decay, stats rebuild)
+CREATE TABLE job_runs (
+  job_name TEXT PRIMARY KEY,
+  status TEXT NOT NULL
+    CHECK (status IN ('running', 'succeeded', 'failed')),
+  triggered_by TEXT NOT NULL DEFAULT 'schedule',
+  instance TEXT NOT NULL DEFAULT '',
+  started_at TIMESTAMP NOT NULL,
+  finished_at TIMESTAMP,
+  duration_ms BIGINT NOT NULL DEFAULT 0,
+  error TEXT NOT NULL DEFAULT ''
+);
+
+-- ============================================
+-- 12. DAILY MANGA STATS (TIME SERIES)
+-- ============================================
+
+-- Per-day activity per manga, written by the TCP stats aggregator.
+-- score is the day's undecayed scoring-engine points.
+CREATE TABLE manga_stats_daily (
+  manga_id TEXT NOT NULL,
+  day DATE NOT NULL,
+  comment_count INTEGER NOT NULL DEFAULT 0,
+  chat_count INTEGER NOT NULL DEFAULT 0,
+  like_count INTEGER NOT NULL DEFAULT 0,
+  score INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (manga_id, day),
+  FOREIGN KEY (manga_id) REFERENCES manga(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_manga_stats_daily_day ON manga_stats_daily(day DESC);
+
+-- ============================================
+-- 13. OUTBOX (CROSS-PROTOCOL SIDE EFFECTS)
+-- ============================================
+
+-- Side effects written in the same transaction as the row that causes them,
+-- then delivered by the outbox relay: destination 'stats' goes to the TCP
+-- aggregator, 'notification' to the UDP broadcaster, 'chat' to WebSocket rooms.
+-- Each destination is claimed by its own relay worker, so a slow sink only
+-- delays its own rows. Rows that keep failing are dead-lettered with their last error.
+CREATE TABLE outbox_events (
+  id TEXT PRIMARY KEY,
+  destination TEXT NOT NULL CHECK (destination IN ('stats', 'notification', 'chat')),
+  payload JSONB NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT,
+  available_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  delivered_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(destination, available_at) WHERE status = 'pending';
+CREATE INDEX idx_outbox_events_status ON outbox_events(status, created_at);
+
 -- ============================================
--- 10. SEED INITIAL DATA
+-- 14. SEED INITIAL DATA
 -- ============================================
 
 -- Seed genres
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 
 	"mangahub/internal/repository"
 	"mangahub/pkg/models"
@@ -144,3 +145,20 @@ func (s *activityService) GetMangaFeed(ctx context.Context, mangaID string, limi
 		HasMore: offset+limit < total,
 	}, nil
 }
+
+// SubscribeActivity records feed entries for manga creation and updates.
+// Comments and chat messages are logged in the transaction that saves them.
+func SubscribeActivity(events EventBus, activitySvc ActivityService) {
+	events.Subscribe(EventMangaCreated, func(ctx context.Context, event DomainEvent) {
+		created := event.(MangaCreated)
+		if err := activitySvc.CreateActivity(ctx, models.ActivityTypeMangaUpdate, created.UserID, &created.Manga.ID); err != nil {
+			logrus.Errorf("Failed to log manga creation activity: %v", err)
+		}
+	})
+	events.Subscribe(EventMangaUpdated, func(ctx context.Context, event DomainEvent) {
+		updated := event.(MangaUpdated)
+		if err := activitySvc.CreateActivity(ctx, models.ActivityTypeMangaUpdate, updated.UserID, &updated.Manga.ID); err != nil {
+			logrus.Errorf("Failed to log manga update activity: %v", err)
+		}
+	})
+}
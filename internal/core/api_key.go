@@ -0,0 +1,142 @@
+// Package core - API Key Business Logic
+// Protocol-agnostic API key management service
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mangahub/internal/repository"
+	"mangahub/pkg/models"
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrInvalidScope  = errors.New("invalid api key scope")
+)
+
+const (
+	apiKeyPrefix       = "mh_"
+	apiKeyBytes        = 32
+	apiKeyDisplayChars = 8
+)
+
+// APIKeyService defines API key operations
+type APIKeyService interface {
+	Create(ctx context.Context, createdBy string, req models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error)
+	List(ctx context.Context) ([]*models.APIKey, error)
+	Revoke(ctx context.Context, id string) error
+	Authenticate(ctx context.Context, rawKey string) (*models.User, *models.APIKey, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	userRepo   repository.UserRepository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, userRepo repository.UserRepository) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// Create issues a new API key owned by createdBy. The plaintext key is only
+// returned here; the database keeps its SHA-256 hash.
+func (s *apiKeyService) Create(ctx context.Context, createdBy string, req models.CreateAPIKeyRequest) (*models.CreateAPIKeyResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required: %w", ErrInvalidScope)
+	}
+
+	scopes := make([]string, 0, len(req.Scopes))
+	seen := make(map[string]bool, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !models.IsValidAPIKeyScope(scope) {
+			return nil, fmt.Errorf("%s: %w", scope, ErrInvalidScope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	raw := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	plaintext := apiKeyPrefix + hex.EncodeToString(raw)
+
+	key := &models.APIKey{
+		Name:      name,
+		Prefix:    plaintext[:len(apiKeyPrefix)+apiKeyDisplayChars],
+		KeyHash:   hashAPIKey(plaintext),
+		Scopes:    scopes,
+		CreatedBy: createdBy,
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &models.CreateAPIKeyResponse{
+		Key:    plaintext,
+		APIKey: key,
+	}, nil
+}
+
+// List returns all API keys (hashes are never serialized)
+func (s *apiKeyService) List(ctx context.Context) ([]*models.APIKey, error) {
+	keys, err := s.apiKeyRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke permanently disables an API key
+func (s *apiKeyService) Revoke(ctx context.Context, id string) error {
+	if err := s.apiKeyRepo.Revoke(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
+
+// Authenticate resolves a plaintext API key to its owning user and key record
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey string) (*models.User, *models.APIKey, error) {
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByHash(ctx, hashAPIKey(rawKey))
+	if err != nil || key.IsRevoked() {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	user, err := s.userRepo.GetByID(ctx, key.CreatedBy)
+	if err != nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	user.PasswordHash = ""
+
+	// Best effort: usage tracking must not fail the request
+	_ = s.apiKeyRepo.TouchLastUsed(ctx, key.ID)
+
+	return user, key, nil
+}
+
+// hashAPIKey returns the hex-encoded SHA-256 digest of a plaintext key.
+// A fast hash is sufficient because keys carry 256 bits of entropy.
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -4,8 +4,11 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 
@@ -17,19 +20,28 @@ import (
 type ChatService interface {
 	SendMessage(ctx context.Context, mangaID, userID string, req models.SendChatMessageRequest) (*models.ChatMessageResponse, error)
 	GetHistory(ctx context.Context, mangaID string, limit, offset int) (*models.ChatHistoryResponse, error)
+	GetHistoryBefore(ctx context.Context, mangaID string, cursor models.ChatHistoryCursor, limit int) (*models.ChatHistoryPage, error)
+	EditMessage(ctx context.Context, id, userID string, req models.EditChatMessageRequest) (*models.ChatMessageResponse, error)
 	DeleteMessage(ctx context.Context, id, userID string) error
+	ToggleReaction(ctx context.Context, id, userID, emoji string) (*models.ChatReactionResponse, error)
+	GetMentions(ctx context.Context, userID string, limit, offset int) (*models.ChatMentionListResponse, error)
 }
 
+// chatEditWindow is how long after sending an author may edit a message
+const chatEditWindow = 15 * time.Minute
+
 type chatService struct {
 	chatRepo repository.ChatRepository
 	userRepo repository.UserRepository
+	events   EventBus
 }
 
 // NewChatService creates a new chat service
-func NewChatService(chatRepo repository.ChatRepository, userRepo repository.UserRepository) ChatService {
+func NewChatService(chatRepo repository.ChatRepository, userRepo repository.UserRepository, events EventBus) ChatService {
 	return &chatService{
 		chatRepo: chatRepo,
 		userRepo: userRepo,
+		events:   events,
 	}
 }
 
@@ -37,10 +49,10 @@ func NewChatService(chatRepo repository.ChatRepository, userRepo repository.User
 func (s *chatService) SendMessage(ctx context.Context, mangaID, userID string, req models.SendChatMessageRequest) (*models.ChatMessageResponse, error) {
 	// Validate input
 	if req.Content == "" {
-		return nil, fmt.Errorf("content is required")
+		return nil, fmt.Errorf("content is required: %w", models.ErrInvalidInput)
 	}
 	if len(req.Content) > 5000 {
-		return nil, fmt.Errorf("content exceeds maximum length of 5000 characters")
+		return nil, fmt.Errorf("content exceeds maximum length of 5000 characters: %w", models.ErrInvalidInput)
 	}
 
 	// Create message
@@ -51,8 +63,20 @@ func (s *chatService) SendMessage(ctx context.Context, mangaID, userID string, r
 		Content:   req.Content,
 		CreatedAt: time.Now(),
 	}
+	if req.ReplyTo != "" {
+		message.ReplyTo = &req.ReplyTo
+	}
 
-	return s.chatRepo.Create(ctx, message)
+	response, err := s.chatRepo.Create(ctx, message)
+	if err != nil {
+		if message.ReplyTo != nil && errors.Is(err, models.ErrNotFound) {
+			return nil, fmt.Errorf("reply_to must be a message in this room: %w", err)
+		}
+		return nil, err
+	}
+
+	s.events.Publish(ctx, ChatMessageSent{Message: response})
+	return response, nil
 }
 
 // GetHistory retrieves chat history for a manga with pagination
@@ -87,6 +111,61 @@ func (s *chatService) GetHistory(ctx context.Context, mangaID string, limit, off
 	}, nil
 }
 
+// GetMentions retrieves the messages mentioning a user, newest first
+func (s *chatService) GetMentions(ctx context.Context, userID string, limit, offset int) (*models.ChatMentionListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	mentions, total, err := s.chatRepo.ListMentions(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mentions: %w", err)
+	}
+
+	responses := make([]models.ChatMentionResponse, 0, len(mentions))
+	for _, m := range mentions {
+		if m != nil {
+			responses = append(responses, *m)
+		}
+	}
+
+	return &models.ChatMentionListResponse{
+		Data:    responses,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: offset+limit < total,
+	}, nil
+}
+
+// GetHistoryBefore retrieves the page of chat history older than cursor
+func (s *chatService) GetHistoryBefore(ctx context.Context, mangaID string, cursor models.ChatHistoryCursor, limit int) (*models.ChatHistoryPage, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	// One extra row tells whether older messages remain
+	messages, err := s.chatRepo.ListBefore(ctx, mangaID, cursor, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat history: %w", err)
+	}
+
+	page := &models.ChatHistoryPage{Data: make([]models.ChatMessageResponse, 0, limit)}
+	if len(messages) > limit {
+		messages = messages[:limit]
+		page.HasMore = true
+	}
+	for _, m := range messages {
+		if m != nil {
+			page.Data = append(page.Data, *m)
+		}
+	}
+	return page, nil
+}
+
 // DeleteMessage removes a chat message (only by owner or admin)
 func (s *chatService) DeleteMessage(ctx context.Context, id, userID string) error {
 	// Get message to verify ownership
@@ -100,7 +179,7 @@ func (s *chatService) DeleteMessage(ctx context.Context, id, userID string) erro
 		// Get user to check if admin
 		user, err := s.userRepo.GetByID(ctx, userID)
 		if err != nil || user.Role != "admin" {
-			return fmt.Errorf("permission denied: only message owner or admin can delete")
+			return fmt.Errorf("only message owner or admin can delete: %w", models.ErrForbidden)
 		}
 	}
 
@@ -110,3 +189,58 @@ func (s *chatService) DeleteMessage(ctx context.Context, id, userID string) erro
 	
 	return nil
 }
+
+// EditMessage replaces a message's content (only by its author, within chatEditWindow)
+func (s *chatService) EditMessage(ctx context.Context, id, userID string, req models.EditChatMessageRequest) (*models.ChatMessageResponse, error) {
+	if req.Content == "" {
+		return nil, fmt.Errorf("content is required: %w", models.ErrInvalidInput)
+	}
+	if len(req.Content) > models.MaxChatMessageLength {
+		return nil, fmt.Errorf("content exceeds maximum length of 5000 characters: %w", models.ErrInvalidInput)
+	}
+
+	message, err := s.chatRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("message not found: %w", err)
+	}
+	if message.UserID != userID {
+		return nil, fmt.Errorf("only message owner can edit: %w", models.ErrForbidden)
+	}
+	if time.Since(message.CreatedAt) > chatEditWindow {
+		return nil, fmt.Errorf("messages can only be edited within %s of sending: %w", chatEditWindow, models.ErrForbidden)
+	}
+
+	response, err := s.chatRepo.Update(ctx, id, req.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit message: %w", err)
+	}
+	return response, nil
+}
+
+// ToggleReaction adds the user's emoji reaction to a message, or removes it
+func (s *chatService) ToggleReaction(ctx context.Context, id, userID, emoji string) (*models.ChatReactionResponse, error) {
+	emoji = strings.TrimSpace(emoji)
+	if !isEmoji(emoji) {
+		return nil, fmt.Errorf("reaction must be a single emoji: %w", models.ErrInvalidInput)
+	}
+
+	response, err := s.chatRepo.ToggleReaction(ctx, id, userID, emoji)
+	if err != nil {
+		return nil, fmt.Errorf("failed to react to message: %w", err)
+	}
+	return response, nil
+}
+
+// isEmoji reports whether s looks like one emoji: short, with no letters,
+// digits, spaces or other ASCII (sequences may join several code points)
+func isEmoji(s string) bool {
+	if s == "" || len(s) > models.MaxChatEmojiLength {
+		return false
+	}
+	for _, r := range s {
+		if r <= unicode.MaxASCII || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEmoji(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "single emoji", input: "👍", want: true},
+		{name: "emoji with variation selector", input: "❤️", want: true},
+		{name: "skin tone modifier", input: "👍🏽", want: true},
+		{name: "zwj sequence", input: "👨‍👩‍👧", want: true},
+		{name: "flag", input: "🇯🇵", want: true},
+		{name: "empty", input: "", want: false},
+		{name: "ascii", input: ":)", want: false},
+		{name: "emoji with ascii", input: "👍!", want: false},
+		{name: "letters", input: "漫画", want: false},
+		{name: "digit", input: "٣", want: false},
+		{name: "space", input: "👍　👍", want: false},
+		{name: "too long", input: strings.Repeat("👍", 9), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isEmoji(tt.input))
+		})
+	}
+}
@@ -25,13 +25,15 @@ type CommentService interface {
 type commentService struct {
 	commentRepo repository.CommentRepository
 	userRepo    repository.UserRepository
+	events      EventBus
 }
 
 // NewCommentService creates a new comment service
-func NewCommentService(commentRepo repository.CommentRepository, userRepo repository.UserRepository) CommentService {
+func NewCommentService(commentRepo repository.CommentRepository, userRepo repository.UserRepository, events EventBus) CommentService {
 	return &commentService{
 		commentRepo: commentRepo,
 		userRepo:    userRepo,
+		events:      events,
 	}
 }
 
@@ -39,10 +41,10 @@ func NewCommentService(commentRepo repository.CommentRepository, userRepo reposi
 func (s *commentService) Create(ctx context.Context, mangaID, userID string, req models.CreateCommentRequest) (*models.CommentResponse, error) {
 	// Validate input
 	if req.Content == "" {
-		return nil, fmt.Errorf("content is required")
+		return nil, fmt.Errorf("content is required: %w", models.ErrInvalidInput)
 	}
 	if len(req.Content) > 5000 {
-		return nil, fmt.Errorf("content exceeds maximum length of 5000 characters")
+		return nil, fmt.Errorf("content exceeds maximum length of 5000 characters: %w", models.ErrInvalidInput)
 	}
 
 	// Create comment
@@ -55,7 +57,13 @@ func (s *commentService) Create(ctx context.Context, mangaID, userID string, req
 		CreatedAt: time.Now(),
 	}
 
-	return s.commentRepo.Create(ctx, comment)
+	response, err := s.commentRepo.Create(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, CommentCreated{Comment: response})
+	return response, nil
 }
 
 // GetByID retrieves a comment by ID
@@ -102,7 +110,14 @@ func (s *commentService) ListByMangaID(ctx context.Context, mangaID string, limi
 
 // IncrementLikes increments the like count for a comment
 func (s *commentService) IncrementLikes(ctx context.Context, id, userID string) (*models.CommentResponse, error) {
-	return s.commentRepo.LikeComment(ctx, id, userID)
+	likedAt := time.Now()
+	response, err := s.commentRepo.LikeComment(ctx, id, userID, likedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, CommentLiked{Comment: response, UserID: userID, LikedAt: likedAt})
+	return response, nil
 }
 
 // Delete removes a comment (only by owner or admin)
@@ -118,7 +133,7 @@ func (s *commentService) Delete(ctx context.Context, id, userID string) error {
 		// Get user to check if admin
 		user, err := s.userRepo.GetByID(ctx, userID)
 		if err != nil || user.Role != "admin" {
-			return fmt.Errorf("permission denied: only comment owner or admin can delete")
+			return fmt.Errorf("only comment owner or admin can delete: %w", models.ErrForbidden)
 		}
 	}
 
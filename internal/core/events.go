@@ -0,0 +1,117 @@
+// Package core - Domain Events
+// In-process event bus the services publish to after a write commits, so
+// every entry point (REST, gRPC, WebSocket, imports) triggers the same reactions
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"mangahub/pkg/models"
+)
+
+// EventName identifies a domain event type
+type EventName string
+
+const (
+	EventMangaCreated    EventName = "manga_created"
+	EventMangaUpdated    EventName = "manga_updated"
+	EventCommentCreated  EventName = "comment_created"
+	EventCommentLiked    EventName = "comment_liked"
+	EventChatMessageSent EventName = "chat_message_sent"
+)
+
+// DomainEvent is a fact published by a service once its write has committed
+type DomainEvent interface {
+	EventName() EventName
+}
+
+// MangaCreated is published after a manga is created
+type MangaCreated struct {
+	Manga  *models.Manga
+	UserID *string // Creator; nil for imports and scripts
+}
+
+// MangaUpdated is published after a manga is updated
+type MangaUpdated struct {
+	Manga  *models.Manga
+	UserID *string // Editor; nil for scripts
+}
+
+// CommentCreated is published after a comment is posted
+type CommentCreated struct {
+	Comment *models.CommentResponse
+}
+
+// CommentLiked is published after a comment is liked
+type CommentLiked struct {
+	Comment *models.CommentResponse
+	UserID  string    // The liker, not the comment author
+	LikedAt time.Time // Trending scores the like from then
+}
+
+// ChatMessageSent is published after a chat message is saved
+type ChatMessageSent struct {
+	Message *models.ChatMessageResponse
+}
+
+func (MangaCreated) EventName() EventName    { return EventMangaCreated }
+func (MangaUpdated) EventName() EventName    { return EventMangaUpdated }
+func (CommentCreated) EventName() EventName  { return EventCommentCreated }
+func (CommentLiked) EventName() EventName    { return EventCommentLiked }
+func (ChatMessageSent) EventName() EventName { return EventChatMessageSent }
+
+// EventHandler reacts to one domain event. Handlers run synchronously in the
+// publisher's goroutine; slow work should be handed off.
+type EventHandler func(ctx context.Context, event DomainEvent)
+
+// EventBus fans domain events out to subscribers.
+//
+// Subscribers for stats, UDP notifications and chat room fan-out enqueue
+// outbox rows, so the relay retries their delivery (see SubscribeStats).
+// Comment and chat activity is logged in the transaction that saves them.
+type EventBus interface {
+	Subscribe(name EventName, handler EventHandler)
+	Publish(ctx context.Context, event DomainEvent)
+}
+
+type eventBus struct {
+	mu       sync.RWMutex
+	handlers map[EventName][]EventHandler
+}
+
+// NewEventBus creates an empty event bus
+func NewEventBus() EventBus {
+	return &eventBus{handlers: make(map[EventName][]EventHandler)}
+}
+
+// Subscribe registers a handler for one event type
+func (b *eventBus) Subscribe(name EventName, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish calls every handler for the event in registration order. A
+// panicking handler is logged and does not stop the others.
+func (b *eventBus) Publish(ctx context.Context, event DomainEvent) {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventName()]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		b.dispatch(ctx, event, handler)
+	}
+}
+
+func (b *eventBus) dispatch(ctx context.Context, event DomainEvent, handler EventHandler) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("Event handler for %s panicked: %v", event.EventName(), r)
+		}
+	}()
+	handler(ctx, event)
+}
@@ -16,36 +16,40 @@ import (
 
 // MangaService defines manga operations
 type MangaService interface {
-	Create(ctx context.Context, req models.CreateMangaRequest) (*models.Manga, error)
+	// Create creates a manga; userID is the creator, empty for imports
+	Create(ctx context.Context, userID string, req models.CreateMangaRequest) (*models.Manga, error)
 	GetByID(ctx context.Context, id string) (*models.Manga, error)
 	GetWithGenres(ctx context.Context, id string) (*models.MangaWithGenres, error)
 	List(ctx context.Context, req models.MangaSearchRequest) (*models.MangaListResponse, error)
 	Search(ctx context.Context, query string, limit, offset int) (*models.MangaListResponse, error)
-	Update(ctx context.Context, id string, req models.UpdateMangaRequest) (*models.Manga, error)
+	// Update applies a partial update; userID is the editor, empty for scripts
+	Update(ctx context.Context, userID, id string, req models.UpdateMangaRequest) (*models.Manga, error)
 	Delete(ctx context.Context, id string) error
 }
 
 type mangaService struct {
 	mangaRepo repository.MangaRepository
+	events    EventBus
 }
 
 // NewMangaService creates a new manga service
-func NewMangaService(mangaRepo repository.MangaRepository) MangaService {
+func NewMangaService(mangaRepo repository.MangaRepository, events EventBus) MangaService {
 	return &mangaService{
 		mangaRepo: mangaRepo,
+		events:    events,
 	}
 }
 
 // Create creates a new manga
-func (s *mangaService) Create(ctx context.Context, req models.CreateMangaRequest) (*models.Manga, error) {
+func (s *mangaService) Create(ctx context.Context, userID string, req models.CreateMangaRequest) (*models.Manga, error) {
 	if req.Title == "" {
-		return nil, fmt.Errorf("title is required")
+		return nil, fmt.Errorf("title is required: %w", models.ErrInvalidInput)
 	}
 	if req.Status == "" {
 		req.Status = "ongoing"
 	}
 	if req.Status != "ongoing" && req.Status != "completed" && req.Status != "hiatus" {
-		return nil, fmt.Errorf("invalid status: must be one of [ongoing, completed, hiatus]")
+		return nil, fmt.Errorf("invalid status (must be one of ongoing, completed, hiatus): %w", models.ErrInvalidInput)
 	}
 
 	manga := &models.Manga{
@@ -57,10 +61,16 @@ func (s *mangaService) Create(ctx context.Context, req models.CreateMangaRequest
 		CreatedAt:   time.Now(),
 	}
 
-	if err := s.mangaRepo.Create(ctx, manga, req.GenreIDs); err != nil {
+	if err := s.mangaRepo.Create(ctx, manga, req.GenreIDs, userID); err != nil {
 		return nil, fmt.Errorf("failed to create manga: %w", err)
 	}
 
+	event := MangaCreated{Manga: manga}
+	if userID != "" {
+		event.UserID = &userID
+	}
+	s.events.Publish(ctx, event)
+
 	return manga, nil
 }
 
@@ -162,7 +172,7 @@ func (s *mangaService) List(ctx context.Context, req models.MangaSearchRequest)
 // Search performs full-text search on manga
 func (s *mangaService) Search(ctx context.Context, query string, limit, offset int) (*models.MangaListResponse, error) {
 	if strings.TrimSpace(query) == "" {
-		return nil, fmt.Errorf("search query cannot be empty")
+		return nil, fmt.Errorf("search query cannot be empty: %w", models.ErrInvalidInput)
 	}
 	if limit <= 0 || limit > 100 {
 		limit = 20
@@ -194,11 +204,11 @@ func (s *mangaService) Search(ctx context.Context, query string, limit, offset i
 }
 
 // Update updates manga information
-func (s *mangaService) Update(ctx context.Context, id string, req models.UpdateMangaRequest) (*models.Manga, error) {
+func (s *mangaService) Update(ctx context.Context, userID, id string, req models.UpdateMangaRequest) (*models.Manga, error) {
 	if req.Status != nil {
 		status := *req.Status
 		if status != "ongoing" && status != "completed" && status != "hiatus" {
-			return nil, fmt.Errorf("invalid status: must be one of [ongoing, completed, hiatus]")
+			return nil, fmt.Errorf("invalid status (must be one of ongoing, completed, hiatus): %w", models.ErrInvalidInput)
 		}
 	}
 
@@ -206,7 +216,18 @@ func (s *mangaService) Update(ctx context.Context, id string, req models.UpdateM
 		return nil, fmt.Errorf("failed to update manga: %w", err)
 	}
 
-	return s.mangaRepo.GetByID(ctx, id)
+	manga, err := s.mangaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("manga not found: %w", err)
+	}
+
+	event := MangaUpdated{Manga: manga}
+	if userID != "" {
+		event.UserID = &userID
+	}
+	s.events.Publish(ctx, event)
+
+	return manga, nil
 }
 
 // Delete removes a manga
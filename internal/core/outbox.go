@@ -0,0 +1,403 @@
+// Package core - Outbox Relay
+// Delivers side effects recorded in the transactional outbox (stats events,
+// UDP notifications, chat room fan-out) with retries and dead-lettering
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"mangahub/internal/repository"
+	"mangahub/pkg/models"
+)
+
+// OutboxDeliverer hands one outbox event to its destination. Deliverers must
+// tolerate redelivery: an event whose settlement fails is delivered again.
+type OutboxDeliverer func(ctx context.Context, event *models.OutboxEvent) error
+
+// OutboxRelay delivers committed outbox events to registered destinations
+type OutboxRelay interface {
+	// Register sets the deliverer for a destination; call before Start
+	Register(destination string, deliver OutboxDeliverer)
+	Start()
+	Stop()
+}
+
+// OutboxConfig tunes the relay
+type OutboxConfig struct {
+	PollInterval time.Duration // Fallback poll when no NOTIFY arrives
+	BatchSize    int           // Events claimed per round
+	MaxAttempts  int           // Attempts before an event is dead-lettered
+	MaxBackoff   time.Duration // Upper bound for the retry delay
+	Retention    time.Duration // How long delivered events are kept
+}
+
+// outboxLease is how long a claimed event stays hidden from other relays
+const outboxLease = 30 * time.Second
+
+type outboxRelay struct {
+	repo    repository.OutboxRepository
+	cfg     OutboxConfig
+	workers map[string]*outboxWorker
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// outboxWorker delivers one destination's events, so a slow sink (a TCP
+// aggregator under load) only delays its own events and never live chat
+type outboxWorker struct {
+	destination string
+	deliver     OutboxDeliverer
+	wake        chan struct{}
+}
+
+// NewOutboxRelay creates a relay; register deliverers, then Start it
+func NewOutboxRelay(repo repository.OutboxRepository, cfg OutboxConfig) OutboxRelay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = 24 * time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &outboxRelay{
+		repo:    repo,
+		cfg:     cfg,
+		workers: make(map[string]*outboxWorker),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Register sets the deliverer for a destination
+func (r *outboxRelay) Register(destination string, deliver OutboxDeliverer) {
+	r.workers[destination] = &outboxWorker{
+		destination: destination,
+		deliver:     deliver,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Start runs a delivery loop per destination, the NOTIFY listener and the
+// purge loop
+func (r *outboxRelay) Start() {
+	r.wg.Add(len(r.workers) + 2)
+	for _, w := range r.workers {
+		go r.run(w)
+	}
+	go r.listen()
+	go r.purgeLoop()
+	logrus.Infof("Outbox relay started with %d destinations", len(r.workers))
+}
+
+// Stop ends delivery after the current event
+func (r *outboxRelay) Stop() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// notify wakes a destination's worker without blocking; an unknown
+// destination wakes every worker
+func (r *outboxRelay) notify(destination string) {
+	if w, ok := r.workers[destination]; ok {
+		w.signal()
+		return
+	}
+	for _, w := range r.workers {
+		w.signal()
+	}
+}
+
+func (w *outboxWorker) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// listen wakes workers on commit notifications, reconnecting on failure
+func (r *outboxRelay) listen() {
+	defer r.wg.Done()
+
+	for {
+		if err := r.repo.Listen(r.ctx, r.notify); err != nil {
+			logrus.Warnf("Outbox listener failed, polling until it reconnects: %v", err)
+		}
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+}
+
+// run delivers a destination's due events on wake-ups and on the poll interval
+func (r *outboxRelay) run(w *outboxWorker) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	r.drain(w)
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-w.wake:
+			r.drain(w)
+		case <-ticker.C:
+			r.drain(w)
+		}
+	}
+}
+
+// purgeLoop drops old delivered events every hour
+func (r *outboxRelay) purgeLoop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.purge()
+		}
+	}
+}
+
+// drain claims and delivers a destination's events until none are due.
+// Delivered events are settled together once per round, or one by one once
+// the round has used half the lease, so they are not claimed again.
+func (r *outboxRelay) drain(w *outboxWorker) {
+	for r.ctx.Err() == nil {
+		claimed := time.Now()
+		events, err := r.repo.Claim(r.ctx, w.destination, r.cfg.BatchSize, outboxLease)
+		if err != nil {
+			if r.ctx.Err() == nil {
+				logrus.Errorf("Failed to claim %s outbox events: %v", w.destination, err)
+			}
+			return
+		}
+
+		var delivered []string
+		for _, event := range events {
+			if r.deliver(w, event) {
+				delivered = append(delivered, event.ID)
+			}
+			if time.Since(claimed) > outboxLease/2 {
+				r.settle(delivered)
+				delivered = nil
+			}
+		}
+		r.settle(delivered)
+
+		if len(events) < r.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// settle marks delivered events, even during shutdown so they are not sent
+// again
+func (r *outboxRelay) settle(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := r.repo.MarkDelivered(ctx, ids); err != nil {
+		logrus.Errorf("Failed to mark %d outbox events delivered: %v", len(ids), err)
+	}
+}
+
+// deliver sends one event, retrying or dead-lettering it on failure; it
+// reports whether the event was delivered
+func (r *outboxRelay) deliver(w *outboxWorker, event *models.OutboxEvent) bool {
+	ctx, cancel := context.WithTimeout(r.ctx, outboxLease/2)
+	defer cancel()
+
+	err := w.deliver(ctx, event)
+	if err == nil {
+		return true
+	}
+
+	// Settle even during shutdown so the retry schedule is kept
+	settleCtx, settleCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer settleCancel()
+
+	log := logrus.WithFields(logrus.Fields{
+		"outbox_id":   event.ID,
+		"destination": event.Destination,
+		"attempts":    event.Attempts,
+	})
+
+	if event.Attempts >= r.cfg.MaxAttempts {
+		log.Errorf("Outbox event dead-lettered: %v", err)
+		if err := r.repo.MarkDead(settleCtx, event.ID, err.Error()); err != nil {
+			log.Errorf("Failed to dead-letter outbox event: %v", err)
+		}
+		return false
+	}
+
+	log.Warnf("Outbox delivery failed, will retry: %v", err)
+	if err := r.repo.MarkFailed(settleCtx, event.ID, err.Error(), time.Now().Add(r.backoff(event.Attempts))); err != nil {
+		log.Errorf("Failed to reschedule outbox event: %v", err)
+	}
+	return false
+}
+
+// backoff doubles from one second per attempt, up to MaxBackoff
+func (r *outboxRelay) backoff(attempts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempts && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.cfg.MaxBackoff {
+		delay = r.cfg.MaxBackoff
+	}
+	return delay
+}
+
+// purge drops delivered events past the retention period
+func (r *outboxRelay) purge() {
+	removed, err := r.repo.PurgeDelivered(r.ctx, time.Now().Add(-r.cfg.Retention))
+	if err != nil {
+		logrus.Errorf("Failed to purge outbox events: %v", err)
+		return
+	}
+	if removed > 0 {
+		logrus.Infof("Purged %d delivered outbox events", removed)
+	}
+}
+
+// StatsWriter delivers stats events straight to the database with the
+// scoring engine, for deployments that run without the TCP aggregator
+func StatsWriter(statsRepo repository.StatsRepository, scoring *ScoringEngine) OutboxDeliverer {
+	return func(ctx context.Context, event *models.OutboxEvent) error {
+		var payload models.OutboxStatsPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("invalid stats payload: %w", err)
+		}
+
+		update := models.StatsUpdate{
+			MangaID:     payload.MangaID,
+			Day:         payload.EventTime.Local().Format("2006-01-02"),
+			WeeklyScore: scoring.Points(payload.Type),
+		}
+		switch payload.Type {
+		case models.ActivityTypeComment:
+			update.CommentCount = 1
+		case models.ActivityTypeChat:
+			update.ChatCount = 1
+		case models.ScoreEventLike:
+			update.LikeCount = 1
+		}
+		// Keyed on the outbox event, so a redelivery is not counted twice
+		err := statsRepo.BatchUpdateStats(ctx, []models.StatsUpdate{update}, []string{event.ID})
+		var dupes *repository.DuplicateEventsError
+		if errors.As(err, &dupes) {
+			return nil
+		}
+		return err
+	}
+}
+
+// SubscribeStats enqueues a stats event for every comment, like and chat
+// message. The rows are written just after the change commits; an increment
+// lost to a crash in between is recounted by the next stats rebuild.
+func SubscribeStats(events EventBus, outbox repository.OutboxRepository) {
+	events.Subscribe(EventCommentCreated, func(ctx context.Context, event DomainEvent) {
+		comment := event.(CommentCreated).Comment
+		enqueueOutbox(ctx, outbox, models.OutboxStats, models.OutboxStatsPayload{
+			Type:      models.ActivityTypeComment,
+			MangaID:   comment.MangaID,
+			UserID:    &comment.User.ID,
+			EventTime: comment.CreatedAt,
+		})
+	})
+	events.Subscribe(EventCommentLiked, func(ctx context.Context, event DomainEvent) {
+		liked := event.(CommentLiked)
+		enqueueOutbox(ctx, outbox, models.OutboxStats, models.OutboxStatsPayload{
+			Type:      models.ScoreEventLike,
+			MangaID:   liked.Comment.MangaID,
+			UserID:    &liked.UserID,
+			EventTime: liked.LikedAt,
+		})
+	})
+	events.Subscribe(EventChatMessageSent, func(ctx context.Context, event DomainEvent) {
+		message := event.(ChatMessageSent).Message
+		enqueueOutbox(ctx, outbox, models.OutboxStats, models.OutboxStatsPayload{
+			Type:      models.ActivityTypeChat,
+			MangaID:   message.MangaID,
+			UserID:    &message.User.ID,
+			EventTime: message.CreatedAt,
+		})
+	})
+}
+
+// SubscribeNotifications enqueues a UDP notification for every new comment;
+// clients that filter by manga only get those for the manga they follow
+func SubscribeNotifications(events EventBus, outbox repository.OutboxRepository) {
+	events.Subscribe(EventCommentCreated, func(ctx context.Context, event DomainEvent) {
+		comment := event.(CommentCreated).Comment
+		enqueueOutbox(ctx, outbox, models.OutboxNotification, models.OutboxNotificationPayload{
+			Type:    models.ActivityTypeComment,
+			MangaID: &comment.MangaID,
+			Message: fmt.Sprintf("New comment by %s", comment.User.Username),
+		})
+	})
+}
+
+// SubscribeChatRooms enqueues the WebSocket room fan-out of every new chat
+// message. Edits, deletes and reactions are written to the outbox in the
+// transaction that makes them.
+func SubscribeChatRooms(events EventBus, outbox repository.OutboxRepository) {
+	events.Subscribe(EventChatMessageSent, func(ctx context.Context, event DomainEvent) {
+		message := event.(ChatMessageSent).Message
+		enqueueOutbox(ctx, outbox, models.OutboxChat, models.OutboxChatPayload{
+			MessageID: message.ID,
+			MangaID:   message.MangaID,
+			UserID:    message.User.ID,
+			Username:  message.User.Username,
+			Content:   message.Content,
+			CreatedAt: message.CreatedAt,
+			ReplyTo:   message.ReplyTo,
+			Mentions:  message.Mentions,
+		})
+	})
+}
+
+// enqueueOutbox writes a subscriber's outbox row. The change it follows has
+// already committed, so the write outlives the request and a failure is only
+// logged.
+func enqueueOutbox(ctx context.Context, outbox repository.OutboxRepository, destination string, payload interface{}) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err := outbox.Enqueue(ctx, destination, payload); err != nil {
+		logrus.Errorf("Failed to enqueue %s outbox event: %v", destination, err)
+	}
+}
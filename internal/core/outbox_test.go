@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mangahub/internal/repository"
+	"mangahub/pkg/models"
+)
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBackoff time.Duration
+		attempts   int
+		want       time.Duration
+	}{
+		{name: "before the first attempt", maxBackoff: 5 * time.Minute, attempts: 0, want: time.Second},
+		{name: "first attempt", maxBackoff: 5 * time.Minute, attempts: 1, want: time.Second},
+		{name: "second attempt doubles", maxBackoff: 5 * time.Minute, attempts: 2, want: 2 * time.Second},
+		{name: "fifth attempt", maxBackoff: 5 * time.Minute, attempts: 5, want: 16 * time.Second},
+		{name: "capped", maxBackoff: 5 * time.Minute, attempts: 10, want: 5 * time.Minute},
+		{name: "many attempts do not overflow", maxBackoff: 5 * time.Minute, attempts: 1000, want: 5 * time.Minute},
+		{name: "cap below one second", maxBackoff: 500 * time.Millisecond, attempts: 1, want: 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewOutboxRelay(nil, OutboxConfig{MaxBackoff: tt.maxBackoff}).(*outboxRelay)
+			assert.Equal(t, tt.want, r.backoff(tt.attempts))
+		})
+	}
+}
+
+// fakeOutbox records enqueued events; the relay-side methods are unused
+type fakeOutbox struct {
+	repository.OutboxRepository
+	enqueued map[string][]interface{} // Destination -> payloads
+}
+
+func (o *fakeOutbox) Enqueue(ctx context.Context, destination string, payload interface{}) error {
+	o.enqueued[destination] = append(o.enqueued[destination], payload)
+	return nil
+}
+
+func TestOutboxSubscribers(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	likedAt := createdAt.Add(time.Hour)
+	replyTo := "msg-0"
+	comment := &models.CommentResponse{
+		ID:        "comm-1",
+		MangaID:   "m1",
+		User:      models.CommentUser{ID: "u1", Username: "alice"},
+		CreatedAt: createdAt,
+	}
+	message := &models.ChatMessageResponse{
+		ID:        "msg-1",
+		MangaID:   "m1",
+		User:      models.ChatUser{ID: "u2", Username: "bob"},
+		Content:   "hi @alice",
+		CreatedAt: createdAt,
+		ReplyTo:   &replyTo,
+		Mentions:  []string{"u1"},
+	}
+	liker, author, chatter, mangaID := "u3", "u1", "u2", "m1"
+
+	tests := []struct {
+		name  string
+		event DomainEvent
+		want  map[string][]interface{}
+	}{
+		{
+			name:  "comment created",
+			event: CommentCreated{Comment: comment},
+			want: map[string][]interface{}{
+				models.OutboxStats: {models.OutboxStatsPayload{Type: models.ActivityTypeComment, MangaID: "m1", UserID: &author, EventTime: createdAt}},
+				models.OutboxNotification: {models.OutboxNotificationPayload{
+					Type: models.ActivityTypeComment, MangaID: &mangaID, Message: "New comment by alice",
+				}},
+			},
+		},
+		{
+			name:  "comment liked at the like time",
+			event: CommentLiked{Comment: comment, UserID: liker, LikedAt: likedAt},
+			want: map[string][]interface{}{
+				models.OutboxStats: {models.OutboxStatsPayload{Type: models.ScoreEventLike, MangaID: "m1", UserID: &liker, EventTime: likedAt}},
+			},
+		},
+		{
+			name:  "chat message sent",
+			event: ChatMessageSent{Message: message},
+			want: map[string][]interface{}{
+				models.OutboxStats: {models.OutboxStatsPayload{Type: models.ActivityTypeChat, MangaID: "m1", UserID: &chatter, EventTime: createdAt}},
+				models.OutboxChat: {models.OutboxChatPayload{
+					MessageID: "msg-1",
+					MangaID:   "m1",
+					UserID:    "u2",
+					Username:  "bob",
+					Content:   "hi @alice",
+					CreatedAt: createdAt,
+					ReplyTo:   &replyTo,
+					Mentions:  []string{"u1"},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &fakeOutbox{enqueued: make(map[string][]interface{})}
+			events := NewEventBus()
+			SubscribeStats(events, outbox)
+			SubscribeNotifications(events, outbox)
+			SubscribeChatRooms(events, outbox)
+
+			events.Publish(context.Background(), tt.event)
+			assert.Equal(t, tt.want, outbox.enqueued)
+		})
+	}
+}
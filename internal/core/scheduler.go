@@ -0,0 +1,239 @@
+// Package core - Scheduled Jobs
+// Runs periodic stats maintenance (weekly score decay, full stats rebuild)
+// on cron-like schedules, with a Postgres advisory lock per job so only one
+// replica runs a job at a time
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+
+	"mangahub/internal/repository"
+	"mangahub/pkg/models"
+)
+
+var (
+	ErrUnknownJob        = errors.New("unknown job")
+	ErrJobAlreadyRunning = errors.New("job is already running")
+)
+
+// jobTimeout bounds a single job run
+const jobTimeout = 10 * time.Minute
+
+// SchedulerService runs and reports on background maintenance jobs
+type SchedulerService interface {
+	Start()
+	Stop()
+	Trigger(ctx context.Context, jobName, triggeredBy string) (*models.JobRun, error)
+	ListJobs(ctx context.Context) ([]models.JobInfo, error)
+}
+
+// SchedulerConfig holds the job schedules (standard 5-field cron or
+// descriptors such as "@hourly"). An empty schedule disables the job's timer;
+// it can still be triggered manually.
+type SchedulerConfig struct {
+	DecaySchedule   string
+	RebuildSchedule string
+}
+
+type scheduledJob struct {
+	name     string
+	schedule string
+	entryID  cron.EntryID
+	run      func(ctx context.Context) error
+}
+
+type schedulerService struct {
+	jobRepo  repository.JobRepository
+	cron     *cron.Cron
+	jobs     map[string]*scheduledJob
+	instance string
+	wg       sync.WaitGroup
+}
+
+// NewSchedulerService creates the job scheduler. Invalid schedules are reported
+// here so misconfiguration fails at startup rather than silently never running.
+func NewSchedulerService(
+	jobRepo repository.JobRepository,
+	statsRepo repository.StatsRepository,
+	dailyRepo repository.DailyStatsRepository,
+	scoring *ScoringEngine,
+	cfg SchedulerConfig,
+) (SchedulerService, error) {
+	instance, _ := os.Hostname()
+
+	s := &schedulerService{
+		jobRepo:  jobRepo,
+		cron:     cron.New(),
+		jobs:     make(map[string]*scheduledJob),
+		instance: instance,
+	}
+
+	s.jobs[models.JobScoreDecay] = &scheduledJob{
+		name:     models.JobScoreDecay,
+		schedule: cfg.DecaySchedule,
+		run: func(ctx context.Context) error {
+			return statsRepo.RecalculateWeeklyScores(ctx, scoring.Formula())
+		},
+	}
+
+	// A rebuild recounts the counters from the source tables and then
+	// recomputes the decayed weekly scores. It also backfills daily stats for
+	// days the aggregator never saw (e.g. before the series existed).
+	s.jobs[models.JobStatsRebuild] = &scheduledJob{
+		name:     models.JobStatsRebuild,
+		schedule: cfg.RebuildSchedule,
+		run: func(ctx context.Context) error {
+			if err := statsRepo.RebuildAllStats(ctx); err != nil {
+				return err
+			}
+			if err := statsRepo.RecalculateWeeklyScores(ctx, scoring.Formula()); err != nil {
+				return err
+			}
+			return dailyRepo.Backfill(ctx, time.Now().AddDate(0, 0, -90), scoring.Formula())
+		},
+	}
+
+	for _, job := range s.jobs {
+		if job.schedule == "" {
+			continue
+		}
+		job := job
+		id, err := s.cron.AddFunc(job.schedule, func() {
+			if _, err := s.execute(context.Background(), job, "schedule"); err != nil {
+				logrus.WithField("job", job.name).Errorf("Scheduled job failed: %v", err)
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q for job %s: %w", job.schedule, job.name, err)
+		}
+		job.entryID = id
+	}
+
+	return s, nil
+}
+
+// Start begins running jobs on their schedules
+func (s *schedulerService) Start() {
+	s.cron.Start()
+	logrus.Info("Job scheduler started")
+}
+
+// Stop stops the timers and waits for running jobs to finish
+func (s *schedulerService) Stop() {
+	<-s.cron.Stop().Done()
+	s.wg.Wait()
+}
+
+// Trigger runs a job immediately, still respecting the cross-replica lock
+func (s *schedulerService) Trigger(ctx context.Context, jobName, triggeredBy string) (*models.JobRun, error) {
+	job, ok := s.jobs[jobName]
+	if !ok {
+		return nil, ErrUnknownJob
+	}
+
+	// Detach from the request so a client disconnect does not abort the job
+	run, err := s.execute(context.WithoutCancel(ctx), job, triggeredBy)
+	if run == nil {
+		return nil, err
+	}
+	if run.Status == models.JobStatusSkipped {
+		return run, ErrJobAlreadyRunning
+	}
+	// A failed run is returned alongside the job's error
+	return run, err
+}
+
+// ListJobs returns every job with its schedule, next run and last recorded run
+func (s *schedulerService) ListJobs(ctx context.Context) ([]models.JobInfo, error) {
+	jobs := make([]models.JobInfo, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		info := models.JobInfo{
+			Name:     job.name,
+			Schedule: job.schedule,
+		}
+
+		if job.entryID != 0 {
+			if next := s.cron.Entry(job.entryID).Next; !next.IsZero() {
+				info.NextRun = &next
+			}
+		}
+
+		lastRun, err := s.jobRepo.GetLastRun(ctx, job.name)
+		if err != nil && !errors.Is(err, models.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get last run for %s: %w", job.name, err)
+		}
+		info.LastRun = lastRun
+
+		jobs = append(jobs, info)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs, nil
+}
+
+// execute runs one job under its advisory lock and records the outcome.
+// If another replica holds the lock, the run is skipped and not recorded.
+func (s *schedulerService) execute(ctx context.Context, job *scheduledJob, triggeredBy string) (*models.JobRun, error) {
+	s.wg.Add(1)
+	defer s.wg.Done()
+
+	ctx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	run := &models.JobRun{
+		JobName:     job.name,
+		Status:      models.JobStatusRunning,
+		TriggeredBy: triggeredBy,
+		Instance:    s.instance,
+		StartedAt:   time.Now(),
+	}
+
+	release, acquired, err := s.jobRepo.TryLock(ctx, job.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock job %s: %w", job.name, err)
+	}
+	if !acquired {
+		run.Status = models.JobStatusSkipped
+		logrus.WithField("job", job.name).Info("Job skipped: running on another instance")
+		return run, nil
+	}
+	defer release()
+
+	if err := s.jobRepo.SaveRun(ctx, run); err != nil {
+		logrus.WithField("job", job.name).Warnf("Failed to record job start: %v", err)
+	}
+
+	jobErr := job.run(ctx)
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(run.StartedAt).Milliseconds()
+	run.Status = models.JobStatusSucceeded
+	if jobErr != nil {
+		run.Status = models.JobStatusFailed
+		run.Error = jobErr.Error()
+	}
+
+	// Record the outcome even if the job ran out of time
+	if err := s.jobRepo.SaveRun(context.WithoutCancel(ctx), run); err != nil {
+		logrus.WithField("job", job.name).Warnf("Failed to record job result: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job":          job.name,
+		"status":       run.Status,
+		"duration_ms":  run.DurationMs,
+		"triggered_by": triggeredBy,
+	}).Info("Job finished")
+
+	return run, jobErr
+}
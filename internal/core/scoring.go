@@ -0,0 +1,77 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"mangahub/pkg/models"
+)
+
+// ScoringEngine is the single source of manga activity scores. Trending
+// rankings, weekly scores and live TCP increments all weight events with the
+// same formula, so every client sees the same ordering.
+type ScoringEngine struct {
+	formula models.ScoringFormula
+}
+
+// NewScoringEngine validates a scoring formula
+func NewScoringEngine(formula models.ScoringFormula) (*ScoringEngine, error) {
+	weights := map[string]float64{
+		"comment": formula.CommentWeight,
+		"like":    formula.LikeWeight,
+		"chat":    formula.ChatWeight,
+		"update":  formula.UpdateWeight,
+	}
+	for name, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("%s weight must not be negative, got %v", name, weight)
+		}
+	}
+	if formula.HalfLife <= 0 {
+		return nil, fmt.Errorf("half-life must be positive, got %v", formula.HalfLife)
+	}
+
+	return &ScoringEngine{formula: formula}, nil
+}
+
+// Formula returns the weights and half-life the engine scores with
+func (e *ScoringEngine) Formula() models.ScoringFormula {
+	return e.formula
+}
+
+// Weight returns the points a fresh event of the given type (an activity_feed
+// type or models.ScoreEventLike) is worth. Unknown types score nothing.
+func (e *ScoringEngine) Weight(eventType string) float64 {
+	switch eventType {
+	case models.ActivityTypeComment:
+		return e.formula.CommentWeight
+	case models.ScoreEventLike:
+		return e.formula.LikeWeight
+	case models.ActivityTypeChat:
+		return e.formula.ChatWeight
+	case models.ActivityTypeMangaUpdate:
+		return e.formula.UpdateWeight
+	default:
+		return 0
+	}
+}
+
+// Points returns Weight rounded to whole weekly_score points
+func (e *ScoringEngine) Points(eventType string) int {
+	return int(math.Round(e.Weight(eventType)))
+}
+
+// WindowDuration maps a trending window (24h, 7d or 30d) to its length
+func WindowDuration(window string) (time.Duration, error) {
+	switch window {
+	case models.TrendingWindowDay:
+		return 24 * time.Hour, nil
+	case models.TrendingWindowWeek:
+		return 7 * 24 * time.Hour, nil
+	case models.TrendingWindowMonth:
+		return 30 * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("unknown trending window %q (must be 24h, 7d or 30d): %w", window, models.ErrInvalidInput)
+	}
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mangahub/pkg/models"
+)
+
+func TestNewScoringEngine(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(f *models.ScoringFormula)
+		wantErr string
+	}{
+		{name: "valid", modify: func(f *models.ScoringFormula) {}},
+		{name: "zero weight", modify: func(f *models.ScoringFormula) { f.ChatWeight = 0 }},
+		{name: "negative weight", modify: func(f *models.ScoringFormula) { f.LikeWeight = -1 }, wantErr: "like weight must not be negative"},
+		{name: "zero half-life", modify: func(f *models.ScoringFormula) { f.HalfLife = 0 }, wantErr: "half-life must be positive"},
+		{name: "negative half-life", modify: func(f *models.ScoringFormula) { f.HalfLife = -time.Hour }, wantErr: "half-life must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formula := models.ScoringFormula{CommentWeight: 3, LikeWeight: 1, ChatWeight: 1, UpdateWeight: 5, HalfLife: time.Hour}
+			tt.modify(&formula)
+
+			engine, err := NewScoringEngine(formula)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, formula, engine.Formula())
+		})
+	}
+}
+
+func TestScoringEngineWeight(t *testing.T) {
+	engine, err := NewScoringEngine(models.ScoringFormula{
+		CommentWeight: 3,
+		LikeWeight:    1.5,
+		ChatWeight:    0.4,
+		UpdateWeight:  5,
+		HalfLife:      24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		eventType  string
+		wantWeight float64
+		wantPoints int
+	}{
+		{eventType: models.ActivityTypeComment, wantWeight: 3, wantPoints: 3},
+		{eventType: models.ScoreEventLike, wantWeight: 1.5, wantPoints: 2},
+		{eventType: models.ActivityTypeChat, wantWeight: 0.4, wantPoints: 0},
+		{eventType: models.ActivityTypeMangaUpdate, wantWeight: 5, wantPoints: 5},
+		{eventType: "unknown", wantWeight: 0, wantPoints: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			assert.Equal(t, tt.wantWeight, engine.Weight(tt.eventType))
+			assert.Equal(t, tt.wantPoints, engine.Points(tt.eventType))
+		})
+	}
+}
+
+func TestWindowDuration(t *testing.T) {
+	tests := []struct {
+		window  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{window: models.TrendingWindowDay, want: 24 * time.Hour},
+		{window: models.TrendingWindowWeek, want: 7 * 24 * time.Hour},
+		{window: models.TrendingWindowMonth, want: 30 * 24 * time.Hour},
+		{window: "1y", wantErr: true},
+		{window: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.window, func(t *testing.T) {
+			got, err := WindowDuration(tt.window)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, models.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
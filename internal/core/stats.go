@@ -3,6 +3,8 @@ package core
 import (
 	"context"
 	"fmt"
+	"math"
+	"time"
 
 	"mangahub/internal/repository"
 	"mangahub/pkg/models"
@@ -16,21 +18,34 @@ type StatsService interface {
 	IncrementChatCount(ctx context.Context, mangaID string) error
 	GetTopManga(ctx context.Context, limit, offset int) (*models.RankedMangaResponse, error)
 	CalculateWeeklyScore(ctx context.Context, mangaID string) error
+	GetTrending(ctx context.Context, window string, limit int) (*models.TrendingResponse, error)
+	GetMangaTimeSeries(ctx context.Context, mangaID string, days int) (*models.StatsTimeSeries, error)
+	GetGlobalTimeSeries(ctx context.Context, days int) (*models.StatsTimeSeries, error)
+	GetLeaderboard(ctx context.Context, category, window string, limit int) (*models.LeaderboardResponse, error)
 }
 
 type statsService struct {
-	statsRepo repository.StatsRepository
-	mangaRepo repository.MangaRepository
+	statsRepo       repository.StatsRepository
+	mangaRepo       repository.MangaRepository
+	leaderboardRepo repository.LeaderboardRepository
+	dailyRepo       repository.DailyStatsRepository
+	scoring         *ScoringEngine
 }
 
 // NewStatsService creates a new statistics service
 func NewStatsService(
 	statsRepo repository.StatsRepository,
 	mangaRepo repository.MangaRepository,
+	leaderboardRepo repository.LeaderboardRepository,
+	dailyRepo repository.DailyStatsRepository,
+	scoring *ScoringEngine,
 ) StatsService {
 	return &statsService{
-		statsRepo: statsRepo,
-		mangaRepo: mangaRepo,
+		statsRepo:       statsRepo,
+		mangaRepo:       mangaRepo,
+		leaderboardRepo: leaderboardRepo,
+		dailyRepo:       dailyRepo,
+		scoring:         scoring,
 	}
 }
 
@@ -129,19 +144,152 @@ func (s *statsService) GetTopManga(ctx context.Context, limit, offset int) (*mod
 	}, nil
 }
 
-// CalculateWeeklyScore calculates and updates the weekly score for a manga
-// Formula (UseCase): comments * 1 + likes * 1 + chats * 2
+// CalculateWeeklyScore recomputes the weekly score for a manga from its last
+// 7 days of activity using the scoring engine's formula
 func (s *statsService) CalculateWeeklyScore(ctx context.Context, mangaID string) error {
-	stats, err := s.statsRepo.GetByMangaID(ctx, mangaID)
+	score, err := s.statsRepo.GetScore(ctx, mangaID, time.Now().AddDate(0, 0, -7), s.scoring.Formula())
 	if err != nil {
-		return fmt.Errorf("failed to get stats: %w", err)
+		return fmt.Errorf("failed to get score: %w", err)
 	}
 
-	weeklyScore := (stats.CommentCount * 1) + (stats.LikeCount * 1) + (stats.ChatCount * 2)
-
-	if err := s.statsRepo.UpdateWeeklyScore(ctx, mangaID, weeklyScore); err != nil {
+	if err := s.statsRepo.UpdateWeeklyScore(ctx, mangaID, int(math.Round(score))); err != nil {
 		return fmt.Errorf("failed to update weekly score: %w", err)
 	}
 
 	return nil
 }
+
+// GetTrending ranks manga by decayed activity over a window (24h, 7d or 30d).
+// An empty window defaults to 7d.
+func (s *statsService) GetTrending(ctx context.Context, window string, limit int) (*models.TrendingResponse, error) {
+	if window == "" {
+		window = models.TrendingWindowWeek
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	length, err := WindowDuration(window)
+	if err != nil {
+		return nil, err
+	}
+
+	trending, err := s.statsRepo.GetTrendingManga(ctx, time.Now().Add(-length), s.scoring.Formula(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trending manga: %w", err)
+	}
+
+	entries := []models.TrendingEntry{}
+	for _, t := range trending {
+		manga, err := s.mangaRepo.GetByID(ctx, t.MangaID)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, models.TrendingEntry{
+			Manga:         *manga,
+			Score:         t.Score,
+			ActivityCount: t.ActivityCount,
+			Rank:          len(entries) + 1,
+		})
+	}
+
+	return &models.TrendingResponse{
+		Window: window,
+		Data:   entries,
+	}, nil
+}
+
+// GetLeaderboard ranks users by contribution for a category over a time
+// window (weekly, monthly or all_time). Empty values default to commenters
+// and weekly.
+func (s *statsService) GetLeaderboard(ctx context.Context, category, window string, limit int) (*models.LeaderboardResponse, error) {
+	if category == "" {
+		category = models.LeaderboardCommenters
+	}
+	if window == "" {
+		window = models.LeaderboardWeekly
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	var since *time.Time
+	switch window {
+	case models.LeaderboardWeekly:
+		t := time.Now().AddDate(0, 0, -7)
+		since = &t
+	case models.LeaderboardMonthly:
+		t := time.Now().AddDate(0, 0, -30)
+		since = &t
+	case models.LeaderboardAllTime:
+	default:
+		return nil, fmt.Errorf("unknown leaderboard window %q: %w", window, models.ErrInvalidInput)
+	}
+
+	entries, err := s.leaderboardRepo.GetLeaderboard(ctx, category, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	return &models.LeaderboardResponse{
+		Category: category,
+		Window:   window,
+		Entries:  entries,
+	}, nil
+}
+
+// GetMangaTimeSeries returns a manga's daily stats for the last days days
+// (default 30, at most 365), ending today
+func (s *statsService) GetMangaTimeSeries(ctx context.Context, mangaID string, days int) (*models.StatsTimeSeries, error) {
+	days = seriesDays(days)
+
+	if _, err := s.mangaRepo.GetByID(ctx, mangaID); err != nil {
+		return nil, fmt.Errorf("failed to get manga: %w", err)
+	}
+
+	points, err := s.dailyRepo.GetMangaSeries(ctx, mangaID, seriesStart(days))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manga time series: %w", err)
+	}
+
+	return &models.StatsTimeSeries{
+		MangaID: mangaID,
+		Days:    days,
+		Points:  points,
+	}, nil
+}
+
+// GetGlobalTimeSeries returns daily stats summed over every manga
+func (s *statsService) GetGlobalTimeSeries(ctx context.Context, days int) (*models.StatsTimeSeries, error) {
+	days = seriesDays(days)
+
+	points, err := s.dailyRepo.GetGlobalSeries(ctx, seriesStart(days))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get global time series: %w", err)
+	}
+
+	return &models.StatsTimeSeries{
+		Days:   days,
+		Points: points,
+	}, nil
+}
+
+// maxSeriesDays caps a requested series length
+const maxSeriesDays = 365
+
+// seriesDays applies the default and cap to a requested series length
+func seriesDays(days int) int {
+	if days <= 0 {
+		return 30
+	}
+	if days > maxSeriesDays {
+		return maxSeriesDays
+	}
+	return days
+}
+
+// seriesStart returns the first day of a series of days days ending today
+func seriesStart(days int) time.Time {
+	return time.Now().AddDate(0, 0, -(days - 1))
+}
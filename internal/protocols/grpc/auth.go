@@ -0,0 +1,244 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+
+	"mangahub/internal/core"
+	pb "mangahub/internal/protocols/grpc/pb"
+	"mangahub/pkg/models"
+)
+
+// accessLevel describes who may call an RPC
+type accessLevel int
+
+const (
+	accessOpen   accessLevel = iota // never requires credentials (health probes)
+	accessPublic                    // anonymous unless the server requires auth
+	accessUser                      // any authenticated caller
+	accessAdmin                     // callers with the admin role
+)
+
+// methodPolicy is the access rule for one fully-qualified RPC method.
+// scope is the API key scope that allows key-based callers through.
+type methodPolicy struct {
+	access accessLevel
+	scope  models.APIKeyScope
+}
+
+// methodPolicies maps RPC methods to access rules; unlisted methods require a user
+var methodPolicies = map[string]methodPolicy{
+	pb.MangaService_StreamSearch_FullMethodName:     {access: accessPublic},
+	pb.MangaService_SearchManga_FullMethodName:      {access: accessPublic},
+	pb.MangaService_GetManga_FullMethodName:         {access: accessPublic},
+	pb.MangaService_GetTrendingManga_FullMethodName: {access: accessUser, scope: models.ScopeStatsRead},
+	pb.MangaService_AutoSuggest_FullMethodName:      {access: accessPublic},
+	pb.MangaService_HealthCheck_FullMethodName:      {access: accessOpen},
+	pb.MangaService_CreateManga_FullMethodName:      {access: accessAdmin, scope: models.ScopeMangaWrite},
+	pb.MangaService_UpdateManga_FullMethodName:      {access: accessAdmin, scope: models.ScopeMangaWrite},
+	pb.MangaService_DeleteManga_FullMethodName:      {access: accessAdmin, scope: models.ScopeMangaWrite},
+	pb.MangaService_ListComments_FullMethodName:     {access: accessPublic},
+	pb.MangaService_CreateComment_FullMethodName:    {access: accessUser},
+	pb.MangaService_GetChatHistory_FullMethodName:   {access: accessPublic},
+	pb.MangaService_GetLeaderboard_FullMethodName:   {access: accessUser, scope: models.ScopeStatsRead},
+	pb.ChatService_Join_FullMethodName:              {access: accessUser},
+
+	// Probes and tooling must work without credentials
+	grpc_health_v1.Health_Check_FullMethodName:                                   {access: accessOpen},
+	grpc_health_v1.Health_List_FullMethodName:                                    {access: accessOpen},
+	grpc_health_v1.Health_Watch_FullMethodName:                                   {access: accessOpen},
+	grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName:      {access: accessOpen},
+	grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: {access: accessOpen},
+}
+
+type principalKey struct{}
+
+// principal is the authenticated caller attached to a request context
+type principal struct {
+	user   *models.User
+	apiKey *models.APIKey
+}
+
+// UserFromContext returns the authenticated user for a gRPC call, if any
+func UserFromContext(ctx context.Context) (*models.User, bool) {
+	p, ok := ctx.Value(principalKey{}).(*principal)
+	if !ok || p.user == nil {
+		return nil, false
+	}
+	return p.user, true
+}
+
+// APIKeyFromContext returns the API key used to authenticate a gRPC call, if any
+func APIKeyFromContext(ctx context.Context) (*models.APIKey, bool) {
+	p, ok := ctx.Value(principalKey{}).(*principal)
+	if !ok || p.apiKey == nil {
+		return nil, false
+	}
+	return p.apiKey, true
+}
+
+// AuthInterceptor authenticates gRPC calls from "authorization" metadata
+// ("Bearer <jwt>" or "ApiKey <key>") and enforces per-method access rules
+type AuthInterceptor struct {
+	authSvc     core.AuthService
+	apiKeySvc   core.APIKeyService
+	requireAuth bool
+}
+
+// NewAuthInterceptor creates an auth interceptor. When requireAuth is set,
+// public read methods also require credentials.
+func NewAuthInterceptor(authSvc core.AuthService, apiKeySvc core.APIKeyService, requireAuth bool) *AuthInterceptor {
+	return &AuthInterceptor{
+		authSvc:     authSvc,
+		apiKeySvc:   apiKeySvc,
+		requireAuth: requireAuth,
+	}
+}
+
+// Unary returns the unary server interceptor
+func (a *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			a.audit(ctx, info.FullMethod, start, err)
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		a.audit(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// Stream returns the stream server interceptor
+func (a *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			a.audit(ss.Context(), info.FullMethod, start, err)
+			return err
+		}
+
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		err = handler(srv, wrapped)
+		a.audit(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// authorize resolves the caller and checks it against the method policy
+func (a *AuthInterceptor) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	policy, known := methodPolicies[fullMethod]
+	if !known {
+		policy = methodPolicy{access: accessUser}
+	}
+
+	scheme, credential, present := authorizationFromMetadata(ctx)
+	if !present {
+		if policy.access == accessOpen || (policy.access == accessPublic && !a.requireAuth) {
+			return ctx, nil
+		}
+		return ctx, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	p := &principal{}
+	switch scheme {
+	case "Bearer":
+		user, err := a.authSvc.ValidateToken(ctx, credential)
+		if err != nil {
+			return ctx, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+		p.user = user
+
+	case "ApiKey":
+		if a.apiKeySvc == nil {
+			return ctx, status.Error(codes.Unauthenticated, "api keys are not enabled")
+		}
+		user, key, err := a.apiKeySvc.Authenticate(ctx, credential)
+		if err != nil {
+			return ctx, status.Error(codes.Unauthenticated, "invalid api key")
+		}
+		// Beyond public reads, a key needs the scope the method declares
+		if policy.access > accessPublic && (policy.scope == "" || !key.HasScope(policy.scope)) {
+			return ctx, status.Error(codes.PermissionDenied, "api key not permitted for this method")
+		}
+		p.user = user
+		p.apiKey = key
+
+	default:
+		return ctx, status.Error(codes.Unauthenticated, "unsupported authorization scheme")
+	}
+
+	if policy.access == accessAdmin && !p.user.HasRole(models.UserRoleAdmin) {
+		return ctx, status.Error(codes.PermissionDenied, "admin access required")
+	}
+
+	return context.WithValue(ctx, principalKey{}, p), nil
+}
+
+// audit logs one line per call with the caller identity and outcome
+func (a *AuthInterceptor) audit(ctx context.Context, fullMethod string, start time.Time, err error) {
+	fields := logrus.Fields{
+		"method":   fullMethod,
+		"code":     status.Code(err).String(),
+		"duration": time.Since(start).String(),
+		"auth":     "anonymous",
+	}
+	if user, ok := UserFromContext(ctx); ok {
+		fields["user_id"] = user.ID
+		fields["auth"] = "jwt"
+	}
+	if key, ok := APIKeyFromContext(ctx); ok {
+		fields["api_key_id"] = key.ID
+		fields["auth"] = "api_key"
+	}
+
+	entry := logrus.WithFields(fields)
+	if err != nil {
+		entry.Warn("gRPC call failed")
+		return
+	}
+	// Probes and reflection would otherwise flood the audit log
+	if policy, known := methodPolicies[fullMethod]; known && policy.access == accessOpen {
+		entry.Debug("gRPC call")
+		return
+	}
+	entry.Info("gRPC call")
+}
+
+// authorizationFromMetadata splits the "authorization" metadata value into scheme and credential
+func authorizationFromMetadata(ctx context.Context) (scheme, credential string, ok bool) {
+	md, found := metadata.FromIncomingContext(ctx)
+	if !found {
+		return "", "", false
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", "", false
+	}
+
+	// A malformed header still counts as presented credentials so it is rejected
+	// rather than silently treated as anonymous
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 {
+		return parts[0], "", true
+	}
+	return parts[0], parts[1], true
+}
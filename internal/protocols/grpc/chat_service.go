@@ -0,0 +1,169 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "mangahub/internal/protocols/grpc/pb"
+	wsProtocol "mangahub/internal/protocols/websocket"
+	"mangahub/internal/repository"
+	"mangahub/pkg/models"
+)
+
+// ChatServiceServer implements bidirectional chat streaming on top of the
+// WebSocket hub, so gRPC and WebSocket clients share the same rooms
+type ChatServiceServer struct {
+	pb.UnimplementedChatServiceServer
+	hub       *wsProtocol.Hub
+	mangaRepo repository.MangaRepository
+}
+
+// NewChatServiceServer creates a new gRPC chat service
+func NewChatServiceServer(hub *wsProtocol.Hub, mangaRepo repository.MangaRepository) *ChatServiceServer {
+	return &ChatServiceServer{
+		hub:       hub,
+		mangaRepo: mangaRepo,
+	}
+}
+
+// Join attaches the caller to a manga chat room for the lifetime of the stream
+func (s *ChatServiceServer) Join(stream pb.ChatService_JoinServer) error {
+	ctx := stream.Context()
+
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	// First frame selects the room
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if first.MangaId == "" {
+		return status.Error(codes.InvalidArgument, "manga_id is required on the first frame")
+	}
+	if _, err := s.mangaRepo.GetByID(ctx, first.MangaId); err != nil {
+		return status.Error(codes.NotFound, "manga not found")
+	}
+
+	client, err := s.hub.Attach(newStreamTransport(stream), user.ID, user.Username, first.MangaId)
+	switch {
+	case errors.Is(err, wsProtocol.ErrRoomFull):
+		return status.Error(codes.ResourceExhausted, "chat room is full")
+	case err != nil:
+		return status.Error(codes.Unavailable, "chat room closed")
+	}
+	defer client.Detach()
+
+	if first.Content != "" {
+		_ = client.Submit(first.Content)
+	}
+
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			frame, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			// Rejections are reported back to the client by Submit
+			_ = client.Submit(frame.Content)
+		}
+	}()
+
+	select {
+	case err := <-recvErr:
+		if err == io.EOF || errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled {
+			return nil
+		}
+		return err
+	case <-client.Done():
+		return status.Error(codes.Unavailable, "chat room closed")
+	}
+}
+
+// streamTransport adapts a Join stream to the hub's Transport interface
+type streamTransport struct {
+	mu     sync.Mutex
+	stream pb.ChatService_JoinServer
+	closed bool
+}
+
+func newStreamTransport(stream pb.ChatService_JoinServer) *streamTransport {
+	return &streamTransport{stream: stream}
+}
+
+// WriteMessage sends a room message as a ChatEvent
+func (t *streamTransport) WriteMessage(msg *wsProtocol.Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Sending after the handler has returned is not allowed
+	if t.closed {
+		return io.ErrClosedPipe
+	}
+
+	return t.stream.Send(toPbChatEvent(msg))
+}
+
+// toPbChatEvent converts a hub message, including presence, edits, deletes
+// and reactions, so stream clients see the same room as WebSocket clients
+func toPbChatEvent(msg *wsProtocol.Message) *pb.ChatEvent {
+	event := &pb.ChatEvent{
+		Type:      msg.Type,
+		Id:        msg.ID,
+		UserId:    msg.UserID,
+		Username:  msg.Username,
+		MangaId:   msg.MangaID,
+		Content:   msg.Content,
+		Timestamp: timestamppb.New(msg.Timestamp),
+		Emoji:     msg.Emoji,
+	}
+	if msg.EditedAt != nil {
+		event.EditedAt = timestamppb.New(*msg.EditedAt)
+	}
+	if len(msg.Reactions) > 0 {
+		event.Reactions = make(map[string]int32, len(msg.Reactions))
+		for emoji, count := range msg.Reactions {
+			event.Reactions[emoji] = int32(count)
+		}
+	}
+	if msg.Type == models.ChatEventReaction {
+		event.Event = "removed"
+		if msg.Added {
+			event.Event = "added"
+		}
+	}
+	for _, user := range msg.Users {
+		event.Users = append(event.Users, &pb.ChatMember{
+			UserId:   user.UserID,
+			Username: user.Username,
+			Status:   user.Status,
+		})
+	}
+	return event
+}
+
+// Ping is a no-op; HTTP/2 keepalive covers liveness
+func (t *streamTransport) Ping() error {
+	return nil
+}
+
+// Close stops further sends on the stream
+func (t *streamTransport) Close() error {
+	t.mu.Lock()
+	t.closed = true
+	t.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,220 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "mangahub/internal/protocols/grpc/pb"
+	"mangahub/pkg/models"
+)
+
+// CreateManga creates a manga through the same core service as REST (admin only)
+func (s *MangaServiceServer) CreateManga(ctx context.Context, req *pb.CreateMangaRequest) (*pb.MangaResponse, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	manga, err := s.mangaSvc.Create(ctx, user.ID, models.CreateMangaRequest{
+		Title:       strings.TrimSpace(req.Title),
+		Description: req.Description,
+		CoverURL:    req.CoverUrl,
+		Status:      req.Status,
+		GenreIDs:    req.GenreIds,
+	})
+	if err != nil {
+		return nil, toStatusError(err, "failed to create manga")
+	}
+
+	return s.buildMangaResponse(ctx, manga.ID)
+}
+
+// UpdateManga applies a partial update to a manga (admin only)
+func (s *MangaServiceServer) UpdateManga(ctx context.Context, req *pb.UpdateMangaRequest) (*pb.MangaResponse, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if req.MangaId == "" {
+		return nil, status.Error(codes.InvalidArgument, "manga_id is required")
+	}
+
+	update := models.UpdateMangaRequest{
+		Title:       req.Title,
+		Description: req.Description,
+		CoverURL:    req.CoverUrl,
+		Status:      req.Status,
+		GenreIDs:    req.GenreIds,
+	}
+
+	if _, err := s.mangaSvc.Update(ctx, user.ID, req.MangaId, update); err != nil {
+		return nil, toStatusError(err, "failed to update manga")
+	}
+
+	return s.buildMangaResponse(ctx, req.MangaId)
+}
+
+// DeleteManga deletes a manga and everything that cascades from it (admin only)
+func (s *MangaServiceServer) DeleteManga(ctx context.Context, req *pb.DeleteMangaRequest) (*pb.DeleteMangaResponse, error) {
+	if _, ok := UserFromContext(ctx); !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if req.MangaId == "" {
+		return nil, status.Error(codes.InvalidArgument, "manga_id is required")
+	}
+
+	if err := s.mangaSvc.Delete(ctx, req.MangaId); err != nil {
+		return nil, toStatusError(err, "failed to delete manga")
+	}
+
+	return &pb.DeleteMangaResponse{Success: true}, nil
+}
+
+// ListComments returns paginated comments for a manga, newest first
+func (s *MangaServiceServer) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
+	if req.MangaId == "" {
+		return nil, status.Error(codes.InvalidArgument, "manga_id is required")
+	}
+
+	result, err := s.commentSvc.ListByMangaID(ctx, req.MangaId, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, toStatusError(err, "failed to list comments")
+	}
+
+	comments := make([]*pb.CommentResponse, 0, len(result.Data))
+	for i := range result.Data {
+		comments = append(comments, toPbComment(&result.Data[i]))
+	}
+
+	return &pb.ListCommentsResponse{
+		Comments: comments,
+		Total:    int32(result.Total),
+		Limit:    int32(result.Limit),
+		Offset:   int32(result.Offset),
+		HasMore:  result.HasMore,
+	}, nil
+}
+
+// CreateComment posts a comment as the authenticated caller
+func (s *MangaServiceServer) CreateComment(ctx context.Context, req *pb.CreateCommentRequest) (*pb.CommentResponse, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if req.MangaId == "" {
+		return nil, status.Error(codes.InvalidArgument, "manga_id is required")
+	}
+
+	comment, err := s.commentSvc.Create(ctx, req.MangaId, user.ID, models.CreateCommentRequest{
+		MangaID: req.MangaId,
+		Content: req.Content,
+	})
+	if err != nil {
+		return nil, toStatusError(err, "failed to create comment")
+	}
+
+	return toPbComment(comment), nil
+}
+
+// GetChatHistory returns paginated chat history for a manga room, newest first
+func (s *MangaServiceServer) GetChatHistory(ctx context.Context, req *pb.ChatHistoryRequest) (*pb.ChatHistoryResponse, error) {
+	if req.MangaId == "" {
+		return nil, status.Error(codes.InvalidArgument, "manga_id is required")
+	}
+
+	result, err := s.chatSvc.GetHistory(ctx, req.MangaId, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, toStatusError(err, "failed to get chat history")
+	}
+
+	messages := make([]*pb.ChatMessage, 0, len(result.Data))
+	for i := range result.Data {
+		messages = append(messages, toPbChatMessage(&result.Data[i]))
+	}
+
+	return &pb.ChatHistoryResponse{
+		Messages: messages,
+		Total:    int32(result.Total),
+		Limit:    int32(result.Limit),
+		Offset:   int32(result.Offset),
+		HasMore:  result.HasMore,
+	}, nil
+}
+
+// GetLeaderboard ranks users by contribution for a category and time window
+func (s *MangaServiceServer) GetLeaderboard(ctx context.Context, req *pb.LeaderboardRequest) (*pb.LeaderboardResponse, error) {
+	leaderboard, err := s.statsSvc.GetLeaderboard(ctx, req.Category, req.Window, int(req.Limit))
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidInput) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Error(codes.Internal, "failed to get leaderboard")
+	}
+
+	entries := make([]*pb.LeaderboardEntry, 0, len(leaderboard.Entries))
+	for _, entry := range leaderboard.Entries {
+		entries = append(entries, &pb.LeaderboardEntry{
+			UserId:   entry.UserID,
+			Username: entry.Username,
+			Score:    int32(entry.Score),
+			Rank:     int32(entry.Rank),
+		})
+	}
+
+	return &pb.LeaderboardResponse{
+		Category: leaderboard.Category,
+		Window:   leaderboard.Window,
+		Entries:  entries,
+	}, nil
+}
+
+// toStatusError maps core/repository errors to gRPC status codes. Lookup
+// misses, validation and permission errors are reported to the caller;
+// anything else is logged and returned as a generic internal error.
+func toStatusError(err error, msg string) error {
+	var appErr *models.AppError
+	switch {
+	case errors.Is(err, models.ErrNotFound), errors.Is(err, models.ErrMangaNotFound):
+		return status.Errorf(codes.NotFound, "%s: not found", msg)
+	case errors.As(err, &appErr) && appErr.StatusCode == 404:
+		return status.Errorf(codes.NotFound, "%s: not found", msg)
+	case errors.Is(err, models.ErrInvalidInput):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	case errors.As(err, &appErr) && appErr.StatusCode == 400:
+		return status.Errorf(codes.InvalidArgument, "%s: %s", msg, appErr.Message)
+	case errors.Is(err, models.ErrForbidden):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", msg, err)
+	default:
+		logrus.WithError(err).Error(msg)
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+func toPbComment(c *models.CommentResponse) *pb.CommentResponse {
+	return &pb.CommentResponse{
+		Id:        c.ID,
+		MangaId:   c.MangaID,
+		UserId:    c.User.ID,
+		Username:  c.User.Username,
+		Content:   c.Content,
+		LikeCount: int32(c.LikeCount),
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+}
+
+func toPbChatMessage(m *models.ChatMessageResponse) *pb.ChatMessage {
+	return &pb.ChatMessage{
+		Id:        m.ID,
+		MangaId:   m.MangaID,
+		UserId:    m.User.ID,
+		Username:  m.User.Username,
+		Content:   m.Content,
+		CreatedAt: timestamppb.New(m.CreatedAt),
+	}
+}
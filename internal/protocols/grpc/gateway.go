@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "mangahub/internal/protocols/grpc/pb"
+)
+
+// NewGateway returns an HTTP handler that translates REST/JSON calls into gRPC
+// calls against the server at endpoint, using the google.api.http mappings in
+// manga.proto. Requests go through the normal interceptors, so the
+// Authorization header is checked exactly as it is for native gRPC clients.
+func NewGateway(ctx context.Context, endpoint string) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		// Keep snake_case field names and zero values, matching the REST API
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+	)
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := pb.RegisterMangaServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+		return nil, fmt.Errorf("failed to register gateway for %s: %w", endpoint, err)
+	}
+
+	return mux, nil
+}
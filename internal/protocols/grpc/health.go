@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "mangahub/internal/protocols/grpc/pb"
+)
+
+const (
+	healthCheckInterval = 10 * time.Second
+	healthPingTimeout   = 2 * time.Second
+)
+
+// healthServices lists the services reported through grpc.health.v1.
+// The empty name is the overall server status used by probes that pass no service.
+var healthServices = []string{
+	"",
+	pb.MangaService_ServiceDesc.ServiceName,
+	pb.ChatService_ServiceDesc.ServiceName,
+}
+
+// HealthMonitor backs the standard grpc.health.v1 service. Every service
+// depends on PostgreSQL, so all statuses follow the database pool's health.
+type HealthMonitor struct {
+	server *health.Server
+	pool   *pgxpool.Pool
+	stop   chan struct{}
+}
+
+// NewHealthMonitor creates a health monitor; statuses start as NOT_SERVING
+// until the first database ping succeeds
+func NewHealthMonitor(pool *pgxpool.Pool) *HealthMonitor {
+	m := &HealthMonitor{
+		server: health.NewServer(),
+		pool:   pool,
+		stop:   make(chan struct{}),
+	}
+	m.setAll(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	return m
+}
+
+// Register adds the health service to a gRPC server
+func (m *HealthMonitor) Register(server *grpc.Server) {
+	grpc_health_v1.RegisterHealthServer(server, m.server)
+}
+
+// Start checks the database immediately and then on a fixed interval
+func (m *HealthMonitor) Start() {
+	m.check()
+
+	go func() {
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.check()
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown stops polling and reports NOT_SERVING for every service so
+// load balancers drain the server before it stops
+func (m *HealthMonitor) Shutdown() {
+	close(m.stop)
+	m.server.Shutdown()
+}
+
+// check pings the pool and updates all statuses on change
+func (m *HealthMonitor) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), healthPingTimeout)
+	defer cancel()
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if err := m.pool.Ping(ctx); err != nil {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	previous, err := m.server.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err == nil && previous.Status == status {
+		return
+	}
+
+	logrus.WithField("status", status.String()).Info("gRPC health status changed")
+	m.setAll(status)
+}
+
+func (m *HealthMonitor) setAll(status grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	for _, service := range healthServices {
+		m.server.SetServingStatus(service, status)
+	}
+}
@@ -7,6 +7,7 @@
 package pb
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -446,6 +447,7 @@ func (x *GetMangaRequest) GetMangaId() string {
 type TrendingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Window        string                 `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"` // 24h, 7d or 30d (default 7d)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -487,9 +489,17 @@ func (x *TrendingRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *TrendingRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
 type TrendingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Manga         []*MangaResponse       `protobuf:"bytes,1,rep,name=manga,proto3" json:"manga,omitempty"`
+	Manga         []*MangaResponse       `protobuf:"bytes,1,rep,name=manga,proto3" json:"manga,omitempty"` // relevance_score carries the trending score
+	Window        string                 `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -531,6 +541,13 @@ func (x *TrendingResponse) GetManga() []*MangaResponse {
 	return nil
 }
 
+func (x *TrendingResponse) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
 type AutoSuggestRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
@@ -731,69 +748,1364 @@ func (x *HealthCheckResponse) GetUptime() int64 {
 	return 0
 }
 
-var File_manga_proto protoreflect.FileDescriptor
+type CreateMangaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	CoverUrl      string                 `protobuf:"bytes,3,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // ongoing (default), completed, hiatus
+	GenreIds      []string               `protobuf:"bytes,5,rep,name=genre_ids,json=genreIds,proto3" json:"genre_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_manga_proto_rawDesc = "" +
-	"\n" +
-	"\vmanga.proto\x12\vmangahub.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x01\n" +
-	"\rSearchRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x1b\n" +
-	"\tgenre_ids\x18\x04 \x03(\tR\bgenreIds\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\"\x83\x03\n" +
-	"\rMangaResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
-	"\tcover_url\x18\x04 \x01(\tR\bcoverUrl\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\x12*\n" +
-	"\x06genres\x18\x06 \x03(\v2\x12.mangahub.v1.GenreR\x06genres\x12#\n" +
-	"\rcomment_count\x18\a \x01(\x05R\fcommentCount\x12\x1d\n" +
-	"\n" +
-	"chat_count\x18\b \x01(\x05R\tchatCount\x12!\n" +
-	"\fweekly_score\x18\t \x01(\x05R\vweeklyScore\x129\n" +
-	"\n" +
-	"created_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12'\n" +
-	"\x0frelevance_score\x18\v \x01(\x02R\x0erelevanceScore\"+\n" +
-	"\x05Genre\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\xa1\x01\n" +
-	"\x0eSearchResponse\x120\n" +
-	"\x05manga\x18\x01 \x03(\v2\x1a.mangahub.v1.MangaResponseR\x05manga\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x19\n" +
-	"\bhas_more\x18\x05 \x01(\bR\ahasMore\",\n" +
-	"\x0fGetMangaRequest\x12\x19\n" +
-	"\bmanga_id\x18\x01 \x01(\tR\amangaId\"'\n" +
-	"\x0fTrendingRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\"D\n" +
-	"\x10TrendingResponse\x120\n" +
-	"\x05manga\x18\x01 \x03(\v2\x1a.mangahub.v1.MangaResponseR\x05manga\"B\n" +
-	"\x12AutoSuggestRequest\x12\x16\n" +
-	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"7\n" +
-	"\x13AutoSuggestResponse\x12 \n" +
-	"\vsuggestions\x18\x01 \x03(\tR\vsuggestions\"\x14\n" +
-	"\x12HealthCheckRequest\"\x94\x01\n" +
-	"\x13HealthCheckResponse\x121\n" +
-	"\x06status\x18\x01 \x01(\x0e2\x19.mangahub.v1.HealthStatusR\x06status\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
-	"\aversion\x18\x03 \x01(\tR\aversion\x12\x16\n" +
-	"\x06uptime\x18\x04 \x01(\x03R\x06uptime*K\n" +
+func (x *CreateMangaRequest) Reset() {
+	*x = CreateMangaRequest{}
+	mi := &file_manga_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMangaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMangaRequest) ProtoMessage() {}
+
+func (x *CreateMangaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMangaRequest.ProtoReflect.Descriptor instead.
+func (*CreateMangaRequest) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateMangaRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateMangaRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateMangaRequest) GetCoverUrl() string {
+	if x != nil {
+		return x.CoverUrl
+	}
+	return ""
+}
+
+func (x *CreateMangaRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateMangaRequest) GetGenreIds() []string {
+	if x != nil {
+		return x.GenreIds
+	}
+	return nil
+}
+
+type UpdateMangaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MangaId       string                 `protobuf:"bytes,1,opt,name=manga_id,json=mangaId,proto3" json:"manga_id,omitempty"`
+	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"` // Unset fields are left unchanged
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	CoverUrl      *string                `protobuf:"bytes,4,opt,name=cover_url,json=coverUrl,proto3,oneof" json:"cover_url,omitempty"`
+	Status        *string                `protobuf:"bytes,5,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	GenreIds      []string               `protobuf:"bytes,6,rep,name=genre_ids,json=genreIds,proto3" json:"genre_ids,omitempty"` // Empty keeps current genres
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMangaRequest) Reset() {
+	*x = UpdateMangaRequest{}
+	mi := &file_manga_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMangaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMangaRequest) ProtoMessage() {}
+
+func (x *UpdateMangaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMangaRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMangaRequest) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateMangaRequest) GetMangaId() string {
+	if x != nil {
+		return x.MangaId
+	}
+	return ""
+}
+
+func (x *UpdateMangaRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateMangaRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateMangaRequest) GetCoverUrl() string {
+	if x != nil && x.CoverUrl != nil {
+		return *x.CoverUrl
+	}
+	return ""
+}
+
+func (x *UpdateMangaRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *UpdateMangaRequest) GetGenreIds() []string {
+	if x != nil {
+		return x.GenreIds
+	}
+	return nil
+}
+
+type DeleteMangaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MangaId       string                 `protobuf:"bytes,1,opt,name=manga_id,json=mangaId,proto3" json:"manga_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMangaRequest) Reset() {
+	*x = DeleteMangaRequest{}
+	mi := &file_manga_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMangaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMangaRequest) ProtoMessage() {}
+
+func (x *DeleteMangaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMangaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMangaRequest) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteMangaRequest) GetMangaId() string {
+	if x != nil {
+		return x.MangaId
+	}
+	return ""
+}
+
+type DeleteMangaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMangaResponse) Reset() {
+	*x = DeleteMangaResponse{}
+	mi := &file_manga_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMangaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMangaResponse) ProtoMessage() {}
+
+func (x *DeleteMangaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMangaResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMangaResponse) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteMangaResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MangaId       string                 `protobuf:"bytes,1,opt,name=manga_id,json=mangaId,proto3" json:"manga_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Max results (default 20)
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_manga_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListCommentsRequest) GetMangaId() string {
+	if x != nil {
+		return x.MangaId
+	}
+	return ""
+}
+
+func (x *ListCommentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type CommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MangaId       string                 `protobuf:"bytes,2,opt,name=manga_id,json=mangaId,proto3" json:"manga_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	LikeCount     int32                  `protobuf:"varint,6,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentResponse) Reset() {
+	*x = CommentResponse{}
+	mi := &file_manga_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentResponse) ProtoMessage() {}
+
+func (x *CommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentResponse.ProtoReflect.Descriptor instead.
+func (*CommentResponse) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CommentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CommentResponse) GetMangaId() string {
+	if x != nil {
+		return x.MangaId
+	}
+	return ""
+}
+
+func (x *CommentResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CommentResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CommentResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CommentResponse) GetLikeCount() int32 {
+	if x != nil {
+		return x.LikeCount
+	}
+	return 0
+}
+
+func (x *CommentResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*CommentResponse     `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	HasMore       bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_manga_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListCommentsResponse) GetComments() []*CommentResponse {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListCommentsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCommentsResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListCommentsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type CreateCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MangaId       string                 `protobuf:"bytes,1,opt,name=manga_id,json=mangaId,proto3" json:"manga_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentRequest) Reset() {
+	*x = CreateCommentRequest{}
+	mi := &file_manga_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentRequest) ProtoMessage() {}
+
+func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateCommentRequest) GetMangaId() string {
+	if x != nil {
+		return x.MangaId
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ChatHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MangaId       string                 `protobuf:"bytes,1,opt,name=manga_id,json=mangaId,proto3" json:"manga_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Max results (default 50)
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatHistoryRequest) Reset() {
+	*x = ChatHistoryRequest{}
+	mi := &file_manga_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatHistoryRequest) ProtoMessage() {}
+
+func (x *ChatHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ChatHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ChatHistoryRequest) GetMangaId() string {
+	if x != nil {
+		return x.MangaId
+	}
+	return ""
+}
+
+func (x *ChatHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ChatHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MangaId       string                 `protobuf:"bytes,2,opt,name=manga_id,json=mangaId,proto3" json:"manga_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	mi := &file_manga_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChatMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetMangaId() string {
+	if x != nil {
+		return x.MangaId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ChatHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*ChatMessage         `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"` // Newest first
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	HasMore       bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatHistoryResponse) Reset() {
+	*x = ChatHistoryResponse{}
+	mi := &file_manga_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatHistoryResponse) ProtoMessage() {}
+
+func (x *ChatHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ChatHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ChatHistoryResponse) GetMessages() []*ChatMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ChatHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ChatHistoryResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ChatHistoryResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ChatHistoryResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type LeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"` // commenters (default), liked_commenters, chatters
+	Window        string                 `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`     // weekly (default), monthly, all_time
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`      // Max entries (default 10)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardRequest) Reset() {
+	*x = LeaderboardRequest{}
+	mi := &file_manga_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardRequest) ProtoMessage() {}
+
+func (x *LeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*LeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LeaderboardRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Rank          int32                  `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"` // Ties share a rank
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_manga_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LeaderboardEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type LeaderboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Window        string                 `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardResponse) Reset() {
+	*x = LeaderboardResponse{}
+	mi := &file_manga_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardResponse) ProtoMessage() {}
+
+func (x *LeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*LeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LeaderboardResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *LeaderboardResponse) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *LeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ChatClientFrame struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MangaId       string                 `protobuf:"bytes,1,opt,name=manga_id,json=mangaId,proto3" json:"manga_id,omitempty"` // Required on the first frame only
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`                // Message text (ignored on the first frame if empty)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatClientFrame) Reset() {
+	*x = ChatClientFrame{}
+	mi := &file_manga_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatClientFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatClientFrame) ProtoMessage() {}
+
+func (x *ChatClientFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatClientFrame.ProtoReflect.Descriptor instead.
+func (*ChatClientFrame) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChatClientFrame) GetMangaId() string {
+	if x != nil {
+		return x.MangaId
+	}
+	return ""
+}
+
+func (x *ChatClientFrame) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ChatEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "message", "history", "join", "leave", "presence", "typing_start", "typing_stop",
+	// "message_edited", "message_deleted", "reaction", "error"
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	MangaId       string                 `protobuf:"bytes,4,opt,name=manga_id,json=mangaId,proto3" json:"manga_id,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Id            string                 `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`                                                                                          // Chat message ID, to match edits, deletes and reactions
+	Emoji         string                 `protobuf:"bytes,8,opt,name=emoji,proto3" json:"emoji,omitempty"`                                                                                    // "reaction": the emoji added or removed
+	Reactions     map[string]int32       `protobuf:"bytes,9,rep,name=reactions,proto3" json:"reactions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // "reaction": emoji -> count after the change
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`                                                             // "message_edited"
+	Event         string                 `protobuf:"bytes,11,opt,name=event,proto3" json:"event,omitempty"`                                                                                   // "reaction": "added" or "removed"
+	Users         []*ChatMember          `protobuf:"bytes,12,rep,name=users,proto3" json:"users,omitempty"`                                                                                   // "presence": room members
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatEvent) Reset() {
+	*x = ChatEvent{}
+	mi := &file_manga_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatEvent) ProtoMessage() {}
+
+func (x *ChatEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatEvent.ProtoReflect.Descriptor instead.
+func (*ChatEvent) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ChatEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetMangaId() string {
+	if x != nil {
+		return x.MangaId
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetReactions() map[string]int32 {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *ChatEvent) GetUsers() []*ChatMember {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type ChatMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // "online", "away"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMember) Reset() {
+	*x = ChatMember{}
+	mi := &file_manga_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMember) ProtoMessage() {}
+
+func (x *ChatMember) ProtoReflect() protoreflect.Message {
+	mi := &file_manga_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMember.ProtoReflect.Descriptor instead.
+func (*ChatMember) Descriptor() ([]byte, []int) {
+	return file_manga_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ChatMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChatMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ChatMember) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+var File_manga_proto protoreflect.FileDescriptor
+
+const file_manga_proto_rawDesc = "" +
+	"\n" +
+	"\vmanga.proto\x12\vmangahub.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x01\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\tgenre_ids\x18\x04 \x03(\tR\bgenreIds\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"\x83\x03\n" +
+	"\rMangaResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tcover_url\x18\x04 \x01(\tR\bcoverUrl\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12*\n" +
+	"\x06genres\x18\x06 \x03(\v2\x12.mangahub.v1.GenreR\x06genres\x12#\n" +
+	"\rcomment_count\x18\a \x01(\x05R\fcommentCount\x12\x1d\n" +
+	"\n" +
+	"chat_count\x18\b \x01(\x05R\tchatCount\x12!\n" +
+	"\fweekly_score\x18\t \x01(\x05R\vweeklyScore\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12'\n" +
+	"\x0frelevance_score\x18\v \x01(\x02R\x0erelevanceScore\"+\n" +
+	"\x05Genre\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xa1\x01\n" +
+	"\x0eSearchResponse\x120\n" +
+	"\x05manga\x18\x01 \x03(\v2\x1a.mangahub.v1.MangaResponseR\x05manga\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\",\n" +
+	"\x0fGetMangaRequest\x12\x19\n" +
+	"\bmanga_id\x18\x01 \x01(\tR\amangaId\"?\n" +
+	"\x0fTrendingRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\tR\x06window\"\\\n" +
+	"\x10TrendingResponse\x120\n" +
+	"\x05manga\x18\x01 \x03(\v2\x1a.mangahub.v1.MangaResponseR\x05manga\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\tR\x06window\"B\n" +
+	"\x12AutoSuggestRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"7\n" +
+	"\x13AutoSuggestResponse\x12 \n" +
+	"\vsuggestions\x18\x01 \x03(\tR\vsuggestions\"\x14\n" +
+	"\x12HealthCheckRequest\"\x94\x01\n" +
+	"\x13HealthCheckResponse\x121\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x19.mangahub.v1.HealthStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x16\n" +
+	"\x06uptime\x18\x04 \x01(\x03R\x06uptime\"\x9e\x01\n" +
+	"\x12CreateMangaRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tcover_url\x18\x03 \x01(\tR\bcoverUrl\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1b\n" +
+	"\tgenre_ids\x18\x05 \x03(\tR\bgenreIds\"\x80\x02\n" +
+	"\x12UpdateMangaRequest\x12\x19\n" +
+	"\bmanga_id\x18\x01 \x01(\tR\amangaId\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12 \n" +
+	"\tcover_url\x18\x04 \x01(\tH\x02R\bcoverUrl\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x05 \x01(\tH\x03R\x06status\x88\x01\x01\x12\x1b\n" +
+	"\tgenre_ids\x18\x06 \x03(\tR\bgenreIdsB\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_cover_urlB\t\n" +
+	"\a_status\"/\n" +
+	"\x12DeleteMangaRequest\x12\x19\n" +
+	"\bmanga_id\x18\x01 \x01(\tR\amangaId\"/\n" +
+	"\x13DeleteMangaResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"^\n" +
+	"\x13ListCommentsRequest\x12\x19\n" +
+	"\bmanga_id\x18\x01 \x01(\tR\amangaId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xe5\x01\n" +
+	"\x0fCommentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bmanga_id\x18\x02 \x01(\tR\amangaId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\x06 \x01(\x05R\tlikeCount\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xaf\x01\n" +
+	"\x14ListCommentsResponse\x128\n" +
+	"\bcomments\x18\x01 \x03(\v2\x1c.mangahub.v1.CommentResponseR\bcomments\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\"K\n" +
+	"\x14CreateCommentRequest\x12\x19\n" +
+	"\bmanga_id\x18\x01 \x01(\tR\amangaId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"]\n" +
+	"\x12ChatHistoryRequest\x12\x19\n" +
+	"\bmanga_id\x18\x01 \x01(\tR\amangaId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xc2\x01\n" +
+	"\vChatMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bmanga_id\x18\x02 \x01(\tR\amangaId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xaa\x01\n" +
+	"\x13ChatHistoryResponse\x124\n" +
+	"\bmessages\x18\x01 \x03(\v2\x18.mangahub.v1.ChatMessageR\bmessages\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\"^\n" +
+	"\x12LeaderboardRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\tR\x06window\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"q\n" +
+	"\x10LeaderboardEntry\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\x05R\x04rank\"\x82\x01\n" +
+	"\x13LeaderboardResponse\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\tR\x06window\x127\n" +
+	"\aentries\x18\x03 \x03(\v2\x1d.mangahub.v1.LeaderboardEntryR\aentries\"F\n" +
+	"\x0fChatClientFrame\x12\x19\n" +
+	"\bmanga_id\x18\x01 \x01(\tR\amangaId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\xea\x03\n" +
+	"\tChatEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x19\n" +
+	"\bmanga_id\x18\x04 \x01(\tR\amangaId\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x128\n" +
+	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x0e\n" +
+	"\x02id\x18\a \x01(\tR\x02id\x12\x14\n" +
+	"\x05emoji\x18\b \x01(\tR\x05emoji\x12C\n" +
+	"\treactions\x18\t \x03(\v2%.mangahub.v1.ChatEvent.ReactionsEntryR\treactions\x127\n" +
+	"\tedited_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\x12\x14\n" +
+	"\x05event\x18\v \x01(\tR\x05event\x12-\n" +
+	"\x05users\x18\f \x03(\v2\x17.mangahub.v1.ChatMemberR\x05users\x1a<\n" +
+	"\x0eReactionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"Y\n" +
+	"\n" +
+	"ChatMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status*K\n" +
 	"\fHealthStatus\x12\x1d\n" +
 	"\x19HEALTH_STATUS_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aSERVING\x10\x01\x12\x0f\n" +
-	"\vNOT_SERVING\x10\x022\xdb\x03\n" +
-	"\fMangaService\x12H\n" +
-	"\fStreamSearch\x12\x1a.mangahub.v1.SearchRequest\x1a\x1a.mangahub.v1.MangaResponse0\x01\x12F\n" +
-	"\vSearchManga\x12\x1a.mangahub.v1.SearchRequest\x1a\x1b.mangahub.v1.SearchResponse\x12D\n" +
-	"\bGetManga\x12\x1c.mangahub.v1.GetMangaRequest\x1a\x1a.mangahub.v1.MangaResponse\x12O\n" +
-	"\x10GetTrendingManga\x12\x1c.mangahub.v1.TrendingRequest\x1a\x1d.mangahub.v1.TrendingResponse\x12P\n" +
-	"\vAutoSuggest\x12\x1f.mangahub.v1.AutoSuggestRequest\x1a .mangahub.v1.AutoSuggestResponse\x12P\n" +
-	"\vHealthCheck\x12\x1f.mangahub.v1.HealthCheckRequest\x1a .mangahub.v1.HealthCheckResponseB(Z&mangahub/internal/protocols/grpc/pb;pbb\x06proto3"
+	"\vNOT_SERVING\x10\x022\x90\v\n" +
+	"\fMangaService\x12c\n" +
+	"\fStreamSearch\x12\x1a.mangahub.v1.SearchRequest\x1a\x1a.mangahub.v1.MangaResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/search/stream0\x01\x12Z\n" +
+	"\vSearchManga\x12\x1a.mangahub.v1.SearchRequest\x1a\x1b.mangahub.v1.SearchResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/search\x12b\n" +
+	"\bGetManga\x12\x1c.mangahub.v1.GetMangaRequest\x1a\x1a.mangahub.v1.MangaResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/manga/{manga_id}\x12e\n" +
+	"\x10GetTrendingManga\x12\x1c.mangahub.v1.TrendingRequest\x1a\x1d.mangahub.v1.TrendingResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/trending\x12e\n" +
+	"\vAutoSuggest\x12\x1f.mangahub.v1.AutoSuggestRequest\x1a .mangahub.v1.AutoSuggestResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/suggest\x12d\n" +
+	"\vHealthCheck\x12\x1f.mangahub.v1.HealthCheckRequest\x1a .mangahub.v1.HealthCheckResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/health\x12`\n" +
+	"\vCreateManga\x12\x1f.mangahub.v1.CreateMangaRequest\x1a\x1a.mangahub.v1.MangaResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/manga\x12k\n" +
+	"\vUpdateManga\x12\x1f.mangahub.v1.UpdateMangaRequest\x1a\x1a.mangahub.v1.MangaResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*2\x14/v1/manga/{manga_id}\x12n\n" +
+	"\vDeleteManga\x12\x1f.mangahub.v1.DeleteMangaRequest\x1a .mangahub.v1.DeleteMangaResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/v1/manga/{manga_id}\x12z\n" +
+	"\fListComments\x12 .mangahub.v1.ListCommentsRequest\x1a!.mangahub.v1.ListCommentsResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/manga/{manga_id}/comments\x12z\n" +
+	"\rCreateComment\x12!.mangahub.v1.CreateCommentRequest\x1a\x1c.mangahub.v1.CommentResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/manga/{manga_id}/comments\x12v\n" +
+	"\x0eGetChatHistory\x12\x1f.mangahub.v1.ChatHistoryRequest\x1a .mangahub.v1.ChatHistoryResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/manga/{manga_id}/chat\x12x\n" +
+	"\x0eGetLeaderboard\x12\x1f.mangahub.v1.LeaderboardRequest\x1a .mangahub.v1.LeaderboardResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/leaderboards/{category}2O\n" +
+	"\vChatService\x12@\n" +
+	"\x04Join\x12\x1c.mangahub.v1.ChatClientFrame\x1a\x16.mangahub.v1.ChatEvent(\x010\x01B(Z&mangahub/internal/protocols/grpc/pb;pbb\x06proto3"
 
 var (
 	file_manga_proto_rawDescOnce sync.Once
@@ -808,7 +2120,7 @@ func file_manga_proto_rawDescGZIP() []byte {
 }
 
 var file_manga_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_manga_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_manga_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_manga_proto_goTypes = []any{
 	(HealthStatus)(0),             // 0: mangahub.v1.HealthStatus
 	(*SearchRequest)(nil),         // 1: mangahub.v1.SearchRequest
@@ -822,31 +2134,74 @@ var file_manga_proto_goTypes = []any{
 	(*AutoSuggestResponse)(nil),   // 9: mangahub.v1.AutoSuggestResponse
 	(*HealthCheckRequest)(nil),    // 10: mangahub.v1.HealthCheckRequest
 	(*HealthCheckResponse)(nil),   // 11: mangahub.v1.HealthCheckResponse
-	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(*CreateMangaRequest)(nil),    // 12: mangahub.v1.CreateMangaRequest
+	(*UpdateMangaRequest)(nil),    // 13: mangahub.v1.UpdateMangaRequest
+	(*DeleteMangaRequest)(nil),    // 14: mangahub.v1.DeleteMangaRequest
+	(*DeleteMangaResponse)(nil),   // 15: mangahub.v1.DeleteMangaResponse
+	(*ListCommentsRequest)(nil),   // 16: mangahub.v1.ListCommentsRequest
+	(*CommentResponse)(nil),       // 17: mangahub.v1.CommentResponse
+	(*ListCommentsResponse)(nil),  // 18: mangahub.v1.ListCommentsResponse
+	(*CreateCommentRequest)(nil),  // 19: mangahub.v1.CreateCommentRequest
+	(*ChatHistoryRequest)(nil),    // 20: mangahub.v1.ChatHistoryRequest
+	(*ChatMessage)(nil),           // 21: mangahub.v1.ChatMessage
+	(*ChatHistoryResponse)(nil),   // 22: mangahub.v1.ChatHistoryResponse
+	(*LeaderboardRequest)(nil),    // 23: mangahub.v1.LeaderboardRequest
+	(*LeaderboardEntry)(nil),      // 24: mangahub.v1.LeaderboardEntry
+	(*LeaderboardResponse)(nil),   // 25: mangahub.v1.LeaderboardResponse
+	(*ChatClientFrame)(nil),       // 26: mangahub.v1.ChatClientFrame
+	(*ChatEvent)(nil),             // 27: mangahub.v1.ChatEvent
+	(*ChatMember)(nil),            // 28: mangahub.v1.ChatMember
+	nil,                           // 29: mangahub.v1.ChatEvent.ReactionsEntry
+	(*timestamppb.Timestamp)(nil), // 30: google.protobuf.Timestamp
 }
 var file_manga_proto_depIdxs = []int32{
 	3,  // 0: mangahub.v1.MangaResponse.genres:type_name -> mangahub.v1.Genre
-	12, // 1: mangahub.v1.MangaResponse.created_at:type_name -> google.protobuf.Timestamp
+	30, // 1: mangahub.v1.MangaResponse.created_at:type_name -> google.protobuf.Timestamp
 	2,  // 2: mangahub.v1.SearchResponse.manga:type_name -> mangahub.v1.MangaResponse
 	2,  // 3: mangahub.v1.TrendingResponse.manga:type_name -> mangahub.v1.MangaResponse
 	0,  // 4: mangahub.v1.HealthCheckResponse.status:type_name -> mangahub.v1.HealthStatus
-	1,  // 5: mangahub.v1.MangaService.StreamSearch:input_type -> mangahub.v1.SearchRequest
-	1,  // 6: mangahub.v1.MangaService.SearchManga:input_type -> mangahub.v1.SearchRequest
-	5,  // 7: mangahub.v1.MangaService.GetManga:input_type -> mangahub.v1.GetMangaRequest
-	6,  // 8: mangahub.v1.MangaService.GetTrendingManga:input_type -> mangahub.v1.TrendingRequest
-	8,  // 9: mangahub.v1.MangaService.AutoSuggest:input_type -> mangahub.v1.AutoSuggestRequest
-	10, // 10: mangahub.v1.MangaService.HealthCheck:input_type -> mangahub.v1.HealthCheckRequest
-	2,  // 11: mangahub.v1.MangaService.StreamSearch:output_type -> mangahub.v1.MangaResponse
-	4,  // 12: mangahub.v1.MangaService.SearchManga:output_type -> mangahub.v1.SearchResponse
-	2,  // 13: mangahub.v1.MangaService.GetManga:output_type -> mangahub.v1.MangaResponse
-	7,  // 14: mangahub.v1.MangaService.GetTrendingManga:output_type -> mangahub.v1.TrendingResponse
-	9,  // 15: mangahub.v1.MangaService.AutoSuggest:output_type -> mangahub.v1.AutoSuggestResponse
-	11, // 16: mangahub.v1.MangaService.HealthCheck:output_type -> mangahub.v1.HealthCheckResponse
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	30, // 5: mangahub.v1.CommentResponse.created_at:type_name -> google.protobuf.Timestamp
+	17, // 6: mangahub.v1.ListCommentsResponse.comments:type_name -> mangahub.v1.CommentResponse
+	30, // 7: mangahub.v1.ChatMessage.created_at:type_name -> google.protobuf.Timestamp
+	21, // 8: mangahub.v1.ChatHistoryResponse.messages:type_name -> mangahub.v1.ChatMessage
+	24, // 9: mangahub.v1.LeaderboardResponse.entries:type_name -> mangahub.v1.LeaderboardEntry
+	30, // 10: mangahub.v1.ChatEvent.timestamp:type_name -> google.protobuf.Timestamp
+	29, // 11: mangahub.v1.ChatEvent.reactions:type_name -> mangahub.v1.ChatEvent.ReactionsEntry
+	30, // 12: mangahub.v1.ChatEvent.edited_at:type_name -> google.protobuf.Timestamp
+	28, // 13: mangahub.v1.ChatEvent.users:type_name -> mangahub.v1.ChatMember
+	1,  // 14: mangahub.v1.MangaService.StreamSearch:input_type -> mangahub.v1.SearchRequest
+	1,  // 15: mangahub.v1.MangaService.SearchManga:input_type -> mangahub.v1.SearchRequest
+	5,  // 16: mangahub.v1.MangaService.GetManga:input_type -> mangahub.v1.GetMangaRequest
+	6,  // 17: mangahub.v1.MangaService.GetTrendingManga:input_type -> mangahub.v1.TrendingRequest
+	8,  // 18: mangahub.v1.MangaService.AutoSuggest:input_type -> mangahub.v1.AutoSuggestRequest
+	10, // 19: mangahub.v1.MangaService.HealthCheck:input_type -> mangahub.v1.HealthCheckRequest
+	12, // 20: mangahub.v1.MangaService.CreateManga:input_type -> mangahub.v1.CreateMangaRequest
+	13, // 21: mangahub.v1.MangaService.UpdateManga:input_type -> mangahub.v1.UpdateMangaRequest
+	14, // 22: mangahub.v1.MangaService.DeleteManga:input_type -> mangahub.v1.DeleteMangaRequest
+	16, // 23: mangahub.v1.MangaService.ListComments:input_type -> mangahub.v1.ListCommentsRequest
+	19, // 24: mangahub.v1.MangaService.CreateComment:input_type -> mangahub.v1.CreateCommentRequest
+	20, // 25: mangahub.v1.MangaService.GetChatHistory:input_type -> mangahub.v1.ChatHistoryRequest
+	23, // 26: mangahub.v1.MangaService.GetLeaderboard:input_type -> mangahub.v1.LeaderboardRequest
+	26, // 27: mangahub.v1.ChatService.Join:input_type -> mangahub.v1.ChatClientFrame
+	2,  // 28: mangahub.v1.MangaService.StreamSearch:output_type -> mangahub.v1.MangaResponse
+	4,  // 29: mangahub.v1.MangaService.SearchManga:output_type -> mangahub.v1.SearchResponse
+	2,  // 30: mangahub.v1.MangaService.GetManga:output_type -> mangahub.v1.MangaResponse
+	7,  // 31: mangahub.v1.MangaService.GetTrendingManga:output_type -> mangahub.v1.TrendingResponse
+	9,  // 32: mangahub.v1.MangaService.AutoSuggest:output_type -> mangahub.v1.AutoSuggestResponse
+	11, // 33: mangahub.v1.MangaService.HealthCheck:output_type -> mangahub.v1.HealthCheckResponse
+	2,  // 34: mangahub.v1.MangaService.CreateManga:output_type -> mangahub.v1.MangaResponse
+	2,  // 35: mangahub.v1.MangaService.UpdateManga:output_type -> mangahub.v1.MangaResponse
+	15, // 36: mangahub.v1.MangaService.DeleteManga:output_type -> mangahub.v1.DeleteMangaResponse
+	18, // 37: mangahub.v1.MangaService.ListComments:output_type -> mangahub.v1.ListCommentsResponse
+	17, // 38: mangahub.v1.MangaService.CreateComment:output_type -> mangahub.v1.CommentResponse
+	22, // 39: mangahub.v1.MangaService.GetChatHistory:output_type -> mangahub.v1.ChatHistoryResponse
+	25, // 40: mangahub.v1.MangaService.GetLeaderboard:output_type -> mangahub.v1.LeaderboardResponse
+	27, // 41: mangahub.v1.ChatService.Join:output_type -> mangahub.v1.ChatEvent
+	28, // [28:42] is the sub-list for method output_type
+	14, // [14:28] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_manga_proto_init() }
@@ -854,15 +2209,16 @@ func file_manga_proto_init() {
 	if File_manga_proto != nil {
 		return
 	}
+	file_manga_proto_msgTypes[12].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_manga_proto_rawDesc), len(file_manga_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   11,
+			NumMessages:   29,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_manga_proto_goTypes,
 		DependencyIndexes: file_manga_proto_depIdxs,
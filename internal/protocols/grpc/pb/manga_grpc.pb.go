@@ -25,6 +25,13 @@ const (
 	MangaService_GetTrendingManga_FullMethodName = "/mangahub.v1.MangaService/GetTrendingManga"
 	MangaService_AutoSuggest_FullMethodName      = "/mangahub.v1.MangaService/AutoSuggest"
 	MangaService_HealthCheck_FullMethodName      = "/mangahub.v1.MangaService/HealthCheck"
+	MangaService_CreateManga_FullMethodName      = "/mangahub.v1.MangaService/CreateManga"
+	MangaService_UpdateManga_FullMethodName      = "/mangahub.v1.MangaService/UpdateManga"
+	MangaService_DeleteManga_FullMethodName      = "/mangahub.v1.MangaService/DeleteManga"
+	MangaService_ListComments_FullMethodName     = "/mangahub.v1.MangaService/ListComments"
+	MangaService_CreateComment_FullMethodName    = "/mangahub.v1.MangaService/CreateComment"
+	MangaService_GetChatHistory_FullMethodName   = "/mangahub.v1.MangaService/GetChatHistory"
+	MangaService_GetLeaderboard_FullMethodName   = "/mangahub.v1.MangaService/GetLeaderboard"
 )
 
 // MangaServiceClient is the client API for MangaService service.
@@ -38,6 +45,15 @@ type MangaServiceClient interface {
 	GetTrendingManga(ctx context.Context, in *TrendingRequest, opts ...grpc.CallOption) (*TrendingResponse, error)
 	AutoSuggest(ctx context.Context, in *AutoSuggestRequest, opts ...grpc.CallOption) (*AutoSuggestResponse, error)
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
+	// Write APIs backed by the same core services as REST
+	CreateManga(ctx context.Context, in *CreateMangaRequest, opts ...grpc.CallOption) (*MangaResponse, error)
+	UpdateManga(ctx context.Context, in *UpdateMangaRequest, opts ...grpc.CallOption) (*MangaResponse, error)
+	DeleteManga(ctx context.Context, in *DeleteMangaRequest, opts ...grpc.CallOption) (*DeleteMangaResponse, error)
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error)
+	GetChatHistory(ctx context.Context, in *ChatHistoryRequest, opts ...grpc.CallOption) (*ChatHistoryResponse, error)
+	// User rankings, same data as GET /api/v1/stats/leaderboard
+	GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error)
 }
 
 type mangaServiceClient struct {
@@ -117,6 +133,76 @@ func (c *mangaServiceClient) HealthCheck(ctx context.Context, in *HealthCheckReq
 	return out, nil
 }
 
+func (c *mangaServiceClient) CreateManga(ctx context.Context, in *CreateMangaRequest, opts ...grpc.CallOption) (*MangaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MangaResponse)
+	err := c.cc.Invoke(ctx, MangaService_CreateManga_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mangaServiceClient) UpdateManga(ctx context.Context, in *UpdateMangaRequest, opts ...grpc.CallOption) (*MangaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MangaResponse)
+	err := c.cc.Invoke(ctx, MangaService_UpdateManga_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mangaServiceClient) DeleteManga(ctx context.Context, in *DeleteMangaRequest, opts ...grpc.CallOption) (*DeleteMangaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMangaResponse)
+	err := c.cc.Invoke(ctx, MangaService_DeleteManga_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mangaServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, MangaService_ListComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mangaServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommentResponse)
+	err := c.cc.Invoke(ctx, MangaService_CreateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mangaServiceClient) GetChatHistory(ctx context.Context, in *ChatHistoryRequest, opts ...grpc.CallOption) (*ChatHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatHistoryResponse)
+	err := c.cc.Invoke(ctx, MangaService_GetChatHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mangaServiceClient) GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardResponse)
+	err := c.cc.Invoke(ctx, MangaService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MangaServiceServer is the server API for MangaService service.
 // All implementations must embed UnimplementedMangaServiceServer
 // for forward compatibility.
@@ -128,6 +214,15 @@ type MangaServiceServer interface {
 	GetTrendingManga(context.Context, *TrendingRequest) (*TrendingResponse, error)
 	AutoSuggest(context.Context, *AutoSuggestRequest) (*AutoSuggestResponse, error)
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
+	// Write APIs backed by the same core services as REST
+	CreateManga(context.Context, *CreateMangaRequest) (*MangaResponse, error)
+	UpdateManga(context.Context, *UpdateMangaRequest) (*MangaResponse, error)
+	DeleteManga(context.Context, *DeleteMangaRequest) (*DeleteMangaResponse, error)
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	CreateComment(context.Context, *CreateCommentRequest) (*CommentResponse, error)
+	GetChatHistory(context.Context, *ChatHistoryRequest) (*ChatHistoryResponse, error)
+	// User rankings, same data as GET /api/v1/stats/leaderboard
+	GetLeaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error)
 	mustEmbedUnimplementedMangaServiceServer()
 }
 
@@ -156,6 +251,27 @@ func (UnimplementedMangaServiceServer) AutoSuggest(context.Context, *AutoSuggest
 func (UnimplementedMangaServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method HealthCheck not implemented")
 }
+func (UnimplementedMangaServiceServer) CreateManga(context.Context, *CreateMangaRequest) (*MangaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateManga not implemented")
+}
+func (UnimplementedMangaServiceServer) UpdateManga(context.Context, *UpdateMangaRequest) (*MangaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateManga not implemented")
+}
+func (UnimplementedMangaServiceServer) DeleteManga(context.Context, *DeleteMangaRequest) (*DeleteMangaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteManga not implemented")
+}
+func (UnimplementedMangaServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedMangaServiceServer) CreateComment(context.Context, *CreateCommentRequest) (*CommentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateComment not implemented")
+}
+func (UnimplementedMangaServiceServer) GetChatHistory(context.Context, *ChatHistoryRequest) (*ChatHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetChatHistory not implemented")
+}
+func (UnimplementedMangaServiceServer) GetLeaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
 func (UnimplementedMangaServiceServer) mustEmbedUnimplementedMangaServiceServer() {}
 func (UnimplementedMangaServiceServer) testEmbeddedByValue()                      {}
 
@@ -278,6 +394,132 @@ func _MangaService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MangaService_CreateManga_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMangaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).CreateManga(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_CreateManga_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).CreateManga(ctx, req.(*CreateMangaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MangaService_UpdateManga_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMangaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).UpdateManga(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_UpdateManga_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).UpdateManga(ctx, req.(*UpdateMangaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MangaService_DeleteManga_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMangaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).DeleteManga(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_DeleteManga_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).DeleteManga(ctx, req.(*DeleteMangaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MangaService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_ListComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MangaService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).CreateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_CreateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MangaService_GetChatHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChatHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).GetChatHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_GetChatHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).GetChatHistory(ctx, req.(*ChatHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MangaService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MangaServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MangaService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MangaServiceServer).GetLeaderboard(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MangaService_ServiceDesc is the grpc.ServiceDesc for MangaService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,6 +547,34 @@ var MangaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HealthCheck",
 			Handler:    _MangaService_HealthCheck_Handler,
 		},
+		{
+			MethodName: "CreateManga",
+			Handler:    _MangaService_CreateManga_Handler,
+		},
+		{
+			MethodName: "UpdateManga",
+			Handler:    _MangaService_UpdateManga_Handler,
+		},
+		{
+			MethodName: "DeleteManga",
+			Handler:    _MangaService_DeleteManga_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _MangaService_ListComments_Handler,
+		},
+		{
+			MethodName: "CreateComment",
+			Handler:    _MangaService_CreateComment_Handler,
+		},
+		{
+			MethodName: "GetChatHistory",
+			Handler:    _MangaService_GetChatHistory_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _MangaService_GetLeaderboard_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -315,3 +585,113 @@ var MangaService_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "manga.proto",
 }
+
+const (
+	ChatService_Join_FullMethodName = "/mangahub.v1.ChatService/Join"
+)
+
+// ChatServiceClient is the client API for ChatService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ChatService is the gRPC alternative to /ws/manga/:manga_id. Both transports
+// share the same rooms, so gRPC and WebSocket users talk to each other.
+type ChatServiceClient interface {
+	// The first client frame must set manga_id to pick the room; later frames
+	// carry message content. The server replays recent history, then streams
+	// live room events until either side closes. Not mapped by the
+	// grpc-gateway; JSON clients use the WebSocket endpoint instead.
+	Join(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ChatClientFrame, ChatEvent], error)
+}
+
+type chatServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChatServiceClient(cc grpc.ClientConnInterface) ChatServiceClient {
+	return &chatServiceClient{cc}
+}
+
+func (c *chatServiceClient) Join(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ChatClientFrame, ChatEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChatService_ServiceDesc.Streams[0], ChatService_Join_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChatClientFrame, ChatEvent]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_JoinClient = grpc.BidiStreamingClient[ChatClientFrame, ChatEvent]
+
+// ChatServiceServer is the server API for ChatService service.
+// All implementations must embed UnimplementedChatServiceServer
+// for forward compatibility.
+//
+// ChatService is the gRPC alternative to /ws/manga/:manga_id. Both transports
+// share the same rooms, so gRPC and WebSocket users talk to each other.
+type ChatServiceServer interface {
+	// The first client frame must set manga_id to pick the room; later frames
+	// carry message content. The server replays recent history, then streams
+	// live room events until either side closes. Not mapped by the
+	// grpc-gateway; JSON clients use the WebSocket endpoint instead.
+	Join(grpc.BidiStreamingServer[ChatClientFrame, ChatEvent]) error
+	mustEmbedUnimplementedChatServiceServer()
+}
+
+// UnimplementedChatServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChatServiceServer struct{}
+
+func (UnimplementedChatServiceServer) Join(grpc.BidiStreamingServer[ChatClientFrame, ChatEvent]) error {
+	return status.Error(codes.Unimplemented, "method Join not implemented")
+}
+func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
+func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeChatServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChatServiceServer will
+// result in compilation errors.
+type UnsafeChatServiceServer interface {
+	mustEmbedUnimplementedChatServiceServer()
+}
+
+func RegisterChatServiceServer(s grpc.ServiceRegistrar, srv ChatServiceServer) {
+	// If the following call panics, it indicates UnimplementedChatServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChatService_ServiceDesc, srv)
+}
+
+func _ChatService_Join_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChatServiceServer).Join(&grpc.GenericServerStream[ChatClientFrame, ChatEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatService_JoinServer = grpc.BidiStreamingServer[ChatClientFrame, ChatEvent]
+
+// ChatService_ServiceDesc is the grpc.ServiceDesc for ChatService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChatService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mangahub.v1.ChatService",
+	HandlerType: (*ChatServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Join",
+			Handler:       _ChatService_Join_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "manga.proto",
+}
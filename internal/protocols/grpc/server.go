@@ -5,15 +5,15 @@ import (
 	"fmt"
 	"net"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	grpc_logging "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
-	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"mangahub/internal/core"
 	pb "mangahub/internal/protocols/grpc/pb"
 	"mangahub/internal/repository"
 )
@@ -22,47 +22,67 @@ import (
 type Server struct {
 	server *grpc.Server
 	port   int
-	health *health.Server
+	health *HealthMonitor
 	stop   chan struct{}
 }
 
+// ServerOptions returns the interceptor chain shared by every gRPC server:
+// panic recovery first, then authentication and audit logging
+func ServerOptions(auth *AuthInterceptor) []grpc.ServerOption {
+	recoveryOpt := grpc_recovery.WithRecoveryHandlerContext(func(ctx context.Context, p interface{}) error {
+		logrus.WithField("panic", p).Error("gRPC handler panic recovered")
+		return status.Error(codes.Internal, "internal server error")
+	})
+
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			grpc_recovery.UnaryServerInterceptor(recoveryOpt),
+			auth.Unary(),
+		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			grpc_recovery.StreamServerInterceptor(recoveryOpt),
+			auth.Stream(),
+		)),
+	}
+}
+
+// RegisterStandardServices registers grpc.health.v1 (tracking database
+// health) and server reflection on a gRPC server. The returned monitor must be
+// started by the caller and shut down before the server stops.
+func RegisterStandardServices(server *grpc.Server, pool *pgxpool.Pool) *HealthMonitor {
+	healthMonitor := NewHealthMonitor(pool)
+	healthMonitor.Register(server)
+	reflection.Register(server)
+	return healthMonitor
+}
+
 // NewServer creates a new gRPC server with all required services
 func NewServer(
 	port int,
 	pool *pgxpool.Pool,
 	mangaRepo repository.MangaRepository,
 	statsRepo repository.StatsRepository,
+	mangaSvc core.MangaService,
+	commentSvc core.CommentService,
+	chatSvc core.ChatService,
+	activitySvc core.ActivityService,
+	statsSvc core.StatsService,
+	authSvc core.AuthService,
+	apiKeySvc core.APIKeyService,
+	requireAuth bool,
 ) *Server {
-	// Setup logging
-	logger := logrus.New()
-	grpcLogger := logrus.NewEntry(logger)
-
-	// Create health server
-	healthServer := health.NewServer()
-	healthServer.SetServingStatus("mangahub.v1.MangaService", grpc_health_v1.HealthCheckResponse_SERVING)
-
 	// Create gRPC server with middleware
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpc_logging.UnaryServerInterceptor(grpcLogger),
-			grpc_recovery.UnaryServerInterceptor(),
-		)),
-		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			grpc_logging.StreamServerInterceptor(grpcLogger),
-			grpc_recovery.StreamServerInterceptor(),
-		)),
-	)
+	server := grpc.NewServer(ServerOptions(NewAuthInterceptor(authSvc, apiKeySvc, requireAuth))...)
 
 	// Register services
-	mangaService := NewMangaServiceServer(pool, mangaRepo, statsRepo)
+	mangaService := NewMangaServiceServer(pool, mangaRepo, statsRepo, mangaSvc, commentSvc, chatSvc, activitySvc, statsSvc)
 	pb.RegisterMangaServiceServer(server, mangaService)
-	grpc_health_v1.RegisterHealthServer(server, healthServer)
-	reflection.Register(server)
+	healthMonitor := RegisterStandardServices(server, pool)
 
 	return &Server{
 		server: server,
 		port:   port,
-		health: healthServer,
+		health: healthMonitor,
 		stop:   make(chan struct{}),
 	}
 }
@@ -74,6 +94,8 @@ func (s *Server) Start() error {
 		return fmt.Errorf("failed to listen on port %d: %w", s.port, err)
 	}
 
+	s.health.Start()
+
 	go func() {
 		logrus.Infof("✅ gRPC server starting on port %d", s.port)
 		if err := s.server.Serve(listener); err != nil {
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"mangahub/internal/core"
 	pb "mangahub/internal/protocols/grpc/pb"
 	"mangahub/internal/repository"
 	"mangahub/pkg/models"
@@ -26,14 +28,35 @@ type MangaServiceServer struct {
 	pool      *pgxpool.Pool
 	mangaRepo repository.MangaRepository
 	statsRepo repository.StatsRepository
+
+	// Core services shared with REST for the write APIs
+	mangaSvc    core.MangaService
+	commentSvc  core.CommentService
+	chatSvc     core.ChatService
+	activitySvc core.ActivityService
+	statsSvc    core.StatsService
 }
 
 // NewMangaServiceServer creates a new gRPC manga service
-func NewMangaServiceServer(pool *pgxpool.Pool, mangaRepo repository.MangaRepository, statsRepo repository.StatsRepository) *MangaServiceServer {
+func NewMangaServiceServer(
+	pool *pgxpool.Pool,
+	mangaRepo repository.MangaRepository,
+	statsRepo repository.StatsRepository,
+	mangaSvc core.MangaService,
+	commentSvc core.CommentService,
+	chatSvc core.ChatService,
+	activitySvc core.ActivityService,
+	statsSvc core.StatsService,
+) *MangaServiceServer {
 	return &MangaServiceServer{
-		pool:      pool,
-		mangaRepo: mangaRepo,
-		statsRepo: statsRepo,
+		pool:        pool,
+		mangaRepo:   mangaRepo,
+		statsRepo:   statsRepo,
+		mangaSvc:    mangaSvc,
+		commentSvc:  commentSvc,
+		chatSvc:     chatSvc,
+		activitySvc: activitySvc,
+		statsSvc:    statsSvc,
 	}
 }
 
@@ -264,21 +287,26 @@ func (s *MangaServiceServer) GetManga(ctx context.Context, req *pb.GetMangaReque
 		return nil, status.Errorf(codes.InvalidArgument, "manga_id is required")
 	}
 
+	return s.buildMangaResponse(ctx, req.MangaId)
+}
+
+// buildMangaResponse loads a manga with genres and stats into its protobuf form
+func (s *MangaServiceServer) buildMangaResponse(ctx context.Context, mangaID string) (*pb.MangaResponse, error) {
 	// Get manga with genres
-	mangaWithGenres, err := s.mangaRepo.GetWithGenres(ctx, req.MangaId)
+	mangaWithGenres, err := s.mangaRepo.GetWithGenres(ctx, mangaID)
 	if err != nil {
-		if err.Error() == "manga not found" {
+		if errors.Is(err, models.ErrMangaNotFound) || err.Error() == "manga not found" {
 			return nil, status.Errorf(codes.NotFound, "manga not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get manga: %v", err)
 	}
 
 	// Get stats
-	stats, err := s.statsRepo.GetByMangaID(ctx, req.MangaId)
+	stats, err := s.statsRepo.GetByMangaID(ctx, mangaID)
 	if err != nil {
 		// Don't fail if stats are missing, use defaults
 		stats = &models.MangaStats{
-			MangaID: req.MangaId,
+			MangaID: mangaID,
 		}
 	}
 
@@ -307,7 +335,8 @@ func (s *MangaServiceServer) GetManga(ctx context.Context, req *pb.GetMangaReque
 	}, nil
 }
 
-// GetTrendingManga returns hot manga based on weekly_score
+// GetTrendingManga ranks manga with the shared scoring engine over a window
+// (24h, 7d or 30d), matching REST /api/v1/manga/trending
 func (s *MangaServiceServer) GetTrendingManga(ctx context.Context, req *pb.TrendingRequest) (*pb.TrendingResponse, error) {
 	if req.Limit <= 0 {
 		req.Limit = 10
@@ -316,16 +345,18 @@ func (s *MangaServiceServer) GetTrendingManga(ctx context.Context, req *pb.Trend
 		req.Limit = 50
 	}
 
-	// Get top manga by weekly score from stats repository
-	statsList, _, err := s.statsRepo.GetTopByWeeklyScore(ctx, int(req.Limit), 0)
+	trending, err := s.statsSvc.GetTrending(ctx, req.Window, int(req.Limit))
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidInput) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get trending manga: %v", err)
 	}
 
 	// Convert to protobuf responses
 	var results []*pb.MangaResponse
-	for _, stats := range statsList {
-		mangaWithGenres, err := s.mangaRepo.GetWithGenres(ctx, stats.MangaID)
+	for _, entry := range trending.Data {
+		mangaWithGenres, err := s.mangaRepo.GetWithGenres(ctx, entry.Manga.ID)
 		if err != nil {
 			continue // Skip if genres fail to load
 		}
@@ -339,13 +370,18 @@ func (s *MangaServiceServer) GetTrendingManga(ctx context.Context, req *pb.Trend
 			})
 		}
 
+		stats, err := s.statsRepo.GetByMangaID(ctx, entry.Manga.ID)
+		if err != nil {
+			stats = &models.MangaStats{}
+		}
+
 		results = append(results, &pb.MangaResponse{
-			Id:            stats.MangaID,
+			Id:            entry.Manga.ID,
 			Title:         mangaWithGenres.Manga.Title,
 			CoverUrl:      mangaWithGenres.Manga.CoverURL,
 			Status:        string(mangaWithGenres.Manga.Status),
 			Genres:        genres,
-			RelevanceScore: float32(stats.WeeklyScore) / 1000.0, // Normalize score
+			RelevanceScore: float32(entry.Score),
 			CommentCount:  int32(stats.CommentCount),
 			ChatCount:     int32(stats.ChatCount),
 			WeeklyScore:   int32(stats.WeeklyScore),
@@ -354,7 +390,8 @@ func (s *MangaServiceServer) GetTrendingManga(ctx context.Context, req *pb.Trend
 	}
 
 	return &pb.TrendingResponse{
-		Manga: results,
+		Manga:  results,
+		Window: trending.Window,
 	}, nil
 }
 
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mangahub/pkg/models"
+)
+
+// createAPIKey issues a new scoped API key (admin only)
+func (s *Server) createAPIKey(c *gin.Context) {
+	userID, ok := GetUserID(c)
+	if !ok {
+		c.JSON(401, models.APIResponse{
+			Success:   false,
+			Error:     "unauthorized",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, models.APIResponse{
+			Success:   false,
+			Error:     "invalid request body",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	resp, err := s.apiKeySvc.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		c.JSON(400, models.APIResponse{
+			Success:   false,
+			Error:     err.Error(),
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(201, models.APIResponse{
+		Success:   true,
+		Message:   "API key created; store it now, it will not be shown again",
+		Data:      resp,
+		Timestamp: time.Now(),
+	})
+}
+
+// listAPIKeys returns all API keys without their secrets (admin only)
+func (s *Server) listAPIKeys(c *gin.Context) {
+	keys, err := s.apiKeySvc.List(c.Request.Context())
+	if err != nil {
+		c.JSON(500, models.APIResponse{
+			Success:   false,
+			Error:     "failed to list api keys",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if keys == nil {
+		keys = []*models.APIKey{}
+	}
+
+	c.JSON(200, models.APIResponse{
+		Success:   true,
+		Data:      gin.H{"api_keys": keys},
+		Timestamp: time.Now(),
+	})
+}
+
+// revokeAPIKey revokes an API key (admin only)
+func (s *Server) revokeAPIKey(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := s.apiKeySvc.Revoke(c.Request.Context(), id); err != nil {
+		code := 500
+		if errors.Is(err, models.ErrNotFound) {
+			code = 404
+		}
+		c.JSON(code, models.APIResponse{
+			Success:   false,
+			Error:     "api key not found or already revoked",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(200, models.APIResponse{
+		Success:   true,
+		Message:   "API key revoked",
+		Timestamp: time.Now(),
+	})
+}
+
+// sendNotification broadcasts a system announcement over UDP.
+// Allowed for admins and for API keys carrying notifications:send.
+func (s *Server) sendNotification(c *gin.Context) {
+	if _, viaKey := GetAPIKey(c); !viaKey {
+		user, ok := GetUser(c)
+		if !ok || user.Role != models.UserRoleAdmin {
+			c.JSON(403, models.APIResponse{
+				Success:   false,
+				Error:     "forbidden: admin access required",
+				Timestamp: time.Now(),
+			})
+			return
+		}
+	}
+
+	var req struct {
+		Message string `json:"message" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, models.APIResponse{
+			Success:   false,
+			Error:     "invalid request body",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if s.udpServer == nil {
+		c.JSON(503, models.APIResponse{
+			Success:   false,
+			Error:     "notification service unavailable",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	s.udpServer.SendSystemNotification(req.Message)
+
+	c.JSON(202, models.APIResponse{
+		Success:   true,
+		Message:   "Notification queued",
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mangahub/pkg/models"
+)
+
+// listMentions returns chat messages that mention the current user, so
+// users can catch up on mentions made while they were offline
+func (s *Server) listMentions(c *gin.Context) {
+	userID, ok := GetUserID(c)
+	if !ok {
+		c.JSON(401, models.APIResponse{
+			Success:   false,
+			Error:     "unauthorized",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	// Parse pagination
+	page := 1
+	limit := 50
+
+	if p := c.Query("page"); p != "" {
+		if v, err := strconv.Atoi(p); err == nil && v > 0 {
+			page = v
+		}
+	}
+
+	if l := c.Query("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 && v <= 100 {
+			limit = v
+		}
+	}
+
+	result, err := s.chatSvc.GetMentions(c.Request.Context(), userID, limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(500, models.APIResponse{
+			Success:   false,
+			Error:     "failed to get mentions",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(200, models.APIResponse{
+		Success:   true,
+		Data:      result,
+		Timestamp: time.Now(),
+	})
+}
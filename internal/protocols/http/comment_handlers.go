@@ -1,13 +1,11 @@
 package http
 
 import (
-	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
-	tcpProtocol "mangahub/internal/protocols/tcp"
 	"mangahub/pkg/models"
 )
 
@@ -54,28 +52,6 @@ func (s *Server) createComment(c *gin.Context) {
 		return
 	}
 
-	// CROSS-PROTOCOL INTEGRATION:
-	// 1. Record activity to activity_feed
-	_ = s.activitySvc.CreateActivity(c.Request.Context(), "comment", &userID, &mangaID)
-
-	// 2. Emit TCP stats event for real-time aggregation
-	if s.tcpAddr != "" {
-		go func() {
-			event := tcpProtocol.StatsEvent{
-				Type:      tcpProtocol.EventTypeComment,
-				MangaID:   mangaID,
-				UserID:    &userID,
-				EventTime: time.Now(),
-				Weight:    1,
-				Source:    "http",
-			}
-			if err := tcpProtocol.SendStatsEvent(s.tcpAddr, event); err != nil {
-				// Log error but don't fail the request
-				fmt.Printf("Failed to emit TCP stats event: %v\n", err)
-			}
-		}()
-	}
-
 	c.JSON(201, models.APIResponse{
 		Success:   true,
 		Message:   "Comment created successfully",
@@ -163,9 +139,6 @@ func (s *Server) likeComment(c *gin.Context) {
 		return
 	}
 
-	// Record activity
-	_ = s.activitySvc.CreateActivity(c.Request.Context(), "comment", &userID, nil)
-
 	c.JSON(200, models.APIResponse{
 		Success:   true,
 		Message:   "Comment liked successfully",
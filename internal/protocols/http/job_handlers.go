@@ -0,0 +1,69 @@
+package http
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"mangahub/internal/core"
+	"mangahub/pkg/models"
+)
+
+// listJobs returns each scheduled job with its schedule and last run (admin only)
+func (s *Server) listJobs(c *gin.Context) {
+	jobs, err := s.schedulerSvc.ListJobs(c.Request.Context())
+	if err != nil {
+		c.JSON(500, models.APIResponse{
+			Success:   false,
+			Error:     "failed to list jobs",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(200, models.APIResponse{
+		Success:   true,
+		Data:      gin.H{"jobs": jobs},
+		Timestamp: time.Now(),
+	})
+}
+
+// runJob runs a job now and waits for it to finish (admin only)
+func (s *Server) runJob(c *gin.Context) {
+	userID, _ := GetUserID(c)
+
+	run, err := s.schedulerSvc.Trigger(c.Request.Context(), c.Param("name"), userID)
+	switch {
+	case errors.Is(err, core.ErrUnknownJob):
+		c.JSON(404, models.APIResponse{
+			Success:   false,
+			Error:     "job not found",
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, core.ErrJobAlreadyRunning):
+		c.JSON(409, models.APIResponse{
+			Success:   false,
+			Error:     "job is already running on another instance",
+			Data:      run,
+			Timestamp: time.Now(),
+		})
+		return
+	case err != nil:
+		c.JSON(500, models.APIResponse{
+			Success:   false,
+			Error:     "job failed: " + err.Error(),
+			Data:      run,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(200, models.APIResponse{
+		Success:   true,
+		Message:   "Job completed",
+		Data:      run,
+		Timestamp: time.Now(),
+	})
+}
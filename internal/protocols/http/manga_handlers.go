@@ -1,14 +1,12 @@
 package http
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
-	tcpProtocol "mangahub/internal/protocols/tcp"
-	udpProtocol "mangahub/internal/protocols/udp"
 	"mangahub/pkg/models"
 )
 
@@ -52,7 +50,7 @@ func (s *Server) createManga(c *gin.Context) {
 		return
 	}
 
-	manga, err := s.mangaSvc.Create(c.Request.Context(), req)
+	manga, err := s.mangaSvc.Create(c.Request.Context(), userID, req)
 	if err != nil {
 		c.JSON(400, models.APIResponse{
 			Success:   false,
@@ -62,35 +60,6 @@ func (s *Server) createManga(c *gin.Context) {
 		return
 	}
 
-	// CROSS-PROTOCOL INTEGRATION:
-	// 1. Record activity to activity_feed
-	_ = s.activitySvc.CreateActivity(c.Request.Context(), "manga_update", &userID, &manga.ID)
-
-	// 2. UDP broadcast notification (admin action broadcast)
-	if s.udpServer != nil {
-		mangaID := manga.ID
-		notification := udpProtocol.Notification{
-			Type:    "manga_update",
-			MangaID: &mangaID,
-			Title:   manga.Title,
-			Message: fmt.Sprintf("New manga '%s' added by %s", manga.Title, user.Username),
-		}
-		s.udpServer.Broadcast(notification)
-	}
-
-	// 3. TCP stats event
-	if s.tcpAddr != "" {
-		event := tcpProtocol.StatsEvent{
-			Type:      tcpProtocol.EventTypeUpdate,
-			MangaID:   manga.ID,
-			UserID:    &userID,
-			EventTime: time.Now(),
-			Weight:    5,
-			Source:    "http",
-		}
-		_ = tcpProtocol.SendStatsEvent(s.tcpAddr, event)
-	}
-
 	c.JSON(201, models.APIResponse{
 		Success:   true,
 		Message:   "Manga created successfully",
@@ -227,7 +196,8 @@ func (s *Server) searchManga(c *gin.Context) {
 	})
 }
 
-// getTrendingManga returns trending manga (top manga by weekly score)
+// getTrendingManga returns manga ranked by the shared scoring engine
+// Query: window=24h|7d|30d (default 7d), limit
 func (s *Server) getTrendingManga(c *gin.Context) {
 	// Parse limit
 	limit := 10
@@ -237,9 +207,16 @@ func (s *Server) getTrendingManga(c *gin.Context) {
 		}
 	}
 
-	// Use GetTopManga from stats service which returns top manga by weekly score
-	result, err := s.statsSvc.GetTopManga(c.Request.Context(), limit, 0)
+	result, err := s.statsSvc.GetTrending(c.Request.Context(), c.Query("window"), limit)
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidInput) {
+			c.JSON(400, models.APIResponse{
+				Success:   false,
+				Error:     err.Error(),
+				Timestamp: time.Now(),
+			})
+			return
+		}
 		c.JSON(500, models.APIResponse{
 			Success:   false,
 			Error:     "failed to get trending manga",
@@ -287,7 +264,7 @@ func (s *Server) updateManga(c *gin.Context) {
 		return
 	}
 
-	manga, err := s.mangaSvc.Update(c.Request.Context(), id, req)
+	manga, err := s.mangaSvc.Update(c.Request.Context(), userID, id, req)
 	if err != nil {
 		c.JSON(400, models.APIResponse{
 			Success:   false,
@@ -297,9 +274,6 @@ func (s *Server) updateManga(c *gin.Context) {
 		return
 	}
 
-	// Record activity
-	_ = s.activitySvc.CreateActivity(c.Request.Context(), "manga_update", &userID, &manga.ID)
-
 	c.JSON(200, models.APIResponse{
 		Success:   true,
 		Message:   "Manga updated successfully",
@@ -310,8 +284,7 @@ func (s *Server) updateManga(c *gin.Context) {
 
 // deleteManga deletes a manga
 func (s *Server) deleteManga(c *gin.Context) {
-	userID, ok := GetUserID(c)
-	if !ok {
+	if _, ok := GetUserID(c); !ok {
 		c.JSON(401, models.APIResponse{
 			Success:   false,
 			Error:     "unauthorized",
@@ -339,9 +312,6 @@ func (s *Server) deleteManga(c *gin.Context) {
 		return
 	}
 
-	// Record activity
-	_ = s.activitySvc.CreateActivity(c.Request.Context(), "manga_update", &userID, &id)
-
 	c.JSON(200, models.APIResponse{
 		Success:   true,
 		Message:   "Manga deleted successfully",
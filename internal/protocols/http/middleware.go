@@ -9,8 +9,10 @@ import (
 	"mangahub/pkg/models"
 )
 
-// AuthMiddleware validates JWT token and sets user context
-func AuthMiddleware(authSvc core.AuthService) gin.HandlerFunc {
+// AuthMiddleware validates a JWT ("Bearer <token>") or API key ("ApiKey <key>")
+// and sets user context. API keys are only accepted when the route declares the
+// scopes it needs and the key grants all of them.
+func AuthMiddleware(authSvc core.AuthService, apiKeySvc core.APIKeyService, scopes ...models.APIKeyScope) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -20,14 +22,48 @@ func AuthMiddleware(authSvc core.AuthService) gin.HandlerFunc {
 			return
 		}
 
-		// Extract token from "Bearer <token>"
+		// Extract credential from "<scheme> <credential>"
 		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
+		if len(parts) != 2 || (parts[0] != "Bearer" && parts[0] != "ApiKey") {
 			c.JSON(401, gin.H{"error": "invalid authorization format"})
 			c.Abort()
 			return
 		}
 
+		if parts[0] == "ApiKey" {
+			if apiKeySvc == nil {
+				c.JSON(401, gin.H{"error": "api keys are not enabled"})
+				c.Abort()
+				return
+			}
+
+			user, key, err := apiKeySvc.Authenticate(c.Request.Context(), parts[1])
+			if err != nil {
+				c.JSON(401, gin.H{"error": "unauthorized"})
+				c.Abort()
+				return
+			}
+
+			if len(scopes) == 0 {
+				c.JSON(403, gin.H{"error": "forbidden: api keys are not accepted for this route"})
+				c.Abort()
+				return
+			}
+			for _, scope := range scopes {
+				if !key.HasScope(scope) {
+					c.JSON(403, gin.H{"error": "forbidden: api key missing scope " + string(scope)})
+					c.Abort()
+					return
+				}
+			}
+
+			c.Set("user_id", user.ID)
+			c.Set("user", user)
+			c.Set("api_key", key)
+			c.Next()
+			return
+		}
+
 		token := parts[1]
 
 		// Validate token
@@ -66,6 +102,17 @@ func GetUser(c *gin.Context) (*models.User, bool) {
 	return u, ok
 }
 
+// GetAPIKey returns the API key used to authenticate the request, if any
+func GetAPIKey(c *gin.Context) (*models.APIKey, bool) {
+	key, exists := c.Get("api_key")
+	if !exists {
+		return nil, false
+	}
+
+	k, ok := key.(*models.APIKey)
+	return k, ok
+}
+
 // AdminMiddleware ensures the user has admin role
 func AdminMiddleware(authSvc core.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
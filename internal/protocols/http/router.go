@@ -10,6 +10,7 @@ import (
 	"mangahub/internal/core"
 	udpProtocol "mangahub/internal/protocols/udp"
 	"mangahub/pkg/config"
+	"mangahub/pkg/models"
 )
 
 var wsUpgrader = websocket.Upgrader{
@@ -22,16 +23,17 @@ var wsUpgrader = websocket.Upgrader{
 
 // Server manages HTTP REST API server
 type Server struct {
-	router      *gin.Engine
-	config      *config.Config
-	authSvc     core.AuthService
-	mangaSvc    core.MangaService
-	commentSvc  core.CommentService
-	chatSvc     core.ChatService
-	activitySvc core.ActivityService
-	statsSvc    core.StatsService
-	udpServer   *udpProtocol.Server // For broadcasting admin events
-	tcpAddr     string              // TCP server address for stats events
+	router       *gin.Engine
+	config       *config.Config
+	authSvc      core.AuthService
+	mangaSvc     core.MangaService
+	commentSvc   core.CommentService
+	chatSvc      core.ChatService
+	activitySvc  core.ActivityService
+	statsSvc     core.StatsService
+	apiKeySvc    core.APIKeyService
+	schedulerSvc core.SchedulerService
+	udpServer    *udpProtocol.Server // For broadcasting admin events
 }
 
 // NewServer creates a new HTTP server with all handlers
@@ -43,6 +45,8 @@ func NewServer(
 	chatSvc core.ChatService,
 	activitySvc core.ActivityService,
 	statsSvc core.StatsService,
+	apiKeySvc core.APIKeyService,
+	schedulerSvc core.SchedulerService,
 ) *Server {
 	// Set Gin to release mode by default
 	gin.SetMode(gin.ReleaseMode)
@@ -55,24 +59,25 @@ func NewServer(
 	router.Use(corsMiddleware())
 	
 	s := &Server{
-		router:      router,
-		config:      cfg,
-		authSvc:     authSvc,
-		mangaSvc:    mangaSvc,
-		commentSvc:  commentSvc,
-		chatSvc:     chatSvc,
-		activitySvc: activitySvc,
-		statsSvc:    statsSvc,
+		router:       router,
+		config:       cfg,
+		authSvc:      authSvc,
+		mangaSvc:     mangaSvc,
+		commentSvc:   commentSvc,
+		chatSvc:      chatSvc,
+		activitySvc:  activitySvc,
+		statsSvc:     statsSvc,
+		apiKeySvc:    apiKeySvc,
+		schedulerSvc: schedulerSvc,
 	}
 
 	s.setupRoutes()
 	return s
 }
 
-// SetCrossProtocolServers sets UDP and TCP servers for cross-protocol event emission
-func (s *Server) SetCrossProtocolServers(udpServer *udpProtocol.Server, tcpAddr string) {
+// SetCrossProtocolServers sets the UDP server for admin broadcasts
+func (s *Server) SetCrossProtocolServers(udpServer *udpProtocol.Server) {
 	s.udpServer = udpServer
-	s.tcpAddr = tcpAddr
 }
 
 // setupRoutes registers all HTTP routes
@@ -91,19 +96,27 @@ func (s *Server) setupRoutes() {
 		}
 
 		// Admin routes (requires admin role)
-		admin := v1.Group("/admin", AuthMiddleware(s.authSvc), AdminMiddleware(s.authSvc))
+		admin := v1.Group("/admin", AuthMiddleware(s.authSvc, s.apiKeySvc), AdminMiddleware(s.authSvc))
 		{
 			admin.PUT("/users/:id/role", s.updateUserRole)  // Update user role
+			admin.POST("/api-keys", s.createAPIKey)         // Issue API key
+			admin.GET("/api-keys", s.listAPIKeys)           // List API keys
+			admin.DELETE("/api-keys/:id", s.revokeAPIKey)   // Revoke API key
+			admin.GET("/jobs", s.listJobs)                  // Scheduled jobs + last run
+			admin.POST("/jobs/:name/run", s.runJob)         // Run a job now
 		}
 
+		// Notification routes (admins or API keys with notifications:send)
+		v1.POST("/notifications", AuthMiddleware(s.authSvc, s.apiKeySvc, models.ScopeNotificationsSend), s.sendNotification)
+
 		// Manga routes
 		v1.GET("/manga", s.listManga)                  // Public: list manga
 		v1.GET("/manga/search", s.searchManga)         // Public: search
-		v1.GET("/manga/trending", s.getTrendingManga)  // Public: trending manga
 		v1.GET("/manga/:id", s.getManga)               // Public: get single manga
+		v1.GET("/manga/:id/stats/daily", s.getMangaTimeSeries)  // Public: daily stats time series
 		
 		// Protected manga routes
-		protected := v1.Group("", AuthMiddleware(s.authSvc))
+		protected := v1.Group("", AuthMiddleware(s.authSvc, s.apiKeySvc, models.ScopeMangaWrite))
 		{
 			protected.POST("/manga", s.createManga)            // Create manga
 			protected.PUT("/manga/:id", s.updateManga)         // Update manga
@@ -113,13 +126,19 @@ func (s *Server) setupRoutes() {
 		// Comment routes (use same parameter name :id to avoid conflicts)
 		v1.GET("/manga/:id/comments", s.listComments)        // Public: list comments
 		
-		protectedComments := v1.Group("", AuthMiddleware(s.authSvc))
+		protectedComments := v1.Group("", AuthMiddleware(s.authSvc, s.apiKeySvc))
 		{
 			protectedComments.POST("/manga/:id/comments", s.createComment)                     // Create comment
 			protectedComments.POST("/manga/:id/comments/:comment_id/like", s.likeComment)      // Like comment
 			protectedComments.DELETE("/manga/:id/comments/:comment_id", s.deleteComment)       // Delete own comment
 		}
 
+		// Current user routes
+		me := v1.Group("/me", AuthMiddleware(s.authSvc, s.apiKeySvc))
+		{
+			me.GET("/mentions", s.listMentions)  // Chat messages mentioning me
+		}
+
 		// Activity routes
 		activity := v1.Group("/activity")
 		{
@@ -127,7 +146,7 @@ func (s *Server) setupRoutes() {
 			activity.GET("/recent", s.getGlobalFeed)           // Alias for global feed
 			activity.GET("/manga/:manga_id", s.getMangaFeed)   // Public: manga feed
 			
-			protected := activity.Group("", AuthMiddleware(s.authSvc))
+			protected := activity.Group("", AuthMiddleware(s.authSvc, s.apiKeySvc))
 			{
 				protected.GET("/user/:user_id", s.getUserFeed)  // Get user feed
 			}
@@ -137,9 +156,17 @@ func (s *Server) setupRoutes() {
 		stats := v1.Group("/stats")
 		{
 			stats.GET("/top", s.getTopManga)  // Top manga by weekly score
+			stats.GET("/daily", s.getGlobalTimeSeries)   // Daily activity across all manga
+		}
+
+		// Trending and leaderboard routes (users or API keys with stats:read)
+		statsRead := v1.Group("", AuthMiddleware(s.authSvc, s.apiKeySvc, models.ScopeStatsRead))
+		{
+			statsRead.GET("/manga/trending", s.getTrendingManga)       // Trending manga
+			statsRead.GET("/stats/leaderboard", s.getLeaderboard)      // Top users by category and window
 		}
 
-		// Statistics and leaderboard routes (public)
+		// Statistics routes (public)
 		v1.GET("/statistics/user/:id", s.getUserStatistics)  // User statistics
 	}
 }
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
@@ -36,6 +37,93 @@ func (s *Server) getTopManga(c *gin.Context) {
 	})
 }
 
+// getLeaderboard returns user rankings by contribution
+// Query: category=commenters|liked_commenters|chatters, window=weekly|monthly|all_time, limit
+func (s *Server) getLeaderboard(c *gin.Context) {
+	limit := 10
+	if l := c.Query("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 && v <= 100 {
+			limit = v
+		}
+	}
+
+	leaderboard, err := s.statsSvc.GetLeaderboard(c.Request.Context(), c.Query("category"), c.Query("window"), limit)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidInput) {
+			c.JSON(400, models.APIResponse{
+				Success:   false,
+				Error:     err.Error(),
+				Timestamp: time.Now(),
+			})
+			return
+		}
+		c.JSON(500, models.APIResponse{
+			Success:   false,
+			Error:     "failed to get leaderboard",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(200, models.APIResponse{
+		Success:   true,
+		Data:      leaderboard,
+		Timestamp: time.Now(),
+	})
+}
+
+// getGlobalTimeSeries returns daily activity summed over every manga
+// Query: days (default 30, max 365)
+func (s *Server) getGlobalTimeSeries(c *gin.Context) {
+	days, _ := strconv.Atoi(c.Query("days"))
+
+	series, err := s.statsSvc.GetGlobalTimeSeries(c.Request.Context(), days)
+	if err != nil {
+		c.JSON(500, models.APIResponse{
+			Success:   false,
+			Error:     "failed to get time series",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(200, models.APIResponse{
+		Success:   true,
+		Data:      series,
+		Timestamp: time.Now(),
+	})
+}
+
+// getMangaTimeSeries returns daily comments, chats, likes and score for a manga
+// Query: days (default 30, max 365)
+func (s *Server) getMangaTimeSeries(c *gin.Context) {
+	days, _ := strconv.Atoi(c.Query("days"))
+
+	series, err := s.statsSvc.GetMangaTimeSeries(c.Request.Context(), c.Param("id"), days)
+	if err != nil {
+		if errors.Is(err, models.ErrMangaNotFound) {
+			c.JSON(404, models.APIResponse{
+				Success:   false,
+				Error:     "manga not found",
+				Timestamp: time.Now(),
+			})
+			return
+		}
+		c.JSON(500, models.APIResponse{
+			Success:   false,
+			Error:     "failed to get time series",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	c.JSON(200, models.APIResponse{
+		Success:   true,
+		Data:      series,
+		Timestamp: time.Now(),
+	})
+}
+
 // getUserStatistics returns statistics for a specific user
 func (s *Server) getUserStatistics(c *gin.Context) {
 	userID := c.Param("id")
@@ -0,0 +1,300 @@
+package tcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mangahub/internal/repository"
+	"mangahub/pkg/models"
+)
+
+// BatchConfig controls how the aggregator buffers events before writing them
+type BatchConfig struct {
+	Size          int           // Flush once this many events are buffered
+	FlushInterval time.Duration // Flush at least this often while events are pending
+	DedupeWindow  time.Duration // How long processed event IDs are kept in the database
+}
+
+// batchKey identifies one coalesced update: a manga on a day
+type batchKey struct {
+	mangaID string
+	day     string
+}
+
+// batcher buffers stats events in memory, coalesces them per manga and day,
+// and writes them with one BatchUpdateStats call on a size or time trigger.
+// Callers get a channel that receives the result of the flush carrying their
+// event, so acknowledgements can wait until the event is durable.
+//
+// Event IDs are recorded in the database with the batch, so an event is
+// counted once even when it is replayed after a restart or redelivered by the
+// outbox. A replay arriving while its original is buffered or being written
+// waits for that flush and gets the same result.
+type batcher struct {
+	statsRepo    repository.StatsRepository
+	activityRepo repository.ActivityRepository
+	cfg          BatchConfig
+	flushed      func([]models.StatsUpdate) // Called after each successful write
+
+	mu      sync.Mutex
+	buf     *pendingBatch
+	pending map[string]*pendingBatch // Event ID -> batch buffering or writing it
+	closed  bool                     // Set by close; later events are refused
+
+	full chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// pendingBatch is the events of one flush and the callers waiting on it
+type pendingBatch struct {
+	events  []bufferedEvent
+	waiters []chan error
+}
+
+// bufferedEvent is one event's increments, kept separate until the flush so
+// events already processed can be left out
+type bufferedEvent struct {
+	id       string
+	update   models.StatsUpdate
+	activity *models.Activity
+}
+
+const (
+	maxDuplicateRetries = 3           // Flush attempts after finding already-processed events
+	purgeInterval       = time.Minute // Between purges of old processed event IDs
+)
+
+// errBatcherClosed is the result of an event added after close
+var errBatcherClosed = errors.New("stats server is shutting down")
+
+// newBatcher creates a batcher; call run to start flushing. flushed, if not
+// nil, receives every batch of updates once it is written.
+func newBatcher(statsRepo repository.StatsRepository, activityRepo repository.ActivityRepository, cfg BatchConfig, flushed func([]models.StatsUpdate)) *batcher {
+	if cfg.Size <= 0 {
+		cfg.Size = 100
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 250 * time.Millisecond
+	}
+	if cfg.DedupeWindow <= 0 {
+		cfg.DedupeWindow = 24 * time.Hour
+	}
+
+	return &batcher{
+		statsRepo:    statsRepo,
+		activityRepo: activityRepo,
+		cfg:          cfg,
+		flushed:      flushed,
+		buf:          &pendingBatch{},
+		pending:      make(map[string]*pendingBatch),
+		full:         make(chan struct{}, 1),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+// add buffers an event's increments and, if non-nil, its activity_feed row.
+// The returned channel receives the flush result exactly once; after close
+// it receives errBatcherClosed at once.
+func (b *batcher) add(eventID string, update models.StatsUpdate, activity *models.Activity) <-chan error {
+	result := make(chan error, 1)
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		result <- errBatcherClosed
+		return result
+	}
+	if eventID != "" {
+		if batch, ok := b.pending[eventID]; ok {
+			// Replay of an event not yet written: share its outcome
+			batch.waiters = append(batch.waiters, result)
+			b.mu.Unlock()
+			return result
+		}
+		b.pending[eventID] = b.buf
+	}
+
+	b.buf.events = append(b.buf.events, bufferedEvent{id: eventID, update: update, activity: activity})
+	b.buf.waiters = append(b.buf.waiters, result)
+	full := len(b.buf.events) >= b.cfg.Size
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+
+	return result
+}
+
+// run flushes on the interval or when the buffer fills, until close
+func (b *batcher) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.cfg.FlushInterval)
+	defer ticker.Stop()
+	purge := time.NewTicker(purgeInterval)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.flush()
+		case <-b.full:
+			b.flush()
+		case <-purge.C:
+			b.purge()
+		case <-b.stop:
+			b.flush()
+			return
+		}
+	}
+}
+
+// close refuses further events and stops the flush loop after writing
+// anything still buffered
+func (b *batcher) close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.stop)
+	<-b.done
+}
+
+// flush writes the buffered activities and counter increments and reports
+// the outcome to every waiting caller
+func (b *batcher) flush() {
+	b.mu.Lock()
+	batch := b.buf
+	if len(batch.events) == 0 {
+		b.mu.Unlock()
+		return
+	}
+	b.buf = &pendingBatch{}
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	updates, skipped, err := b.write(ctx, batch.events)
+	if err != nil {
+		err = fmt.Errorf("failed to write stats batch: %w", err)
+		fmt.Printf("❌ TCP flush of %d events failed: %v\n", len(batch.events), err)
+	} else {
+		fmt.Printf("📊 TCP flushed %d events as %d updates (%d already processed)\n",
+			len(batch.events), len(updates), len(skipped))
+
+		// The activity feed is an audit trail; a failed row should not fail
+		// events whose stats are already written
+		for _, event := range batch.events {
+			if event.activity == nil || skipped[event.id] {
+				continue
+			}
+			if err := b.activityRepo.Create(ctx, event.activity); err != nil {
+				fmt.Printf("❌ TCP failed to log activity %s: %v\n", event.activity.ID, err)
+			}
+		}
+		if b.flushed != nil && len(updates) > 0 {
+			b.flushed(updates)
+		}
+	}
+
+	// Later replays go to the database, which knows whether they were written
+	b.mu.Lock()
+	for _, event := range batch.events {
+		if event.id != "" {
+			delete(b.pending, event.id)
+		}
+	}
+	waiters := batch.waiters
+	b.mu.Unlock()
+
+	for _, waiter := range waiters {
+		waiter <- err
+	}
+}
+
+// write coalesces events and writes them, leaving out events the database
+// reports as already processed. It returns the updates written and the IDs
+// that were skipped.
+func (b *batcher) write(ctx context.Context, events []bufferedEvent) ([]models.StatsUpdate, map[string]bool, error) {
+	skipped := make(map[string]bool)
+	for attempt := 0; ; attempt++ {
+		updates, ids := coalesce(events, skipped)
+		if len(updates) == 0 && len(ids) == 0 {
+			return nil, skipped, nil
+		}
+
+		err := b.statsRepo.BatchUpdateStats(ctx, updates, ids)
+		var dupes *repository.DuplicateEventsError
+		if !errors.As(err, &dupes) || attempt == maxDuplicateRetries {
+			return updates, skipped, err
+		}
+		for _, id := range dupes.IDs {
+			skipped[id] = true
+		}
+	}
+}
+
+// coalesce sums the increments of events not in skip per manga and day,
+// sorted so concurrent batches lock rows in the same order, and returns them
+// with the IDs of the events included
+func coalesce(events []bufferedEvent, skip map[string]bool) ([]models.StatsUpdate, []string) {
+	byKey := make(map[batchKey]*models.StatsUpdate)
+	var ids []string
+	for _, event := range events {
+		if event.id != "" {
+			if skip[event.id] {
+				continue
+			}
+			ids = append(ids, event.id)
+		}
+
+		update := event.update
+		key := batchKey{mangaID: update.MangaID, day: update.Day}
+		if pending, ok := byKey[key]; ok {
+			pending.CommentCount += update.CommentCount
+			pending.LikeCount += update.LikeCount
+			pending.ChatCount += update.ChatCount
+			pending.WeeklyScore += update.WeeklyScore
+		} else {
+			byKey[key] = &update
+		}
+	}
+
+	updates := make([]models.StatsUpdate, 0, len(byKey))
+	for _, update := range byKey {
+		updates = append(updates, *update)
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		if updates[i].MangaID != updates[j].MangaID {
+			return updates[i].MangaID < updates[j].MangaID
+		}
+		return updates[i].Day < updates[j].Day
+	})
+	return updates, ids
+}
+
+// purge forgets processed event IDs older than the dedupe window
+func (b *batcher) purge() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	removed, err := b.statsRepo.PurgeProcessedEvents(ctx, time.Now().Add(-b.cfg.DedupeWindow))
+	if err != nil {
+		fmt.Printf("❌ TCP failed to purge processed event IDs: %v\n", err)
+		return
+	}
+	if removed > 0 {
+		fmt.Printf("🧹 TCP purged %d processed event IDs\n", removed)
+	}
+}
@@ -0,0 +1,297 @@
+package tcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mangahub/internal/repository"
+	"mangahub/pkg/models"
+)
+
+// fakeStatsRepo records batches and remembers processed event IDs like
+// stats_processed_events
+type fakeStatsRepo struct {
+	repository.StatsRepository
+
+	mu        sync.Mutex
+	processed map[string]bool
+	batches   [][]models.StatsUpdate
+	fail      error         // Returned (once) instead of writing
+	block     chan struct{} // If set, writes wait until it is closed
+}
+
+func newFakeStatsRepo() *fakeStatsRepo {
+	return &fakeStatsRepo{processed: make(map[string]bool)}
+}
+
+func (r *fakeStatsRepo) BatchUpdateStats(ctx context.Context, updates []models.StatsUpdate, eventIDs []string) error {
+	if r.block != nil {
+		<-r.block
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fail != nil {
+		err := r.fail
+		r.fail = nil
+		return err
+	}
+
+	var dupes []string
+	for _, id := range eventIDs {
+		if r.processed[id] {
+			dupes = append(dupes, id)
+		}
+	}
+	if len(dupes) > 0 {
+		return &repository.DuplicateEventsError{IDs: dupes}
+	}
+	for _, id := range eventIDs {
+		r.processed[id] = true
+	}
+	r.batches = append(r.batches, updates)
+	return nil
+}
+
+func (r *fakeStatsRepo) PurgeProcessedEvents(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeStatsRepo) chats() int {
+	count := 0
+	for _, update := range r.written() {
+		count += update.ChatCount
+	}
+	return count
+}
+
+func (r *fakeStatsRepo) written() []models.StatsUpdate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var all []models.StatsUpdate
+	for _, batch := range r.batches {
+		all = append(all, batch...)
+	}
+	return all
+}
+
+type fakeActivityRepo struct {
+	repository.ActivityRepository
+
+	mu      sync.Mutex
+	created []string
+}
+
+func (r *fakeActivityRepo) Create(ctx context.Context, activity *models.Activity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.created = append(r.created, activity.ID)
+	return nil
+}
+
+func chatUpdate(mangaID, day string) models.StatsUpdate {
+	return models.StatsUpdate{MangaID: mangaID, Day: day, ChatCount: 1, WeeklyScore: 1}
+}
+
+// newTestBatcher returns a batcher that only flushes when asked
+func newTestBatcher(statsRepo *fakeStatsRepo, activityRepo *fakeActivityRepo) *batcher {
+	return newBatcher(statsRepo, activityRepo, BatchConfig{Size: 1000, FlushInterval: time.Hour}, nil)
+}
+
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []bufferedEvent
+		skip    map[string]bool
+		updates []models.StatsUpdate
+		ids     []string
+	}{
+		{
+			name:    "empty",
+			updates: []models.StatsUpdate{},
+		},
+		{
+			name: "same manga and day are summed",
+			events: []bufferedEvent{
+				{id: "e1", update: chatUpdate("m1", "2026-01-01")},
+				{id: "e2", update: models.StatsUpdate{MangaID: "m1", Day: "2026-01-01", LikeCount: 1, WeeklyScore: 2}},
+				{update: chatUpdate("m1", "2026-01-01")},
+			},
+			updates: []models.StatsUpdate{
+				{MangaID: "m1", Day: "2026-01-01", ChatCount: 2, LikeCount: 1, WeeklyScore: 4},
+			},
+			ids: []string{"e1", "e2"},
+		},
+		{
+			name: "sorted by manga then day",
+			events: []bufferedEvent{
+				{id: "e1", update: chatUpdate("m2", "2026-01-01")},
+				{id: "e2", update: chatUpdate("m1", "2026-01-02")},
+				{id: "e3", update: chatUpdate("m1", "2026-01-01")},
+			},
+			updates: []models.StatsUpdate{
+				chatUpdate("m1", "2026-01-01"),
+				chatUpdate("m1", "2026-01-02"),
+				chatUpdate("m2", "2026-01-01"),
+			},
+			ids: []string{"e1", "e2", "e3"},
+		},
+		{
+			name: "skipped events are left out",
+			events: []bufferedEvent{
+				{id: "e1", update: chatUpdate("m1", "2026-01-01")},
+				{id: "e2", update: chatUpdate("m1", "2026-01-01")},
+				{id: "e3", update: chatUpdate("m2", "2026-01-01")},
+			},
+			skip: map[string]bool{"e2": true, "e3": true},
+			updates: []models.StatsUpdate{
+				chatUpdate("m1", "2026-01-01"),
+			},
+			ids: []string{"e1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, ids := coalesce(tt.events, tt.skip)
+			assert.Equal(t, tt.updates, updates)
+			assert.Equal(t, tt.ids, ids)
+		})
+	}
+}
+
+func TestBatcherFlushAcksAfterWrite(t *testing.T) {
+	statsRepo := newFakeStatsRepo()
+	b := newTestBatcher(statsRepo, &fakeActivityRepo{})
+
+	r1 := b.add("e1", chatUpdate("m1", "2026-01-01"), nil)
+	r2 := b.add("e2", chatUpdate("m1", "2026-01-01"), nil)
+
+	select {
+	case <-r1:
+		t.Fatal("acknowledged before the flush")
+	default:
+	}
+
+	b.flush()
+	for _, r := range []<-chan error{r1, r2} {
+		require.NoError(t, <-r)
+	}
+
+	want := []models.StatsUpdate{{MangaID: "m1", Day: "2026-01-01", ChatCount: 2, WeeklyScore: 2}}
+	assert.Equal(t, want, statsRepo.written())
+}
+
+func TestBatcherCloseFlushesAndRefusesLaterEvents(t *testing.T) {
+	statsRepo := newFakeStatsRepo()
+	b := newTestBatcher(statsRepo, &fakeActivityRepo{})
+	go b.run()
+
+	buffered := b.add("e1", chatUpdate("m1", "2026-01-01"), nil)
+	b.close()
+	require.NoError(t, <-buffered)
+	assert.Equal(t, 1, statsRepo.chats())
+
+	assert.ErrorIs(t, <-b.add("e2", chatUpdate("m1", "2026-01-01"), nil), errBatcherClosed)
+	assert.Equal(t, 1, statsRepo.chats())
+}
+
+func TestBatcherReplay(t *testing.T) {
+	writeErr := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		processed  []string // Already in stats_processed_events
+		fail       error    // First write fails
+		replay     bool     // Replay e1 while the first flush is writing
+		wantErr    bool
+		wantChats  int // Counted by the first flush
+		finalChats int // Counted after a retry and a late replay
+		activities int
+	}{
+		{name: "new event", wantChats: 1, finalChats: 1, activities: 1},
+		{name: "processed before a restart", processed: []string{"e1"}, wantChats: 0, finalChats: 0, activities: 0},
+		{name: "replay during a successful flush", replay: true, wantChats: 1, finalChats: 1, activities: 1},
+		{name: "replay during a failed flush", replay: true, fail: writeErr, wantErr: true, wantChats: 0, finalChats: 1, activities: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statsRepo := newFakeStatsRepo()
+			for _, id := range tt.processed {
+				statsRepo.processed[id] = true
+			}
+			statsRepo.fail = tt.fail
+			activityRepo := &fakeActivityRepo{}
+			b := newTestBatcher(statsRepo, activityRepo)
+
+			original := b.add("e1", chatUpdate("m1", "2026-01-01"), &models.Activity{ID: "a1"})
+
+			var replay <-chan error
+			if tt.replay {
+				statsRepo.block = make(chan struct{})
+				flushed := make(chan struct{})
+				go func() {
+					b.flush()
+					close(flushed)
+				}()
+
+				// Wait for the flush to take the buffer, then replay
+				for {
+					b.mu.Lock()
+					taken := len(b.buf.events) == 0
+					b.mu.Unlock()
+					if taken {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+				replay = b.add("e1", chatUpdate("m1", "2026-01-01"), &models.Activity{ID: "a2"})
+
+				select {
+				case err := <-replay:
+					t.Fatalf("replay acknowledged (%v) before the original was written", err)
+				default:
+				}
+				close(statsRepo.block)
+				<-flushed
+			} else {
+				b.flush()
+			}
+
+			results := []<-chan error{original}
+			if replay != nil {
+				results = append(results, replay)
+			}
+			for _, r := range results {
+				if tt.wantErr {
+					assert.Error(t, <-r)
+				} else {
+					assert.NoError(t, <-r)
+				}
+			}
+
+			assert.Equal(t, tt.wantChats, statsRepo.chats(), "chat count")
+			assert.Len(t, activityRepo.created, tt.activities)
+
+			// A retry after a failure is counted; any later replay is not
+			if tt.fail != nil {
+				retry := b.add("e1", chatUpdate("m1", "2026-01-01"), nil)
+				b.flush()
+				require.NoError(t, <-retry)
+			}
+			again := b.add("e1", chatUpdate("m1", "2026-01-01"), nil)
+			b.flush()
+			require.NoError(t, <-again)
+			assert.Equal(t, tt.finalChats, statsRepo.chats(), "chat count after replays")
+		})
+	}
+}
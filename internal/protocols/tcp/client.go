@@ -0,0 +1,534 @@
+package tcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mangahub/pkg/models"
+)
+
+// ClientConfig tunes a StatsClient
+type ClientConfig struct {
+	PoolSize   int           // Persistent connections to the aggregator
+	QueueSize  int           // Events held for retry while the aggregator is unreachable
+	Timeout    time.Duration // Dial timeout and max wait for an acknowledgement
+	MaxBackoff time.Duration // Upper bound for the reconnect delay
+	ClientID   string        // Identity sent in the hello frame
+	Secret     string        // Shared secret for signing the welcome nonce, if the server requires one
+}
+
+// ErrQueueFull is returned by Emit when the retry queue has no room
+var ErrQueueFull = errors.New("stats retry queue full")
+
+// errBackingOff is returned while a connection waits before redialling
+var errBackingOff = errors.New("stats connection backing off")
+
+// StatsClient sends events to the TCP stats aggregator over a pool of
+// persistent connections. Each event carries a request ID, so many events can
+// be in flight on one connection. Events that cannot be delivered are kept in
+// a bounded queue and retried in the background.
+type StatsClient struct {
+	cfg   ClientConfig
+	conns []*clientConn
+	next  uint64
+	ids   uint64
+
+	queue chan StatsEvent
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+// NewStatsClient creates a client for the aggregator at addr. Connections are
+// dialled lazily on first use.
+func NewStatsClient(addr string, cfg ClientConfig) *StatsClient {
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 4
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1000
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 30 * time.Second
+	}
+	if cfg.ClientID == "" {
+		cfg.ClientID = "mangahub"
+	}
+
+	c := &StatsClient{
+		cfg:   cfg,
+		conns: make([]*clientConn, cfg.PoolSize),
+		queue: make(chan StatsEvent, cfg.QueueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	for i := range c.conns {
+		c.conns[i] = &clientConn{addr: addr, cfg: cfg}
+	}
+
+	go c.retryLoop()
+	return c
+}
+
+// Send delivers an event and waits for the aggregator's acknowledgement
+func (c *StatsClient) Send(ctx context.Context, event StatsEvent) error {
+	if event.RequestID == "" {
+		event.RequestID = fmt.Sprintf("req-%d", atomic.AddUint64(&c.ids, 1))
+	}
+	if event.EventID == "" {
+		event.EventID = uuid.New().String()
+	}
+	if event.EventTime.IsZero() {
+		// Stamp now so a retried event still counts on the day it happened
+		event.EventTime = time.Now()
+	}
+
+	_, err := c.call(ctx, FrameEvent, event.RequestID, event)
+	return err
+}
+
+// DeliverOutbox relays a stats event from the outbox. The outbox ID doubles
+// as the event ID, so a redelivered event is deduplicated by the aggregator.
+func (c *StatsClient) DeliverOutbox(ctx context.Context, event *models.OutboxEvent) error {
+	var payload models.OutboxStatsPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid stats payload: %w", err)
+	}
+
+	return c.Send(ctx, StatsEvent{
+		Type:      EventType(payload.Type),
+		MangaID:   payload.MangaID,
+		UserID:    payload.UserID,
+		EventTime: payload.EventTime,
+		Source:    "outbox",
+		EventID:   event.ID,
+	})
+}
+
+// Stats queries the current counters for one manga
+func (c *StatsClient) Stats(ctx context.Context, mangaID string) (*models.MangaStats, error) {
+	var stats models.MangaStats
+	if err := c.query(ctx, Query{Kind: QueryStats, MangaID: mangaID}, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// Top queries the manga with the highest weekly scores
+func (c *StatsClient) Top(ctx context.Context, limit int) ([]*models.MangaStats, error) {
+	var top []*models.MangaStats
+	if err := c.query(ctx, Query{Kind: QueryTop, Limit: limit}, &top); err != nil {
+		return nil, err
+	}
+	return top, nil
+}
+
+// query sends a query frame and decodes the result into out
+func (c *StatsClient) query(ctx context.Context, query Query, out interface{}) error {
+	query.RequestID = fmt.Sprintf("req-%d", atomic.AddUint64(&c.ids, 1))
+
+	resp, err := c.call(ctx, FrameQuery, query.RequestID, query)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("parse query result: %w", err)
+	}
+	return nil
+}
+
+// call sends a frame on the pool and waits for the response with the same
+// request ID, moving to the next connection if one fails
+func (c *StatsClient) call(ctx context.Context, frameType FrameType, requestID string, body interface{}) (Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	// Try each pooled connection once, starting from the next in turn
+	start := atomic.AddUint64(&c.next, 1)
+	var err error
+	for i := 0; i < len(c.conns); i++ {
+		conn := c.conns[(start+uint64(i))%uint64(len(c.conns))]
+		var resp Response
+		if resp, err = conn.call(ctx, frameType, requestID, body); err == nil {
+			return resp, nil
+		}
+		var rejected *RejectedError
+		if errors.As(err, &rejected) || ctx.Err() != nil {
+			break
+		}
+	}
+	return Response{}, err
+}
+
+// Subscribe streams score updates for the given manga, or every manga when
+// none are given, over a dedicated connection. The channel is closed when
+// ctx ends or the connection drops; callers resubscribe to resume.
+func (c *StatsClient) Subscribe(ctx context.Context, mangaIDs ...string) (<-chan ScoreUpdate, error) {
+	cc := &clientConn{addr: c.conns[0].addr, cfg: c.cfg}
+	conn, reader, err := cc.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	query := Query{RequestID: "subscribe", Kind: QuerySubscribe, MangaIDs: mangaIDs}
+	conn.SetDeadline(time.Now().Add(c.cfg.Timeout))
+	if err := writeTypedFrame(conn, FrameQuery, query); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("write subscribe: %w", err)
+	}
+	data, err := readFrame(reader, defaultMaxFrame)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("read subscribe response: %w", err)
+	}
+	var resp Response
+	if FrameType(data[0]) != FrameResponse || json.Unmarshal(data[1:], &resp) != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected subscribe response")
+	}
+	if resp.Status != "success" {
+		conn.Close()
+		return nil, &RejectedError{Message: resp.Message}
+	}
+	conn.SetDeadline(time.Time{})
+
+	updates := make(chan ScoreUpdate, 64)
+	go func() {
+		// Ping so the server's idle timeout does not drop a quiet stream
+		ticker := time.NewTicker(idleTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-ticker.C:
+				conn.SetWriteDeadline(time.Now().Add(c.cfg.Timeout))
+				if err := writeTypedFrame(conn, FramePing, nil); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}
+	}()
+	go func() {
+		defer close(updates)
+		defer conn.Close()
+		for {
+			data, err := readFrame(reader, defaultMaxFrame)
+			if err != nil {
+				return
+			}
+			if FrameType(data[0]) != FrameScoreUpdate {
+				continue
+			}
+			var update ScoreUpdate
+			if err := json.Unmarshal(data[1:], &update); err != nil {
+				continue
+			}
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+// Emit sends an event in the background. Failed deliveries are queued and
+// retried under the same event ID, so a retry of an event that was written
+// but not acknowledged is skipped. Emit only fails when the queue is full.
+func (c *StatsClient) Emit(event StatsEvent) error {
+	if event.EventID == "" {
+		event.EventID = uuid.New().String()
+	}
+	if event.EventTime.IsZero() {
+		event.EventTime = time.Now()
+	}
+
+	select {
+	case c.queue <- event:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// retryLoop delivers queued events in order, waiting between failed attempts
+func (c *StatsClient) retryLoop() {
+	defer close(c.done)
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case event := <-c.queue:
+			delay := 100 * time.Millisecond
+			for {
+				err := c.Send(context.Background(), event)
+				if err == nil {
+					break
+				}
+				// The aggregator rejected the event itself; retrying will not help
+				var rejected *RejectedError
+				if errors.As(err, &rejected) {
+					fmt.Printf("❌ TCP stats event dropped: %v\n", err)
+					break
+				}
+
+				select {
+				case <-c.stop:
+					return
+				case <-time.After(delay):
+				}
+				if delay *= 2; delay > c.cfg.MaxBackoff {
+					delay = c.cfg.MaxBackoff
+				}
+			}
+		}
+	}
+}
+
+// Close stops retrying and closes every connection. Queued events are dropped.
+func (c *StatsClient) Close() {
+	c.once.Do(func() {
+		close(c.stop)
+		<-c.done
+		for _, conn := range c.conns {
+			conn.close()
+		}
+		if n := len(c.queue); n > 0 {
+			fmt.Printf("⚠️ TCP stats client closed with %d undelivered events\n", n)
+		}
+	})
+}
+
+// RejectedError is an error reply from the aggregator for one event
+type RejectedError struct {
+	Message string
+}
+
+func (e *RejectedError) Error() string {
+	return "server error: " + e.Message
+}
+
+// clientConn is one persistent connection with its in-flight requests
+type clientConn struct {
+	addr string
+	cfg  ClientConfig
+
+	mu       sync.Mutex
+	conn     net.Conn
+	pending  map[string]chan reply
+	backoff  time.Duration
+	nextDial time.Time
+}
+
+// reply is the outcome of one request on a connection
+type reply struct {
+	resp Response
+	err  error
+}
+
+// call writes a frame and waits for the response with the same request ID
+func (cc *clientConn) call(ctx context.Context, frameType FrameType, requestID string, body interface{}) (Response, error) {
+	result := make(chan reply, 1)
+
+	cc.mu.Lock()
+	if err := cc.connectLocked(); err != nil {
+		cc.mu.Unlock()
+		return Response{}, err
+	}
+	conn := cc.conn
+	cc.pending[requestID] = result
+
+	conn.SetWriteDeadline(time.Now().Add(cc.cfg.Timeout))
+	err := writeTypedFrame(conn, frameType, body)
+	cc.mu.Unlock()
+
+	if err != nil {
+		cc.fail(conn, fmt.Errorf("write frame: %w", err))
+		return Response{}, fmt.Errorf("write frame: %w", err)
+	}
+
+	select {
+	case r := <-result:
+		return r.resp, r.err
+	case <-ctx.Done():
+		cc.mu.Lock()
+		delete(cc.pending, requestID)
+		cc.mu.Unlock()
+		return Response{}, fmt.Errorf("waiting for response: %w", ctx.Err())
+	}
+}
+
+// connectLocked dials and greets the server if there is no live connection,
+// honouring the backoff after failed attempts. Callers hold cc.mu.
+func (cc *clientConn) connectLocked() error {
+	if cc.conn != nil {
+		return nil
+	}
+	if time.Now().Before(cc.nextDial) {
+		return errBackingOff
+	}
+
+	conn, reader, err := cc.dial()
+	if err != nil {
+		if cc.backoff == 0 {
+			cc.backoff = 100 * time.Millisecond
+		} else if cc.backoff *= 2; cc.backoff > cc.cfg.MaxBackoff {
+			cc.backoff = cc.cfg.MaxBackoff
+		}
+		cc.nextDial = time.Now().Add(cc.backoff)
+		return err
+	}
+
+	cc.conn = conn
+	cc.pending = make(map[string]chan reply)
+	cc.backoff = 0
+	go cc.readLoop(conn, reader)
+	return nil
+}
+
+// dial connects and completes the hello handshake
+func (cc *clientConn) dial() (net.Conn, *bufio.Reader, error) {
+	conn, err := net.DialTimeout("tcp", cc.addr, cc.cfg.Timeout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("dial tcp: %w", err)
+	}
+
+	conn.SetDeadline(time.Now().Add(cc.cfg.Timeout))
+	reader := bufio.NewReader(conn)
+	if err := cc.handshake(conn, reader); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	return conn, reader, nil
+}
+
+// handshake sends a hello, waits for the welcome and, if the welcome carries
+// a nonce, signs it and waits for the server to accept the signature
+func (cc *clientConn) handshake(conn net.Conn, reader *bufio.Reader) error {
+	if err := writeTypedFrame(conn, FrameHello, newHello(cc.cfg.ClientID)); err != nil {
+		return fmt.Errorf("write hello: %w", err)
+	}
+
+	data, err := readFrame(reader, defaultMaxFrame)
+	if err != nil {
+		return fmt.Errorf("read welcome: %w", err)
+	}
+
+	switch FrameType(data[0]) {
+	case FrameWelcome:
+	case FrameResponse:
+		var resp Response
+		json.Unmarshal(data[1:], &resp)
+		return fmt.Errorf("handshake rejected: %s", resp.Message)
+	default:
+		// Bare JSON means a server from before protocol versioning
+		return fmt.Errorf("handshake failed: unexpected frame 0x%02x", data[0])
+	}
+
+	var welcome Welcome
+	if err := json.Unmarshal(data[1:], &welcome); err != nil {
+		return fmt.Errorf("parse welcome: %w", err)
+	}
+	if welcome.Nonce == "" {
+		return nil
+	}
+	if cc.cfg.Secret == "" {
+		return fmt.Errorf("handshake failed: server requires a shared secret")
+	}
+
+	auth := Auth{Nonce: welcome.Nonce, Signature: signNonce(cc.cfg.Secret, cc.cfg.ClientID, welcome.Nonce)}
+	if err := writeTypedFrame(conn, FrameAuth, auth); err != nil {
+		return fmt.Errorf("write auth: %w", err)
+	}
+
+	data, err = readFrame(reader, defaultMaxFrame)
+	if err != nil {
+		return fmt.Errorf("read auth response: %w", err)
+	}
+	var resp Response
+	if FrameType(data[0]) != FrameResponse || json.Unmarshal(data[1:], &resp) != nil {
+		return fmt.Errorf("handshake failed: unexpected frame 0x%02x", data[0])
+	}
+	if resp.Status != "success" {
+		return fmt.Errorf("authentication rejected: %s", resp.Message)
+	}
+	return nil
+}
+
+// readLoop routes replies to their waiting senders until the connection fails
+func (cc *clientConn) readLoop(conn net.Conn, reader *bufio.Reader) {
+	for {
+		data, err := readFrame(reader, defaultMaxFrame)
+		if err != nil {
+			cc.fail(conn, fmt.Errorf("read response: %w", err))
+			return
+		}
+		if FrameType(data[0]) != FrameResponse {
+			continue
+		}
+
+		var resp Response
+		if err := json.Unmarshal(data[1:], &resp); err != nil {
+			cc.fail(conn, fmt.Errorf("parse response: %w", err))
+			return
+		}
+
+		cc.mu.Lock()
+		if result, ok := cc.pending[resp.RequestID]; ok {
+			delete(cc.pending, resp.RequestID)
+			if resp.Status != "success" {
+				result <- reply{err: &RejectedError{Message: resp.Message}}
+			} else {
+				result <- reply{resp: resp}
+			}
+		}
+		cc.mu.Unlock()
+	}
+}
+
+// fail drops a broken connection and fails its in-flight requests so their
+// senders can retry on another connection
+func (cc *clientConn) fail(conn net.Conn, err error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.conn != conn {
+		return // Already replaced
+	}
+	conn.Close()
+	cc.conn = nil
+	for _, result := range cc.pending {
+		result <- reply{err: err}
+	}
+	cc.pending = nil
+}
+
+// close closes the connection if open
+func (cc *clientConn) close() {
+	cc.mu.Lock()
+	conn := cc.conn
+	cc.mu.Unlock()
+	if conn != nil {
+		cc.fail(conn, errors.New("client closed"))
+	}
+}
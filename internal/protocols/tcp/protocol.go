@@ -0,0 +1,211 @@
+package tcp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Wire format
+//
+// Every frame is a 4-byte big-endian length followed by the payload. A
+// payload starting with '{' is a legacy frame: a bare JSON StatsEvent, as
+// sent before protocol versioning. Any other payload is a typed frame: one
+// FrameType byte followed by a JSON body.
+//
+// A typed session opens with FrameHello. The server answers FrameWelcome or
+// an error response and closes. When the server has a shared secret, the
+// welcome carries a single-use nonce: the client signs it in a FrameAuth and
+// the server answers a success or error response. After that the client may
+// send event, batch, ping and query frames; the server replies with response
+// and pong frames, and pushes score update frames to connections that
+// subscribed.
+
+// ProtocolVersion is the newest protocol version this package speaks
+const ProtocolVersion = 1
+
+// minProtocolVersion is the oldest typed protocol version the server accepts
+const minProtocolVersion = 1
+
+// FrameType is the first payload byte of a typed frame
+type FrameType byte
+
+const (
+	FrameHello       FrameType = 0x01 // Client -> server: Hello
+	FrameWelcome     FrameType = 0x02 // Server -> client: Welcome
+	FrameEvent       FrameType = 0x03 // Client -> server: StatsEvent
+	FrameBatch       FrameType = 0x04 // Client -> server: EventBatch
+	FramePing        FrameType = 0x05 // Client -> server: empty body
+	FramePong        FrameType = 0x06 // Server -> client: empty body
+	FrameQuery       FrameType = 0x07 // Client -> server: query body
+	FrameResponse    FrameType = 0x08 // Server -> client: Response
+	FrameScoreUpdate FrameType = 0x09 // Server -> client: ScoreUpdate, after a subscribe query
+	FrameAuth        FrameType = 0x0A // Client -> server: Auth, after a welcome with a nonce
+)
+
+// legacyFrameStart is the first byte of a legacy JSON frame
+const legacyFrameStart = '{'
+
+// Frame size limits. Legacy frames keep the original 1KB cap; typed frames
+// may carry batches.
+const (
+	maxLegacyFrameSize = 1024
+	defaultMaxFrame    = 64 * 1024
+)
+
+// nonceTTL bounds how long a client has to sign a welcome nonce
+const nonceTTL = time.Minute
+
+// Hello opens a typed session
+type Hello struct {
+	Version  int    `json:"version"`   // Protocol version the client speaks
+	ClientID string `json:"client_id"` // Service name, for logs
+}
+
+// Welcome accepts a hello
+type Welcome struct {
+	Version  int    `json:"version"` // Protocol version for the session
+	ServerID string `json:"server_id"`
+	Nonce    string `json:"nonce,omitempty"` // Set when the client must authenticate
+}
+
+// Auth answers a welcome nonce
+type Auth struct {
+	Nonce     string `json:"nonce"`
+	Signature string `json:"signature"` // Hex HMAC-SHA256 of the client ID and nonce
+}
+
+// EventBatch carries several events acknowledged by one response
+type EventBatch struct {
+	RequestID string       `json:"request_id,omitempty"`
+	Ack       string       `json:"ack,omitempty"` // AckAsync, as for single events
+	Events    []StatsEvent `json:"events"`
+}
+
+// signNonce computes a client's signature of a welcome nonce
+func signNonce(secret, clientID, nonce string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(clientID + "\n" + nonce))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newHello builds a hello for the current protocol version
+func newHello(clientID string) Hello {
+	return Hello{Version: ProtocolVersion, ClientID: clientID}
+}
+
+// verifyHello checks a hello's version and client ID
+func verifyHello(hello Hello) error {
+	if hello.Version < minProtocolVersion || hello.Version > ProtocolVersion {
+		return fmt.Errorf("unsupported protocol version %d (server speaks %d-%d)",
+			hello.Version, minProtocolVersion, ProtocolVersion)
+	}
+	if hello.ClientID == "" {
+		return fmt.Errorf("client_id is required")
+	}
+	return nil
+}
+
+// nonceStore issues single-use welcome nonces
+type nonceStore struct {
+	mu     sync.Mutex
+	issued map[string]time.Time // Nonce -> expiry
+}
+
+func newNonceStore() *nonceStore {
+	return &nonceStore{issued: make(map[string]time.Time)}
+}
+
+// issue returns a fresh random nonce, valid for nonceTTL
+func (n *nonceStore) issue() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	nonce := hex.EncodeToString(buf)
+
+	now := time.Now()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for issued, expiry := range n.issued {
+		if now.After(expiry) {
+			delete(n.issued, issued)
+		}
+	}
+	n.issued[nonce] = now.Add(nonceTTL)
+	return nonce, nil
+}
+
+// consume reports whether nonce was issued and is unexpired, and retires it
+func (n *nonceStore) consume(nonce string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	expiry, ok := n.issued[nonce]
+	delete(n.issued, nonce)
+	return ok && time.Now().Before(expiry)
+}
+
+// verifyAuth checks that auth signs the nonce welcomed on this connection,
+// and that the nonce has not been used before
+func verifyAuth(auth Auth, clientID, nonce, secret string, nonces *nonceStore) error {
+	if auth.Nonce != nonce {
+		return fmt.Errorf("authentication failed: nonce does not match the welcome")
+	}
+	if !nonces.consume(nonce) {
+		return fmt.Errorf("authentication failed: nonce expired or already used")
+	}
+	expected := signNonce(secret, clientID, nonce)
+	if !hmac.Equal([]byte(expected), []byte(auth.Signature)) {
+		return fmt.Errorf("authentication failed: bad signature")
+	}
+	return nil
+}
+
+// readFrame reads one length-prefixed payload of at most maxSize bytes
+func readFrame(r io.Reader, maxSize int) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	if length == 0 {
+		return nil, fmt.Errorf("invalid frame length 0")
+	}
+	if int(length) > maxSize {
+		return nil, fmt.Errorf("frame too large: %d bytes (max %d)", length, maxSize)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("read frame data: %w", err)
+	}
+	return data, nil
+}
+
+// writeFrame writes a length-prefixed frame
+func writeFrame(w io.Writer, data []byte) error {
+	buf := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	copy(buf[4:], data)
+	_, err := w.Write(buf)
+	return err
+}
+
+// writeTypedFrame writes a typed frame with a JSON body; a nil body is empty
+func writeTypedFrame(w io.Writer, frameType FrameType, body interface{}) error {
+	payload := []byte{byte(frameType)}
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal frame: %w", err)
+		}
+		payload = append(payload, data...)
+	}
+	return writeFrame(w, payload)
+}
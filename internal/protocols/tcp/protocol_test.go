@@ -0,0 +1,217 @@
+package tcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rawFrame builds a frame with an arbitrary length prefix
+func rawFrame(length uint32, payload []byte) []byte {
+	buf := make([]byte, 4, 4+len(payload))
+	binary.BigEndian.PutUint32(buf, length)
+	return append(buf, payload...)
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+	}{
+		{name: "one byte", payload: []byte{byte(FramePing)}},
+		{name: "legacy json", payload: []byte(`{"type":"comment","manga_id":"m1"}`)},
+		{name: "max size", payload: bytes.Repeat([]byte("x"), defaultMaxFrame)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeFrame(&buf, tt.payload))
+			assert.Equal(t, 4+len(tt.payload), buf.Len())
+
+			got, err := readFrame(&buf, defaultMaxFrame)
+			require.NoError(t, err)
+			assert.Equal(t, tt.payload, got)
+			assert.Zero(t, buf.Len(), "frame not fully consumed")
+		})
+	}
+}
+
+func TestReadFrameErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		maxSize int
+		wantErr string
+		wantEOF bool
+	}{
+		{name: "no data", data: nil, maxSize: defaultMaxFrame, wantEOF: true},
+		{name: "short length prefix", data: []byte{0, 0}, maxSize: defaultMaxFrame, wantErr: "unexpected EOF"},
+		{name: "zero length", data: rawFrame(0, nil), maxSize: defaultMaxFrame, wantErr: "invalid frame length 0"},
+		{name: "over the limit", data: rawFrame(maxLegacyFrameSize+1, nil), maxSize: maxLegacyFrameSize, wantErr: "frame too large: 1025 bytes (max 1024)"},
+		{name: "truncated payload", data: rawFrame(10, []byte("abc")), maxSize: defaultMaxFrame, wantErr: "read frame data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readFrame(bytes.NewReader(tt.data), tt.maxSize)
+			require.Error(t, err)
+			if tt.wantEOF {
+				assert.ErrorIs(t, err, io.EOF)
+				return
+			}
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestWriteTypedFrame(t *testing.T) {
+	tests := []struct {
+		name      string
+		frameType FrameType
+		body      interface{}
+		want      []byte
+	}{
+		{name: "empty body", frameType: FramePong, want: []byte{byte(FramePong)}},
+		{
+			name:      "json body",
+			frameType: FrameWelcome,
+			body:      Welcome{Version: 1, ServerID: "s1"},
+			want:      append([]byte{byte(FrameWelcome)}, `{"version":1,"server_id":"s1"}`...),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeTypedFrame(&buf, tt.frameType, tt.body))
+
+			got, err := readFrame(&buf, defaultMaxFrame)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NotEqual(t, byte(legacyFrameStart), got[0], "typed frame mistaken for legacy")
+		})
+	}
+}
+
+func TestVerifyHello(t *testing.T) {
+	tests := []struct {
+		name    string
+		hello   Hello
+		wantErr string
+	}{
+		{name: "current version", hello: newHello("c1")},
+		{name: "version too old", hello: Hello{Version: minProtocolVersion - 1, ClientID: "c1"}, wantErr: "unsupported protocol version"},
+		{name: "version too new", hello: Hello{Version: ProtocolVersion + 1, ClientID: "c1"}, wantErr: "unsupported protocol version"},
+		{name: "missing client id", hello: Hello{Version: ProtocolVersion}, wantErr: "client_id is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyHello(tt.hello)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestVerifyAuth(t *testing.T) {
+	const secret = "s3cret"
+
+	tests := []struct {
+		name     string
+		auth     func(nonce string) Auth
+		clientID string
+		used     bool // Nonce already consumed by an earlier auth
+		expired  bool
+		wantErr  string
+	}{
+		{
+			name:     "signed nonce",
+			auth:     func(nonce string) Auth { return Auth{Nonce: nonce, Signature: signNonce(secret, "c1", nonce)} },
+			clientID: "c1",
+		},
+		{
+			name:     "wrong secret",
+			auth:     func(nonce string) Auth { return Auth{Nonce: nonce, Signature: signNonce("other", "c1", nonce)} },
+			clientID: "c1",
+			wantErr:  "bad signature",
+		},
+		{
+			name:     "other client's signature",
+			auth:     func(nonce string) Auth { return Auth{Nonce: nonce, Signature: signNonce(secret, "c1", nonce)} },
+			clientID: "c2",
+			wantErr:  "bad signature",
+		},
+		{
+			name:     "unsigned",
+			auth:     func(nonce string) Auth { return Auth{Nonce: nonce} },
+			clientID: "c1",
+			wantErr:  "bad signature",
+		},
+		{
+			name: "nonce from another connection",
+			auth: func(nonce string) Auth {
+				return Auth{Nonce: "0123", Signature: signNonce(secret, "c1", "0123")}
+			},
+			clientID: "c1",
+			wantErr:  "nonce does not match",
+		},
+		{
+			name:     "replayed nonce",
+			auth:     func(nonce string) Auth { return Auth{Nonce: nonce, Signature: signNonce(secret, "c1", nonce)} },
+			clientID: "c1",
+			used:     true,
+			wantErr:  "nonce expired or already used",
+		},
+		{
+			name:     "expired nonce",
+			auth:     func(nonce string) Auth { return Auth{Nonce: nonce, Signature: signNonce(secret, "c1", nonce)} },
+			clientID: "c1",
+			expired:  true,
+			wantErr:  "nonce expired or already used",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nonces := newNonceStore()
+			nonce, err := nonces.issue()
+			require.NoError(t, err)
+			if tt.used {
+				require.NoError(t, verifyAuth(tt.auth(nonce), tt.clientID, nonce, secret, nonces))
+			}
+			if tt.expired {
+				nonces.issued[nonce] = time.Now().Add(-time.Second)
+			}
+
+			err = verifyAuth(tt.auth(nonce), tt.clientID, nonce, secret, nonces)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNonceStoreIssuesDistinctNonces(t *testing.T) {
+	nonces := newNonceStore()
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		nonce, err := nonces.issue()
+		require.NoError(t, err)
+		require.False(t, seen[nonce], "nonce %s issued twice", nonce)
+		seen[nonce] = true
+	}
+}
@@ -0,0 +1,245 @@
+package tcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"mangahub/pkg/models"
+)
+
+// Query kinds carried in FrameQuery
+const (
+	QueryStats       = "stats"       // Current counters for one manga
+	QueryTop         = "top"         // Top N manga by weekly score
+	QuerySubscribe   = "subscribe"   // Stream ScoreUpdate frames as batches flush
+	QueryUnsubscribe = "unsubscribe" // Stop the stream
+)
+
+// Query is the body of a FrameQuery
+type Query struct {
+	RequestID string   `json:"request_id,omitempty"`
+	Kind      string   `json:"kind"`
+	MangaID   string   `json:"manga_id,omitempty"`  // For stats
+	Limit     int      `json:"limit,omitempty"`     // For top; default 10, max 100
+	MangaIDs  []string `json:"manga_ids,omitempty"` // For subscribe; empty means every manga
+}
+
+// ScoreUpdate is pushed to subscribers in a FrameScoreUpdate after a flush
+// changes a manga's counters
+type ScoreUpdate struct {
+	MangaID      string    `json:"manga_id"`
+	CommentCount int       `json:"comment_count"`
+	LikeCount    int       `json:"like_count"`
+	ChatCount    int       `json:"chat_count"`
+	WeeklyScore  int       `json:"weekly_score"`
+	ScoreDelta   int       `json:"score_delta"` // Points added by this flush
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// queryTimeout bounds the database work behind one query
+const queryTimeout = 5 * time.Second
+
+// subscription is one connection's live score stream
+type subscription struct {
+	mangaIDs map[string]bool // nil means every manga
+}
+
+// wants reports whether the subscription covers a manga
+func (sub *subscription) wants(mangaID string) bool {
+	return sub.mangaIDs == nil || sub.mangaIDs[mangaID]
+}
+
+// subscribers tracks live score streams and fans flushed updates out to them
+type subscribers struct {
+	mu   sync.Mutex
+	subs map[*responder]*subscription
+
+	flushed chan []models.StatsUpdate
+}
+
+// newSubscribers creates an empty registry
+func newSubscribers() *subscribers {
+	return &subscribers{
+		subs:    make(map[*responder]*subscription),
+		flushed: make(chan []models.StatsUpdate, 64),
+	}
+}
+
+// add registers or replaces a connection's subscription
+func (r *subscribers) add(out *responder, mangaIDs []string) {
+	sub := &subscription{}
+	if len(mangaIDs) > 0 {
+		sub.mangaIDs = make(map[string]bool, len(mangaIDs))
+		for _, id := range mangaIDs {
+			sub.mangaIDs[id] = true
+		}
+	}
+
+	r.mu.Lock()
+	r.subs[out] = sub
+	r.mu.Unlock()
+}
+
+// remove drops a connection's subscription, if any
+func (r *subscribers) remove(out *responder) {
+	r.mu.Lock()
+	delete(r.subs, out)
+	r.mu.Unlock()
+}
+
+// notify hands a written batch to the publisher. It never blocks the flush:
+// with no subscribers the batch is ignored, and when the publisher is behind
+// the batch is dropped.
+func (r *subscribers) notify(updates []models.StatsUpdate) {
+	r.mu.Lock()
+	empty := len(r.subs) == 0
+	r.mu.Unlock()
+	if empty {
+		return
+	}
+
+	select {
+	case r.flushed <- updates:
+	default:
+		fmt.Println("⚠️ TCP subscribers lagging; dropped a score update batch")
+	}
+}
+
+// handleQuery answers a query frame. Queries with a request ID run in the
+// background, like acknowledgements, so they do not stall the connection.
+func (s *Server) handleQuery(out *responder, body []byte, clientAddr string) {
+	var query Query
+	if err := json.Unmarshal(body, &query); err != nil {
+		fmt.Printf("❌ TCP parse error from %s: %v\n", clientAddr, err)
+		out.sendError("", fmt.Sprintf("Invalid query format: %v", err))
+		return
+	}
+
+	if query.RequestID != "" {
+		go s.answerQuery(out, &query, clientAddr)
+		return
+	}
+	s.answerQuery(out, &query, clientAddr)
+}
+
+// answerQuery runs a query and sends its result
+func (s *Server) answerQuery(out *responder, query *Query, clientAddr string) {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	var result interface{}
+	switch query.Kind {
+	case QueryStats:
+		if query.MangaID == "" {
+			out.sendError(query.RequestID, "manga_id is required")
+			return
+		}
+		stats, err := s.statsRepo.GetByMangaID(ctx, query.MangaID)
+		if err != nil {
+			out.sendError(query.RequestID, fmt.Sprintf("Query failed: %v", err))
+			return
+		}
+		result = stats
+
+	case QueryTop:
+		limit := query.Limit
+		if limit <= 0 {
+			limit = 10
+		}
+		if limit > 100 {
+			limit = 100
+		}
+		top, _, err := s.statsRepo.GetTopByWeeklyScore(ctx, limit, 0)
+		if err != nil {
+			out.sendError(query.RequestID, fmt.Sprintf("Query failed: %v", err))
+			return
+		}
+		if top == nil {
+			top = []*models.MangaStats{}
+		}
+		result = top
+
+	case QuerySubscribe:
+		s.subs.add(out, query.MangaIDs)
+		fmt.Printf("📡 TCP client %s subscribed to score updates\n", clientAddr)
+		out.sendSuccess(query.RequestID, "Subscribed")
+		return
+
+	case QueryUnsubscribe:
+		s.subs.remove(out)
+		out.sendSuccess(query.RequestID, "Unsubscribed")
+		return
+
+	default:
+		out.sendError(query.RequestID, fmt.Sprintf("unknown query kind: %s (must be 'stats', 'top', 'subscribe' or 'unsubscribe')", query.Kind))
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		out.sendError(query.RequestID, fmt.Sprintf("Query failed: %v", err))
+		return
+	}
+	out.send(Response{Status: "success", Message: "OK", RequestID: query.RequestID, Data: data})
+}
+
+// publishLoop pushes score updates to subscribers until the server stops
+func (s *Server) publishLoop() {
+	for {
+		select {
+		case <-s.stop:
+			return
+		case updates := <-s.subs.flushed:
+			s.publish(updates)
+		}
+	}
+}
+
+// publish reads the new totals for every manga in a flushed batch that
+// someone subscribes to, and sends each subscriber its share
+func (s *Server) publish(updates []models.StatsUpdate) {
+	// A batch holds one update per manga and day; sum the days
+	deltas := make(map[string]int)
+	for _, update := range updates {
+		deltas[update.MangaID] += update.WeeklyScore
+	}
+
+	s.subs.mu.Lock()
+	subs := make(map[*responder]*subscription, len(s.subs.subs))
+	for out, sub := range s.subs.subs {
+		subs[out] = sub
+	}
+	s.subs.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	for mangaID, delta := range deltas {
+		var scoreUpdate *ScoreUpdate
+		for out, sub := range subs {
+			if !sub.wants(mangaID) {
+				continue
+			}
+			if scoreUpdate == nil {
+				stats, err := s.statsRepo.GetByMangaID(ctx, mangaID)
+				if err != nil {
+					fmt.Printf("❌ TCP failed to read stats for %s: %v\n", mangaID, err)
+					break
+				}
+				scoreUpdate = &ScoreUpdate{
+					MangaID:      stats.MangaID,
+					CommentCount: stats.CommentCount,
+					LikeCount:    stats.LikeCount,
+					ChatCount:    stats.ChatCount,
+					WeeklyScore:  stats.WeeklyScore,
+					ScoreDelta:   delta,
+					UpdatedAt:    stats.UpdatedAt,
+				}
+			}
+			out.sendFrame(FrameScoreUpdate, scoreUpdate)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package tcp
 
 import (
 	"bufio"
-	"context"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
@@ -11,6 +10,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+
+	"mangahub/internal/core"
 	"mangahub/internal/repository"
 	"mangahub/pkg/models"
 )
@@ -22,35 +24,79 @@ const (
 	EventTypeComment EventType = "comment"       // Matches schema: CHECK (type IN ('comment', 'chat', 'manga_update'))
 	EventTypeChat    EventType = "chat"          // Matches schema activity_feed.type
 	EventTypeUpdate  EventType = "manga_update"  // Matches schema activity_feed.type
+	EventTypeLike    EventType = "like"          // Comment like; counted but not an activity_feed type
 )
 
 // StatsEvent represents a stats aggregation event (schema-aligned)
 type StatsEvent struct {
-	Type      EventType `json:"type"`           // Must match schema activity_feed.type
+	Type      EventType `json:"type"`           // activity_feed.type, or "like"
 	MangaID   string    `json:"manga_id"`       // Matches manga_stats.manga_id FK
 	UserID    *string   `json:"user_id"`        // Nullable like activity_feed.user_id
 	EventTime time.Time `json:"event_time"`     // Timestamp for scoring
-	Weight    int       `json:"weight"`         // Scoring weight (comment=1, chat=2, update=5)
-	Source    string    `json:"source"`         // "http", "websocket", "admin"
+	Weight    int       `json:"weight"`         // Ignored on input; set from the scoring engine
+	Source    string    `json:"source"`         // "http", "grpc", "websocket", "admin", "outbox"
+	Ack       string    `json:"ack,omitempty"`  // "async" = acknowledge on receipt, not after the flush
+	RequestID string    `json:"request_id,omitempty"` // Echoed in the reply; lets clients pipeline events
+	EventID   string    `json:"event_id,omitempty"`   // Client-chosen UUID; replays within the dedupe window are skipped
+}
+
+// Response is the server's reply to one event frame
+type Response struct {
+	Status    string `json:"status"` // "success" or "error"
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"` // Copied from the event
+	Data      json.RawMessage `json:"data,omitempty"` // Query result
+}
+
+// idleTimeout closes connections that send nothing for this long. Pooled
+// clients keep connections open between events.
+const idleTimeout = 2 * time.Minute
+
+// AckAsync asks the server to acknowledge an event as soon as it is buffered.
+// By default the acknowledgement waits until the event's batch is written.
+const AckAsync = "async"
+
+// ProtocolConfig controls session setup on the stats server
+type ProtocolConfig struct {
+	SharedSecret string // When set, clients must sign a welcome nonce; legacy frames are refused
+	MaxFrameSize int    // Largest typed frame accepted, in bytes
 }
 
 // Server manages TCP stats aggregation server
 type Server struct {
 	addr      string
 	listener  net.Listener
+	protocol  ProtocolConfig
 	statsRepo repository.StatsRepository
 	activityRepo repository.ActivityRepository
+	scoring   *core.ScoringEngine
+	batch     *batcher
+	subs      *subscribers
+	nonces    *nonceStore
 	connMu    sync.Mutex
+	conns     map[net.Conn]struct{} // Open connections, so Stop can interrupt their reads
+	handlers  sync.WaitGroup        // Running connection handlers
 	stop      chan struct{}
 	stopped   chan struct{}
 }
 
 // NewServer creates a new TCP stats aggregator server
-func NewServer(host string, port int, statsRepo repository.StatsRepository, activityRepo repository.ActivityRepository) *Server {
+func NewServer(host string, port int, statsRepo repository.StatsRepository, activityRepo repository.ActivityRepository, scoring *core.ScoringEngine, batch BatchConfig, protocol ProtocolConfig) *Server {
+	if protocol.MaxFrameSize <= 0 {
+		protocol.MaxFrameSize = defaultMaxFrame
+	}
+
+	subs := newSubscribers()
 	return &Server{
 		addr:        fmt.Sprintf("%s:%d", host, port),
+		protocol:    protocol,
 		statsRepo:   statsRepo,
 		activityRepo: activityRepo,
+		scoring:     scoring,
+		batch:       newBatcher(statsRepo, activityRepo, batch, subs.notify),
+		subs:        subs,
+		nonces:      newNonceStore(),
+		conns:       make(map[net.Conn]struct{}),
 		stop:        make(chan struct{}),
 		stopped:     make(chan struct{}),
 	}
@@ -66,11 +112,15 @@ func (s *Server) Start() error {
 	s.listener = listener
 	fmt.Printf("✅ TCP Stats Aggregator started on %s\n", s.addr)
 
+	go s.batch.run()
+	go s.publishLoop()
 	go s.acceptLoop()
 	return nil
 }
 
-// Stop stops the TCP server gracefully
+// Stop stops the TCP server gracefully. Connection handlers stop reading and
+// finish buffering the frames they have before the batcher is closed, so no
+// accepted event is dropped; anything arriving later is refused.
 func (s *Server) Stop() {
 	fmt.Println("🛑 TCP Stats Aggregator stopping...")
 
@@ -83,10 +133,39 @@ func (s *Server) Stop() {
 	close(s.stop)
 
 	// Wait for accept loop to exit
+	clean := true
 	select {
 	case <-s.stopped:
-		fmt.Println("✅ TCP Stats Aggregator stopped cleanly")
 	case <-time.After(5 * time.Second):
+		clean = false
+	}
+
+	// Interrupt blocked reads and wait for the handlers to return
+	s.connMu.Lock()
+	for conn := range s.conns {
+		conn.SetReadDeadline(time.Now())
+	}
+	s.connMu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		s.handlers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(5 * time.Second):
+		clean = false
+	}
+
+	// Write whatever is still buffered
+	if s.listener != nil {
+		s.batch.close()
+	}
+
+	if clean {
+		fmt.Println("✅ TCP Stats Aggregator stopped cleanly")
+	} else {
 		fmt.Println("⚠️ TCP Stats Aggregator forced stop after timeout")
 	}
 }
@@ -115,109 +194,285 @@ func (s *Server) acceptLoop() {
 				}
 			}
 
-			// Set connection deadlines
-			conn.SetReadDeadline(time.Now().Add(30 * time.Second))
-			conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-
 			clientAddr := conn.RemoteAddr().String()
 			fmt.Printf("🔌 TCP client connected from %s\n", clientAddr)
 
+			s.connMu.Lock()
+			s.conns[conn] = struct{}{}
+			s.connMu.Unlock()
+			s.handlers.Add(1)
 			go s.handleConnection(conn, clientAddr)
 		}
 	}
 }
 
-// handleConnection processes a TCP connection with custom protocol framing
+// handleConnection processes a TCP connection with custom protocol framing.
+// A connection is either legacy (bare JSON events, accepted only without a
+// shared secret) or typed, opening with a hello frame; see protocol.go.
+// Events that carry a request ID are acknowledged out of order as their
+// batches flush, so one connection can have many events in flight; events
+// without one are acknowledged in order before the next frame is read.
 func (s *Server) handleConnection(conn net.Conn, clientAddr string) {
 	defer func() {
 		conn.Close()
+		s.connMu.Lock()
+		delete(s.conns, conn)
+		s.connMu.Unlock()
+		s.handlers.Done()
 		fmt.Printf("🔌 TCP client disconnected: %s\n", clientAddr)
 	}()
 
 	reader := bufio.NewReader(conn)
-	ctx := context.Background()
+	out := &responder{conn: conn}
+	defer s.subs.remove(out)
+	greeted := false
+	var clientID, nonce string // nonce is set until the client authenticates
 
 	for {
+		// Set before checking stop, so Stop's deadline is never overwritten
+		conn.SetReadDeadline(time.Now().Add(idleTimeout))
+
 		select {
 		case <-s.stop:
 			return
 		default:
-			// Read 4-byte length prefix (big-endian)
-			var length uint32
-			if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
-				if err != io.EOF {
-					fmt.Printf("❌ TCP read length error from %s: %v\n", clientAddr, err)
+			data, err := readFrame(reader, s.protocol.MaxFrameSize)
+			if err != nil {
+				select {
+				case <-s.stop:
+				default:
+					if err != io.EOF {
+						fmt.Printf("❌ TCP read error from %s: %v\n", clientAddr, err)
+					}
 				}
 				return
 			}
 
-			// Validation: Check frame size (prevent attacks)
-			const maxFrameSize = 1024 // 1KB max per SPEC.md for internal events
-			if length == 0 {
-				fmt.Printf("❌ TCP invalid frame length 0 from %s\n", clientAddr)
-				return
-			}
-			if length > maxFrameSize {
-				fmt.Printf("❌ TCP frame too large from %s: %d bytes (max %d)\n", 
-					clientAddr, length, maxFrameSize)
-				return
-			}
-
-			// Read message data
-			data := make([]byte, length)
-			if _, err := io.ReadFull(reader, data); err != nil {
-				fmt.Printf("❌ TCP read data error from %s: %v\n", clientAddr, err)
-				return
-			}
-
-			// Parse event
-			var event StatsEvent
-			if err := json.Unmarshal(data, &event); err != nil {
-				fmt.Printf("❌ TCP parse error from %s: %v\n", clientAddr, err)
-				// Send error response back to client
-				s.sendError(conn, fmt.Sprintf("Invalid event format: %v", err))
+			// Legacy clients send a bare JSON event with no handshake
+			if data[0] == legacyFrameStart {
+				if greeted {
+					out.sendError("", "legacy frame after hello; send event frames")
+					return
+				}
+				if s.protocol.SharedSecret != "" {
+					fmt.Printf("❌ TCP unauthenticated legacy frame from %s\n", clientAddr)
+					out.sendError("", "authentication required: open the session with a hello frame")
+					return
+				}
+				if len(data) > maxLegacyFrameSize {
+					fmt.Printf("❌ TCP frame too large from %s: %d bytes (max %d)\n",
+						clientAddr, len(data), maxLegacyFrameSize)
+					return
+				}
+				s.handleEvent(out, data, clientAddr)
 				continue
 			}
 
-			// Fill defaults for missing fields
-			if event.EventTime.IsZero() {
-				event.EventTime = time.Now()
-			}
-			if event.Source == "" {
-				event.Source = "system"
+			// Typed clients get typed replies, including handshake errors
+			frameType, body := FrameType(data[0]), data[1:]
+			out.setTyped()
+			if !greeted {
+				if frameType != FrameHello {
+					out.sendError("", "handshake required: first frame must be hello")
+					return
+				}
+				var ok bool
+				if clientID, nonce, ok = s.handshake(out, body, clientAddr); !ok {
+					return
+				}
+				greeted = true
+				continue
 			}
-			if event.Weight == 0 {
-				switch event.Type {
-				case EventTypeComment:
-					event.Weight = 1
-				case EventTypeChat:
-					event.Weight = 2
-				case EventTypeUpdate:
-					event.Weight = 5
-				default:
-					event.Weight = 1
+			if nonce != "" {
+				if frameType != FrameAuth {
+					out.sendError("", "authentication required: answer the welcome nonce with an auth frame")
+					return
 				}
+				if !s.authenticate(out, body, clientID, nonce, clientAddr) {
+					return
+				}
+				nonce = ""
+				continue
 			}
 
-			// Validate event
-			if err := s.validateEvent(&event, clientAddr); err != nil {
-				fmt.Printf("❌ TCP validation error from %s: %v\n", clientAddr, err)
-				s.sendError(conn, err.Error())
-				continue
+			switch frameType {
+			case FrameEvent:
+				s.handleEvent(out, body, clientAddr)
+			case FrameBatch:
+				s.handleBatch(out, body, clientAddr)
+			case FramePing:
+				out.sendFrame(FramePong, nil)
+			case FrameQuery:
+				s.handleQuery(out, body, clientAddr)
+			case FrameHello:
+				out.sendError("", "duplicate hello")
+			case FrameAuth:
+				out.sendError("", "unexpected auth")
+			default:
+				out.sendError("", fmt.Sprintf("unknown frame type 0x%02x", byte(frameType)))
 			}
+		}
+	}
+}
 
-			// Process event
-			if err := s.processEvent(ctx, &event); err != nil {
-				fmt.Printf("❌ TCP processing error for event %s from %s: %v\n", 
-					event.Type, clientAddr, err)
-				s.sendError(conn, fmt.Sprintf("Processing failed: %v", err))
-				continue
+// handshake verifies a hello and welcomes the client with a nonce to sign,
+// if the server has a shared secret. It returns the client ID and the nonce;
+// false closes the connection.
+func (s *Server) handshake(out *responder, body []byte, clientAddr string) (string, string, bool) {
+	var hello Hello
+	if err := json.Unmarshal(body, &hello); err != nil {
+		out.sendError("", fmt.Sprintf("Invalid hello format: %v", err))
+		return "", "", false
+	}
+	if err := verifyHello(hello); err != nil {
+		fmt.Printf("❌ TCP handshake rejected from %s: %v\n", clientAddr, err)
+		out.sendError("", err.Error())
+		return "", "", false
+	}
+
+	var nonce string
+	if s.protocol.SharedSecret != "" {
+		var err error
+		if nonce, err = s.nonces.issue(); err != nil {
+			fmt.Printf("❌ TCP handshake failed for %s: %v\n", clientAddr, err)
+			out.sendError("", "handshake failed")
+			return "", "", false
+		}
+	}
+
+	out.sendFrame(FrameWelcome, Welcome{Version: hello.Version, ServerID: s.addr, Nonce: nonce})
+	fmt.Printf("🤝 TCP client %s (%s) speaks protocol v%d\n", hello.ClientID, clientAddr, hello.Version)
+	return hello.ClientID, nonce, true
+}
+
+// authenticate checks the client's signature of its welcome nonce; false
+// closes the connection
+func (s *Server) authenticate(out *responder, body []byte, clientID, nonce, clientAddr string) bool {
+	var auth Auth
+	if err := json.Unmarshal(body, &auth); err != nil {
+		out.sendError("", fmt.Sprintf("Invalid auth format: %v", err))
+		return false
+	}
+	if err := verifyAuth(auth, clientID, nonce, s.protocol.SharedSecret, s.nonces); err != nil {
+		fmt.Printf("❌ TCP authentication rejected from %s: %v\n", clientAddr, err)
+		out.sendError("", err.Error())
+		return false
+	}
+
+	out.sendSuccess("", "Authenticated")
+	return true
+}
+
+// handleEvent parses, validates and buffers one event, then acknowledges it
+func (s *Server) handleEvent(out *responder, data []byte, clientAddr string) {
+	var event StatsEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		fmt.Printf("❌ TCP parse error from %s: %v\n", clientAddr, err)
+		// Send error response back to client
+		out.sendError("", fmt.Sprintf("Invalid event format: %v", err))
+		return
+	}
+
+	if err := s.prepareEvent(&event, clientAddr); err != nil {
+		out.sendError(event.RequestID, err.Error())
+		return
+	}
+
+	// Buffer event for the next batch flush
+	result := s.processEvent(&event)
+	s.reply(out, event.RequestID, event.Ack, []<-chan error{result}, clientAddr)
+}
+
+// handleBatch buffers a batch of events with one acknowledgement. The batch
+// is refused as a whole if any event is invalid.
+func (s *Server) handleBatch(out *responder, data []byte, clientAddr string) {
+	var batch EventBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		fmt.Printf("❌ TCP parse error from %s: %v\n", clientAddr, err)
+		out.sendError("", fmt.Sprintf("Invalid batch format: %v", err))
+		return
+	}
+	if len(batch.Events) == 0 {
+		out.sendError(batch.RequestID, "batch has no events")
+		return
+	}
+
+	for i := range batch.Events {
+		if err := s.prepareEvent(&batch.Events[i], clientAddr); err != nil {
+			out.sendError(batch.RequestID, fmt.Sprintf("event %d: %v", i, err))
+			return
+		}
+	}
+
+	results := make([]<-chan error, len(batch.Events))
+	for i := range batch.Events {
+		results[i] = s.processEvent(&batch.Events[i])
+	}
+	s.reply(out, batch.RequestID, batch.Ack, results, clientAddr)
+}
+
+// prepareEvent fills defaults, validates and scores an event
+func (s *Server) prepareEvent(event *StatsEvent, clientAddr string) error {
+	// Fill defaults for missing fields
+	if event.EventTime.IsZero() {
+		event.EventTime = time.Now()
+	}
+	if event.Source == "" {
+		event.Source = "system"
+	}
+
+	// Validate event
+	if err := s.validateEvent(event, clientAddr); err != nil {
+		fmt.Printf("❌ TCP validation error from %s: %v\n", clientAddr, err)
+		return err
+	}
+
+	// Score with the shared engine so clients cannot inflate rankings
+	event.Weight = s.scoring.Points(string(event.Type))
+	return nil
+}
+
+// reply acknowledges buffered events: at once for async acks, otherwise once
+// their batches are written. Replies for requests with an ID do not block
+// the connection.
+func (s *Server) reply(out *responder, requestID, ack string, results []<-chan error, clientAddr string) {
+	if ack == AckAsync {
+		// Only a refusal (e.g. during shutdown) is known this early
+		for _, result := range results {
+			select {
+			case err := <-result:
+				if err != nil {
+					out.sendError(requestID, fmt.Sprintf("Processing failed: %v", err))
+					return
+				}
+			default:
 			}
+		}
+		out.sendSuccess(requestID, "Event queued")
+		return
+	}
 
-			// Send success acknowledgment
-			s.sendSuccess(conn, "Event processed successfully")
+	// Acknowledge only once the batch holding the event is written
+	if requestID != "" {
+		go s.acknowledge(out, requestID, results, clientAddr)
+		return
+	}
+	s.acknowledge(out, requestID, results, clientAddr)
+}
+
+// acknowledge replies once every flush result has arrived
+func (s *Server) acknowledge(out *responder, requestID string, results []<-chan error, clientAddr string) {
+	for _, result := range results {
+		if err := waitForFlush(result); err != nil {
+			fmt.Printf("❌ TCP processing error for request %q from %s: %v\n",
+				requestID, clientAddr, err)
+			out.sendError(requestID, fmt.Sprintf("Processing failed: %v", err))
+			return
 		}
 	}
+
+	// Send success acknowledgment
+	out.sendSuccess(requestID, "Event processed successfully")
 }
 
 // validateEvent validates incoming stats events against schema constraints
@@ -227,10 +482,11 @@ func (s *Server) validateEvent(event *StatsEvent, clientAddr string) error {
 		EventTypeComment: true,
 		EventTypeChat:    true,
 		EventTypeUpdate:  true,
+		EventTypeLike:    true,
 	}
 	
 	if !validTypes[event.Type] {
-		return fmt.Errorf("invalid event type: %s (must be 'comment', 'chat', or 'manga_update')", event.Type)
+		return fmt.Errorf("invalid event type: %s (must be 'comment', 'chat', 'manga_update' or 'like')", event.Type)
 	}
 	
 	// Validate manga_id exists (basic check)
@@ -238,154 +494,140 @@ func (s *Server) validateEvent(event *StatsEvent, clientAddr string) error {
 		return fmt.Errorf("manga_id is required")
 	}
 	
-	// Validate weight ranges
-	if event.Weight < 1 || event.Weight > 10 {
-		return fmt.Errorf("invalid weight: %d (must be 1-10)", event.Weight)
-	}
-	
 	// Validate source types
 	validSources := map[string]bool{
 		"http":      true,
+		"grpc":      true,
 		"websocket": true,
 		"admin":     true,
 		"system":    true,
+		"outbox":    true,
 	}
 	
 	if !validSources[event.Source] {
-		return fmt.Errorf("invalid source: %s (must be 'http', 'websocket', 'admin', or 'system')", event.Source)
+		return fmt.Errorf("invalid source: %s (must be 'http', 'grpc', 'websocket', 'admin', 'system' or 'outbox')", event.Source)
 	}
 	
 	return nil
 }
 
-// processEvent handles a stats event with atomic database operations
-func (s *Server) processEvent(ctx context.Context, event *StatsEvent) error {
+// processEvent buffers a stats event; the returned channel receives the
+// result of the flush that writes it
+func (s *Server) processEvent(event *StatsEvent) <-chan error {
 	fmt.Printf("📊 Processing event: type=%s manga_id=%s weight=%d source=%s\n",
 		event.Type, event.MangaID, event.Weight, event.Source)
 
-	// 1. Log to activity feed first (for audit trail)
-	// Avoid duplicates when HTTP layer already logs activity
-	if event.Source != "http" {
-		activity := &models.Activity{
-			ID:        generateActivityID(),
+	// 1. Log to activity feed (for audit trail)
+	// Avoid duplicates when the HTTP/gRPC layer or the outbox writer already
+	// logged the activity
+	var activity *models.Activity
+	if event.Source != "http" && event.Source != "grpc" && event.Source != "outbox" && event.Type != EventTypeLike {
+		activity = &models.Activity{
+			ID:        uuid.New().String(),
 			Type:      string(event.Type),
 			UserID:    event.UserID,
 			MangaID:   &event.MangaID,
 			CreatedAt: event.EventTime,
 		}
-		
-		if err := s.activityRepo.Create(ctx, activity); err != nil {
-			return fmt.Errorf("failed to log activity: %w", err)
-		}
 	}
 
-	// 2. Update stats based on event type
+	// 2. Counter increments, coalesced per manga and day until the flush
+	return s.batch.add(event.EventID, eventUpdate(event), activity)
+}
+
+// eventUpdate converts an event into the counter increments it contributes
+// to manga_stats and its day in manga_stats_daily
+func eventUpdate(event *StatsEvent) models.StatsUpdate {
+	update := models.StatsUpdate{
+		MangaID:     event.MangaID,
+		Day:         event.EventTime.Local().Format("2006-01-02"),
+		WeeklyScore: event.Weight,
+	}
 	switch event.Type {
 	case EventTypeComment:
-		return s.processCommentEvent(ctx, event)
+		update.CommentCount = 1
 	case EventTypeChat:
-		return s.processChatEvent(ctx, event)
-	case EventTypeUpdate:
-		return s.processUpdateEvent(ctx, event)
-	default:
-		// Default to comment processing for unknown types
-		return s.processCommentEvent(ctx, event)
+		update.ChatCount = 1
+	case EventTypeLike:
+		update.LikeCount = 1
 	}
+	return update
 }
 
-// processCommentEvent handles comment-related statistics
-func (s *Server) processCommentEvent(ctx context.Context, event *StatsEvent) error {
-	// Atomic comment count increment
-	if err := s.statsRepo.IncrementCommentCount(ctx, event.MangaID); err != nil {
-		return fmt.Errorf("failed to increment comment count: %w", err)
-	}
-	
-	// Update weekly score with comment weight
-	if event.Weight > 0 {
-		// Get current stats to calculate new score
-		stats, err := s.statsRepo.GetByMangaID(ctx, event.MangaID)
-		if err != nil {
-			return fmt.Errorf("failed to get stats: %w", err)
-		}
-		
-		newScore := stats.WeeklyScore + event.Weight
-		if err := s.statsRepo.UpdateWeeklyScore(ctx, event.MangaID, newScore); err != nil {
-			return fmt.Errorf("failed to update weekly score: %w", err)
-		}
+// waitForFlush waits for a buffered event's flush result
+func waitForFlush(result <-chan error) error {
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(15 * time.Second):
+		return fmt.Errorf("timed out waiting for stats flush")
 	}
-	
-	return nil
 }
 
-// processChatEvent handles chat-related statistics
-func (s *Server) processChatEvent(ctx context.Context, event *StatsEvent) error {
-	// Atomic chat count increment (weighted higher than comments)
-	if err := s.statsRepo.IncrementChatCount(ctx, event.MangaID); err != nil {
-		return fmt.Errorf("failed to increment chat count: %w", err)
-	}
-
-	return nil
+// responder serialises replies on a connection; pipelined acknowledgements
+// are written from several goroutines
+type responder struct {
+	conn  net.Conn
+	mu    sync.Mutex
+	typed bool // Reply with typed frames after a hello
 }
 
-// processUpdateEvent handles manga update statistics (admin actions)
-func (s *Server) processUpdateEvent(ctx context.Context, event *StatsEvent) error {
-	// Admin updates get bonus points
-	stats, err := s.statsRepo.GetByMangaID(ctx, event.MangaID)
-	if err != nil {
-		return fmt.Errorf("failed to get stats: %w", err)
-	}
-	
-	// Admin updates get high weight (5x normal comments)
-	newScore := stats.WeeklyScore + event.Weight
-	if err := s.statsRepo.UpdateWeeklyScore(ctx, event.MangaID, newScore); err != nil {
-		return fmt.Errorf("failed to update weekly score: %w", err)
-	}
-	
-	return nil
+// setTyped switches replies to typed frames
+func (r *responder) setTyped() {
+	r.mu.Lock()
+	r.typed = true
+	r.mu.Unlock()
 }
 
 // sendError sends an error response back to client
-func (s *Server) sendError(conn net.Conn, message string) {
-	response := map[string]string{
-		"status":  "error",
-		"message": message,
-	}
-	
-	s.sendResponse(conn, response)
+func (r *responder) sendError(requestID, message string) {
+	r.send(Response{Status: "error", Message: message, RequestID: requestID})
 }
 
 // sendSuccess sends a success response back to client
-func (s *Server) sendSuccess(conn net.Conn, message string) {
-	response := map[string]string{
-		"status":  "success",
-		"message": message,
-	}
-	
-	s.sendResponse(conn, response)
+func (r *responder) sendSuccess(requestID, message string) {
+	r.send(Response{Status: "success", Message: message, RequestID: requestID})
 }
 
-// sendResponse sends a JSON response with proper framing
-func (s *Server) sendResponse(conn net.Conn, data interface{}) {
-	responseBytes, err := json.Marshal(data)
-	if err != nil {
-		fmt.Printf("❌ TCP response marshal error: %v\n", err)
+// send writes a response as a typed frame, or bare JSON for legacy clients
+func (r *responder) send(response Response) {
+	r.mu.Lock()
+	typed := r.typed
+	r.mu.Unlock()
+
+	if typed {
+		r.sendFrame(FrameResponse, response)
 		return
 	}
-	
-	// Write length prefix
-	if err := binary.Write(conn, binary.BigEndian, uint32(len(responseBytes))); err != nil {
-		fmt.Printf("❌ TCP response length write error: %v\n", err)
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		fmt.Printf("❌ TCP response marshal error: %v\n", err)
 		return
 	}
-	
-	// Write response data
-	if _, err := conn.Write(responseBytes); err != nil {
+	r.write(func() error { return writeFrame(r.conn, responseBytes) })
+}
+
+// sendFrame writes a typed frame
+func (r *responder) sendFrame(frameType FrameType, body interface{}) {
+	r.write(func() error { return writeTypedFrame(r.conn, frameType, body) })
+}
+
+// write runs one frame write under the connection's write lock
+func (r *responder) write(fn func() error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if err := fn(); err != nil {
 		fmt.Printf("❌ TCP response write error: %v\n", err)
 	}
 }
 
-// SendStatsEvent is a helper for other services to send stats events
-// This should be used by HTTP, WebSocket, and admin services
+// SendStatsEvent sends one event as a legacy frame over a fresh connection
+// and waits for the reply. It only works against servers without a shared
+// secret; long-running services should share a StatsClient instead. Set
+// EventID before retrying so the server can skip the replay.
 func SendStatsEvent(addr string, event StatsEvent) error {
 	conn, err := net.DialTimeout("tcp", addr, 2*time.Second)
 	if err != nil {
@@ -393,9 +635,9 @@ func SendStatsEvent(addr string, event StatsEvent) error {
 	}
 	defer conn.Close()
 	
-	// Set deadlines
+	// Set deadlines (the reply waits for the server's next batch flush)
 	conn.SetWriteDeadline(time.Now().Add(1 * time.Second))
-	conn.SetReadDeadline(time.Now().Add(1 * time.Second))
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
 	
 	// Marshal event
 	data, err := json.Marshal(event)
@@ -425,23 +667,18 @@ func SendStatsEvent(addr string, event StatsEvent) error {
 		return fmt.Errorf("read response data: %w", err)
 	}
 	
-	var response map[string]string
+	var response Response
 	if err := json.Unmarshal(responseData, &response); err != nil {
 		return fmt.Errorf("parse response: %w", err)
 	}
 	
-	if response["status"] != "success" {
-		return fmt.Errorf("server error: %s", response["message"])
+	if response.Status != "success" {
+		return &RejectedError{Message: response.Message}
 	}
 	
 	return nil
 }
 
-// generateActivityID creates a unique activity ID (simplified for example)
-func generateActivityID() string {
-	return fmt.Sprintf("act-%d", time.Now().UnixNano())
-}
-
 // isTemporaryError checks if an error is temporary
 func isTemporaryError(err error) bool {
 	if netErr, ok := err.(net.Error); ok {
@@ -0,0 +1,171 @@
+package udp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"mangahub/pkg/models"
+)
+
+// Notification feed
+//
+// Rows inserted into notifications (by this server, another instance or a
+// script) are announced by a trigger on the "notifications" channel. The
+// server LISTENs on it and broadcasts each new row. After every (re)connect
+// it catches up on rows inserted while it was not listening, paging by
+// (created_at, id). Notifications this server logged itself were already
+// queued by Broadcast, so their IDs are remembered and the echo is skipped.
+
+const (
+	feedRetryDelay = 5 * time.Second // Before listening again after a failure
+	feedPageSize   = 100             // Rows per catch-up query
+	feedSeenSize   = 1024            // Recent notification IDs kept for dedupe
+)
+
+// feed tracks the notifications already broadcast and the catch-up position
+type feed struct {
+	mu         sync.Mutex
+	seen       map[string]bool
+	order      []string // Ring of seen IDs, oldest evicted first
+	next       int
+	positioned bool // Cursor set from the database
+	createdAt  time.Time
+	id         string
+}
+
+func newFeed() *feed {
+	return &feed{seen: make(map[string]bool), order: make([]string, feedSeenSize)}
+}
+
+// markSeen remembers an ID; false if it was already seen
+func (f *feed) markSeen(id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.seen[id] {
+		return false
+	}
+	if old := f.order[f.next]; old != "" {
+		delete(f.seen, old)
+	}
+	f.order[f.next] = id
+	f.next = (f.next + 1) % len(f.order)
+	f.seen[id] = true
+	return true
+}
+
+func (f *feed) isSeen(id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.seen[id]
+}
+
+// advance moves the catch-up position forward to a notification
+func (f *feed) advance(n *models.Notification) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if n.CreatedAt.After(f.createdAt) || (n.CreatedAt.Equal(f.createdAt) && n.ID > f.id) {
+		f.createdAt = n.CreatedAt
+		f.id = n.ID
+	}
+	f.positioned = true
+}
+
+func (f *feed) position() (createdAt time.Time, id string, ok bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.createdAt, f.id, f.positioned
+}
+
+// listenForNotifications broadcasts notifications inserted into the database
+// until the server stops, listening again after connection failures
+func (s *Server) listenForNotifications() {
+	fmt.Println("🔍 UDP notification listener started")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-s.stop
+		cancel()
+	}()
+
+	for {
+		err := s.notificationRepo.Listen(ctx, func() { s.catchUp(ctx) }, func(id string) { s.notified(ctx, id) })
+		if err != nil {
+			fmt.Printf("❌ UDP notification listener error, retrying in %s: %v\n", feedRetryDelay, err)
+		}
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(feedRetryDelay):
+		}
+	}
+}
+
+// catchUp broadcasts notifications inserted since the last one seen. On the
+// first connect it only records where the log ends, so history is not resent.
+func (s *Server) catchUp(ctx context.Context) {
+	createdAt, id, ok := s.feed.position()
+	if !ok {
+		latest, err := s.notificationRepo.GetLatest(ctx)
+		if err != nil {
+			fmt.Printf("❌ UDP database error: %v\n", err)
+			return
+		}
+		if latest == nil {
+			latest = &models.Notification{}
+		}
+		s.feed.advance(latest)
+		return
+	}
+
+	for {
+		notifications, err := s.notificationRepo.GetSince(ctx, createdAt, id, feedPageSize)
+		if err != nil {
+			fmt.Printf("❌ UDP database error: %v\n", err)
+			return
+		}
+		for _, notif := range notifications {
+			s.deliverLogged(notif)
+		}
+		if len(notifications) < feedPageSize {
+			return
+		}
+		last := notifications[len(notifications)-1]
+		createdAt, id = last.CreatedAt, last.ID
+	}
+}
+
+// notified broadcasts the notification announced by a NOTIFY
+func (s *Server) notified(ctx context.Context, id string) {
+	if s.feed.isSeen(id) {
+		return
+	}
+	notif, err := s.notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		fmt.Printf("❌ UDP database error: %v\n", err)
+		return
+	}
+	s.deliverLogged(notif)
+}
+
+// deliverLogged queues a notification read from the database, unless it was
+// already broadcast
+func (s *Server) deliverLogged(notif *models.Notification) {
+	s.feed.advance(notif)
+	if !s.feed.markSeen(notif.ID) {
+		return
+	}
+
+	// Broadcast to all clients without re-logging to DB
+	s.enqueue(Notification{
+		Message:   notif.Message,
+		Timestamp: notif.CreatedAt,
+		Type:      notif.Type,
+		MangaID:   notif.MangaID,
+		Title:     notif.Title,
+	})
+}
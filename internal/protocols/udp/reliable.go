@@ -0,0 +1,216 @@
+package udp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+	"unicode/utf8"
+)
+
+// Reliable delivery
+//
+// Unicast subscribers that set "reliable" in their subscribe packet receive
+// every notification as one or more DataPackets carrying a per-subscriber
+// sequence number. Payloads larger than one datagram are split into parts
+// and reassembled by the client. A client that sees a gap, or a NextSeq in a
+// "subscribed" answer beyond what it received, sends a "nack" listing the
+// missing sequence numbers. The server resends them from a short history, or
+// answers "lost" for those that have left it so the client can move on.
+//
+// Everyone else (plain subscribers, broadcast and multicast) keeps getting a
+// bare Notification per datagram, shortened to fit rather than cut mid-JSON.
+
+// Reliable delivery packet types
+const (
+	PacketData = "data" // Server -> client: DataPacket
+	PacketNack = "nack" // Client -> server: ControlPacket with Seqs
+	PacketLost = "lost" // Server -> client: ControlPacket with Seqs no longer held
+)
+
+const (
+	historySize = 128 // Notifications kept per reliable subscriber for NACKs
+	chunkSize   = 672 // Payload bytes per part; base64 plus header stays under maxPacketSize
+	maxChunks   = 64  // Largest reliable payload is chunkSize * maxChunks
+	maxNackSeqs = 32  // Sequence numbers honoured per NACK
+)
+
+// DataPacket carries one part of a sequenced notification
+type DataPacket struct {
+	Type  string `json:"type"` // PacketData
+	Seq   uint64 `json:"seq"`
+	Part  int    `json:"part"`  // 0-based
+	Parts int    `json:"parts"` // Parts making up the payload
+	Data  []byte `json:"data"`  // Part of the JSON-encoded Notification
+}
+
+// sentNotification is an encoded notification shared by the histories of
+// the subscribers it was sent to
+type sentNotification struct {
+	payload []byte
+}
+
+// historyEntry is one slot of a subscriber's retransmission ring
+type historyEntry struct {
+	seq  uint64
+	sent *sentNotification
+}
+
+// record assigns the next sequence number to a notification for a reliable
+// subscriber and keeps it for retransmission
+func (r *subscribers) record(sub *subscriber, sent *sentNotification) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sub.nextSeq++
+	seq := sub.nextSeq
+	if sub.history == nil {
+		sub.history = make([]historyEntry, historySize)
+	}
+	sub.history[seq%historySize] = historyEntry{seq: seq, sent: sent}
+	return seq
+}
+
+// lookup returns the retained notifications for the requested sequence
+// numbers and the ones that are no longer held
+func (r *subscribers) lookup(addr *net.UDPAddr, seqs []uint64) (found map[uint64]*sentNotification, lost []uint64, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sub, exists := r.subs[addr.String()]
+	if !exists || !sub.reliable {
+		return nil, nil, false
+	}
+
+	found = make(map[uint64]*sentNotification)
+	for _, seq := range seqs {
+		if seq == 0 || seq > sub.nextSeq {
+			continue
+		}
+		entry := sub.history[seq%historySize]
+		if entry.seq == seq && entry.sent != nil {
+			found[seq] = entry.sent
+		} else {
+			lost = append(lost, seq)
+		}
+	}
+	return found, lost, true
+}
+
+// nextSeq returns the sequence number a reliable subscriber will see next
+func (r *subscribers) nextSeq(addr *net.UDPAddr) uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if sub, ok := r.subs[addr.String()]; ok && sub.reliable {
+		return sub.nextSeq + 1
+	}
+	return 0
+}
+
+// sendSequenced sends every part of a sequenced notification to one client
+func (s *Server) sendSequenced(addr *net.UDPAddr, seq uint64, payload []byte) error {
+	parts := (len(payload) + chunkSize - 1) / chunkSize
+	if parts > maxChunks {
+		return fmt.Errorf("notification too large (%d bytes, max %d)", len(payload), chunkSize*maxChunks)
+	}
+
+	for part := 0; part < parts; part++ {
+		end := (part + 1) * chunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		data, err := json.Marshal(DataPacket{
+			Type:  PacketData,
+			Seq:   seq,
+			Part:  part,
+			Parts: parts,
+			Data:  payload[part*chunkSize : end],
+		})
+		if err != nil {
+			return fmt.Errorf("marshal data packet: %w", err)
+		}
+		s.write(addr, data)
+	}
+	return nil
+}
+
+// handleNack resends the requested notifications still in the history
+func (s *Server) handleNack(addr *net.UDPAddr, packet ControlPacket) {
+	seqs := packet.Seqs
+	if len(seqs) > maxNackSeqs {
+		seqs = seqs[:maxNackSeqs]
+	}
+
+	found, lost, ok := s.subs.lookup(addr, seqs)
+	if !ok {
+		s.reply(addr, ControlPacket{Type: PacketUnsubscribed})
+		return
+	}
+
+	for seq, sent := range found {
+		if err := s.sendSequenced(addr, seq, sent.payload); err != nil {
+			lost = append(lost, seq)
+		}
+	}
+	if len(lost) > 0 {
+		s.reply(addr, ControlPacket{Type: PacketLost, Seqs: lost})
+	}
+}
+
+// fitPlain encodes a notification for a single plain datagram, shortening
+// the message when needed so the packet stays valid JSON
+func fitPlain(notification Notification) ([]byte, error) {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) <= maxPacketSize {
+		return data, nil
+	}
+
+	// JSON escaping grows some characters more than others, so search for
+	// the longest prefix, cut at a rune boundary, that still fits
+	message := notification.Message
+	var best []byte
+	lo, hi := 0, len(message)-1
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		cut := mid
+		for cut > 0 && !utf8.RuneStart(message[cut]) {
+			cut--
+		}
+		notification.Message = ""
+		if cut > 0 {
+			notification.Message = message[:cut] + "…"
+		}
+		candidate, err := json.Marshal(notification)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidate) <= maxPacketSize {
+			best = candidate
+			lo = mid + 1
+		} else {
+			hi = mid - 1
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("notification too large (%d bytes) even without a message", len(data)-len(message))
+	}
+	return best, nil
+}
+
+// write sends one datagram, counting it as sent
+func (s *Server) write(addr *net.UDPAddr, data []byte) {
+	s.conn.SetWriteDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, err := s.conn.WriteToUDP(data, addr); err != nil {
+		// Don't fail on send errors - UDP is fire-and-forget
+		fmt.Printf("_UDP send error (ignored): %v\n", err)
+		return
+	}
+
+	s.stats.mu.Lock()
+	s.stats.packetsSent++
+	s.stats.mu.Unlock()
+}
@@ -0,0 +1,136 @@
+package udp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loopbackPair returns a server writing from one loopback socket and the
+// socket it writes to
+func loopbackPair(t *testing.T) (*Server, *net.UDPConn) {
+	t.Helper()
+
+	serverConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { serverConn.Close() })
+
+	client, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	return &Server{conn: serverConn}, client
+}
+
+func TestSendSequencedChunks(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		wantParts int
+		wantErr   bool
+	}{
+		{name: "one byte", size: 1, wantParts: 1},
+		{name: "exactly one chunk", size: chunkSize, wantParts: 1},
+		{name: "one byte over a chunk", size: chunkSize + 1, wantParts: 2},
+		{name: "largest payload", size: chunkSize * maxChunks, wantParts: maxChunks},
+		{name: "too large", size: chunkSize*maxChunks + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := loopbackPair(t)
+			payload := bytes.Repeat([]byte(`"é\x00`), tt.size/4+1)[:tt.size]
+
+			err := server.sendSequenced(client.LocalAddr().(*net.UDPAddr), 7, payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var joined []byte
+			buf := make([]byte, 2*maxPacketSize)
+			for part := 0; part < tt.wantParts; part++ {
+				client.SetReadDeadline(time.Now().Add(time.Second))
+				n, err := client.Read(buf)
+				require.NoError(t, err)
+				assert.LessOrEqual(t, n, maxPacketSize, "datagram over the packet limit")
+
+				var packet DataPacket
+				require.NoError(t, json.Unmarshal(buf[:n], &packet))
+				assert.Equal(t, PacketData, packet.Type)
+				assert.Equal(t, uint64(7), packet.Seq)
+				assert.Equal(t, part, packet.Part)
+				assert.Equal(t, tt.wantParts, packet.Parts)
+				joined = append(joined, packet.Data...)
+			}
+			assert.Equal(t, payload, joined)
+		})
+	}
+}
+
+func TestFitPlain(t *testing.T) {
+	mangaID := "manga-1"
+
+	tests := []struct {
+		name         string
+		notification Notification
+		wantErr      bool
+		unchanged    bool
+	}{
+		{
+			name:         "fits as is",
+			notification: Notification{Message: "📚 New manga: Frieren", Type: "manga_update", MangaID: &mangaID},
+			unchanged:    true,
+		},
+		{
+			name:         "long ascii message",
+			notification: Notification{Message: strings.Repeat("a", 3*maxPacketSize), Type: "system"},
+		},
+		{
+			name:         "multi-byte runes are not split",
+			notification: Notification{Message: strings.Repeat("漫画", maxPacketSize), Type: "system"},
+		},
+		{
+			name:         "escaping grows the message",
+			notification: Notification{Message: strings.Repeat(`"<\`, maxPacketSize), Type: "system"},
+		},
+		{
+			name:         "too large without a message",
+			notification: Notification{Message: "hi", Type: "system", Title: strings.Repeat("t", 2*maxPacketSize)},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := fitPlain(tt.notification)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(data), maxPacketSize)
+
+			var got Notification
+			require.NoError(t, json.Unmarshal(data, &got), "shortened packet is not valid JSON")
+			assert.True(t, utf8.ValidString(got.Message))
+			assert.Equal(t, tt.notification.Type, got.Type)
+			assert.Equal(t, tt.notification.MangaID, got.MangaID)
+
+			if tt.unchanged {
+				assert.Equal(t, tt.notification.Message, got.Message)
+				return
+			}
+			assert.True(t, strings.HasSuffix(got.Message, "…"), "shortened message has no ellipsis")
+			assert.True(t, strings.HasPrefix(tt.notification.Message, strings.TrimSuffix(got.Message, "…")))
+		})
+	}
+}
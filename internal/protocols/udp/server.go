@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/time/rate"
 	"mangahub/internal/repository"
 	"mangahub/pkg/models"
@@ -17,12 +18,13 @@ import (
 type Notification struct {
 	Message   string    `json:"message"`      // Matches notifications.message field
 	Timestamp time.Time `json:"timestamp"`    // Matches notifications.created_at
-	Type      string    `json:"type"`         // Internal type for routing (not in schema)
-	MangaID   *string   `json:"manga_id,omitempty"` // For context, not stored in schema
-	Title     string    `json:"title,omitempty"`    // Optional display hint (not stored in schema)
+	Type      string    `json:"type"`         // Matches notifications.type, used for routing
+	MangaID   *string   `json:"manga_id,omitempty"` // Matches notifications.manga_id
+	Title     string    `json:"title,omitempty"`    // Matches notifications.title, a display hint
 }
 
-// Server manages UDP notification broadcasting
+// Server delivers UDP notifications to subscribers, a broadcast address or
+// a multicast group
 type Server struct {
 	addr        string
 	conn        *net.UDPConn
@@ -36,19 +38,31 @@ type Server struct {
 		packetsDropped  uint64
 		packetsSent     uint64
 	}
-	broadcastAddr *net.UDPAddr // Single broadcast address (no client registration)
+	delivery DeliveryConfig
+	subs     *subscribers
+	target   *net.UDPAddr // Broadcast address or multicast group; nil in unicast mode
+	feed     *feed
 }
 
 const (
 	maxPacketSize    = 1024                  // 1KB max packet size (SPEC.md requirement)
 	packetsPerSecond = 100                   // Rate limit: 100 packets/sec (SPEC.md requirement)
 	burstSize        = 50                    // Burst allowance
+	defaultSubscriberTTL  = 90 * time.Second
+	defaultMaxSubscribers = 10000
 )
 
 // NewServer creates a new UDP notification server
-func NewServer(host string, port int, notificationRepo repository.NotificationRepository) *Server {
-	// Resolve broadcast address (all clients listen on same port)
-	broadcastAddr, _ := net.ResolveUDPAddr("udp", fmt.Sprintf("%s:%d", "255.255.255.255", port))
+func NewServer(host string, port int, notificationRepo repository.NotificationRepository, delivery DeliveryConfig) *Server {
+	if delivery.Mode == "" {
+		delivery.Mode = ModeUnicast
+	}
+	if delivery.SubscriberTTL <= 0 {
+		delivery.SubscriberTTL = defaultSubscriberTTL
+	}
+	if delivery.MaxSubscribers <= 0 {
+		delivery.MaxSubscribers = defaultMaxSubscribers
+	}
 
 	return &Server{
 		addr:             fmt.Sprintf("%s:%d", host, port),
@@ -56,12 +70,46 @@ func NewServer(host string, port int, notificationRepo repository.NotificationRe
 		stop:             make(chan struct{}),
 		rateLimiter:      rate.NewLimiter(rate.Limit(packetsPerSecond), burstSize),
 		notificationRepo: notificationRepo,
-		broadcastAddr:    broadcastAddr,
+		delivery:         delivery,
+		subs:             newSubscribers(delivery.SubscriberTTL, delivery.MaxSubscribers),
+		feed:             newFeed(),
+	}
+}
+
+// resolveTarget resolves the group address for broadcast and multicast mode
+func (s *Server) resolveTarget() error {
+	switch s.delivery.Mode {
+	case ModeUnicast:
+		return nil
+	case ModeBroadcast:
+		// All clients listen on the server port
+		_, port, err := net.SplitHostPort(s.addr)
+		if err != nil {
+			return err
+		}
+		s.target, err = net.ResolveUDPAddr("udp", net.JoinHostPort("255.255.255.255", port))
+		return err
+	case ModeMulticast:
+		addr, err := net.ResolveUDPAddr("udp", s.delivery.MulticastGroup)
+		if err != nil {
+			return fmt.Errorf("resolve multicast group: %w", err)
+		}
+		if !addr.IP.IsMulticast() {
+			return fmt.Errorf("%s is not a multicast address", s.delivery.MulticastGroup)
+		}
+		s.target = addr
+		return nil
+	default:
+		return fmt.Errorf("unknown udp mode %q (want unicast, broadcast or multicast)", s.delivery.Mode)
 	}
 }
 
 // Start starts the UDP notification server
 func (s *Server) Start() error {
+	if err := s.resolveTarget(); err != nil {
+		return err
+	}
+
 	addr, err := net.ResolveUDPAddr("udp", s.addr)
 	if err != nil {
 		return fmt.Errorf("resolve udp addr: %w", err)
@@ -78,13 +126,17 @@ func (s *Server) Start() error {
 	}
 
 	s.conn = conn
-	fmt.Printf("✅ UDP Notification Server started on %s (broadcast mode)\n", s.addr)
+	fmt.Printf("✅ UDP Notification Server started on %s (%s mode)\n", s.addr, s.delivery.Mode)
 
 	// Start broadcast goroutine
 	go s.broadcastLoop()
 
-	// Start database polling for notifications
-	go s.pollDatabaseForNotifications()
+	// Answer subscription packets and expire silent subscribers
+	go s.readLoop()
+	go s.expireLoop()
+
+	// Broadcast notifications logged to the database by anyone
+	go s.listenForNotifications()
 
 	return nil
 }
@@ -115,7 +167,8 @@ func (s *Server) broadcastLoop() {
 	}
 }
 
-// broadcastNotification sends a single notification via UDP broadcast
+// broadcastNotification sends a single notification to the group address,
+// or to each matching subscriber in unicast mode
 func (s *Server) broadcastNotification(notification Notification) error {
 	// Rate limiting (SPEC.md requirement: 100 packets/second)
 	if !s.rateLimiter.Allow() {
@@ -130,101 +183,84 @@ func (s *Server) broadcastNotification(notification Notification) error {
 		notification.Timestamp = time.Now()
 	}
 
-	// Marshal to JSON
-	data, err := json.Marshal(notification)
+	// Marshal to JSON; reliable subscribers get it whole, in parts
+	payload, err := json.Marshal(notification)
 	if err != nil {
 		return fmt.Errorf("marshal notification: %w", err)
 	}
 
-	// VALIDATION: Check packet size (SPEC.md requirement: max 1KB)
-	if len(data) > maxPacketSize {
-		fmt.Printf("⚠️ UDP: Notification too large (%d bytes), truncating\n", len(data))
-		data = data[:maxPacketSize]
+	// VALIDATION: Plain packets must fit one datagram (SPEC.md requirement: max 1KB)
+	plain, err := fitPlain(notification)
+	if err != nil {
+		return err
+	}
+	if len(plain) < len(payload) {
+		fmt.Printf("⚠️ UDP: Notification too large (%d bytes), shortened for plain delivery\n", len(payload))
 	}
 
-	// Send broadcast packet (fire-and-forget)
-	s.conn.SetWriteDeadline(time.Now().Add(100 * time.Millisecond))
-	_, err = s.conn.WriteToUDP(data, s.broadcastAddr)
-	if err != nil {
-		// Don't fail on send errors - UDP is fire-and-forget
-		fmt.Printf("_UDP send error (ignored): %v\n", err)
+	if s.target != nil {
+		s.write(s.target, plain)
+		fmt.Printf("📤 UDP sent to %s group: '%s' (%d bytes)\n", s.delivery.Mode,
+			notification.Message[:min(len(notification.Message), 50)], len(plain))
+		return nil
 	}
 
-	// Update stats
-	s.stats.mu.Lock()
-	s.stats.packetsSent++
-	s.stats.mu.Unlock()
+	// Send packets (fire-and-forget)
+	targets := s.subs.matching(notification)
+	sent := &sentNotification{payload: payload}
+	for _, sub := range targets {
+		if !s.subs.isReliable(sub) {
+			s.write(sub.addr, plain)
+			continue
+		}
+		seq := s.subs.record(sub, sent)
+		if err := s.sendSequenced(sub.addr, seq, payload); err != nil {
+			fmt.Printf("❌ UDP: %v\n", err)
+		}
+	}
 
 	// Log successful broadcast for debugging
-	fmt.Printf("📤 UDP broadcasted: '%s' (%d bytes)\n", 
-		notification.Message[:min(len(notification.Message), 50)], len(data))
+	fmt.Printf("📤 UDP sent to %d subscribers: '%s' (%d bytes)\n", len(targets),
+		notification.Message[:min(len(notification.Message), 50)], len(payload))
 
 	return nil
 }
 
-// pollDatabaseForNotifications polls database for new notifications
-func (s *Server) pollDatabaseForNotifications() {
-	fmt.Println("🔍 UDP database polling started")
-
-	var lastID string
-	ctx := context.Background()
-
-	for {
-		select {
-		case <-s.stop:
-			return
-		case <-time.After(1 * time.Second): // Poll every second
-			// Get new notifications since last check
-			notifications, err := s.notificationRepo.GetNewNotifications(ctx, lastID)
-			if err != nil {
-				fmt.Printf("❌ UDP database error: %v\n", err)
-				continue
-			}
+// Broadcast queues a notification for broadcast (fire-and-forget)
+func (s *Server) Broadcast(notification Notification) {
+	if notification.Timestamp.IsZero() {
+		notification.Timestamp = time.Now()
+	}
 
-			for _, notif := range notifications {
-				// Convert database notification to broadcast format
-				broadcastNotif := Notification{
-					Message:   notif.Message,
-					Timestamp: notif.CreatedAt,
-					Type:      "system", // Default type for database notifications
-				}
-
-				// Broadcast to all clients without re-logging to DB
-				select {
-				case s.broadcast <- broadcastNotif:
-				default:
-					s.stats.mu.Lock()
-					s.stats.packetsDropped++
-					s.stats.mu.Unlock()
-					fmt.Println("❌ UDP: Broadcast channel full, dropping notification")
-				}
-
-				// Update last ID for next poll
-				if notif.ID > lastID {
-					lastID = notif.ID
-				}
-			}
-		}
+	// Log notification to database first (SPEC.md requirement)
+	if _, err := s.logNotification(context.Background(), generateNotificationID(), notification); err != nil {
+		fmt.Printf("❌ UDP database log error: %v\n", err)
 	}
+
+	s.enqueue(notification)
 }
 
-// Broadcast queues a notification for broadcast (fire-and-forget)
-func (s *Server) Broadcast(notification Notification) {
-	// Log notification to database first (SPEC.md requirement)
-	if notification.Message != "" {
-		notif := &models.Notification{
-			ID:        generateNotificationID(),
-			Message:   notification.Message,
-			CreatedAt: notification.Timestamp,
-		}
-		
-		ctx := context.Background()
-		if err := s.notificationRepo.Create(ctx, notif); err != nil {
-			fmt.Printf("❌ UDP database log error: %v\n", err)
-		}
+// logNotification stores a notification under id, reporting false if that ID
+// was already logged. The insert is announced back to this server, which
+// skips it by ID.
+func (s *Server) logNotification(ctx context.Context, id string, notification Notification) (bool, error) {
+	if notification.Message == "" {
+		return true, nil
 	}
 
-	// Queue for broadcast (non-blocking)
+	s.feed.markSeen(id)
+	return s.notificationRepo.Create(ctx, &models.Notification{
+		ID:        id,
+		Message:   notification.Message,
+		Type:      notification.Type,
+		MangaID:   notification.MangaID,
+		Title:     notification.Title,
+		CreatedAt: notification.Timestamp,
+	})
+}
+
+// enqueue queues a notification for broadcast (non-blocking)
+func (s *Server) enqueue(notification Notification) {
 	select {
 	case s.broadcast <- notification:
 	default:
@@ -236,6 +272,32 @@ func (s *Server) Broadcast(notification Notification) {
 	}
 }
 
+// DeliverOutbox broadcasts a notification recorded in the outbox
+func (s *Server) DeliverOutbox(ctx context.Context, event *models.OutboxEvent) error {
+	var payload models.OutboxNotificationPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid notification payload: %w", err)
+	}
+
+	notification := Notification{
+		Message:   payload.Message,
+		Timestamp: event.CreatedAt,
+		Type:      payload.Type,
+		MangaID:   payload.MangaID,
+		Title:     payload.Title,
+	}
+
+	// Keyed on the outbox event, so a redelivery is neither logged nor sent twice
+	created, err := s.logNotification(ctx, event.ID, notification)
+	if err != nil {
+		return fmt.Errorf("log notification: %w", err)
+	}
+	if created {
+		s.enqueue(notification)
+	}
+	return nil
+}
+
 // SendSystemNotification sends a system notification (admin use)
 func (s *Server) SendSystemNotification(message string) {
 	s.Broadcast(Notification{
@@ -264,9 +326,14 @@ func (s *Server) GetStats() (received, dropped, sent uint64) {
 	return s.stats.packetsReceived, s.stats.packetsDropped, s.stats.packetsSent
 }
 
+// Subscribers returns the number of registered unicast subscribers
+func (s *Server) Subscribers() int {
+	return s.subs.count()
+}
+
 // Helper function to generate notification ID
 func generateNotificationID() string {
-	return fmt.Sprintf("notif-%d", time.Now().UnixNano())
+	return uuid.New().String()
 }
 
 // Helper function for string truncation
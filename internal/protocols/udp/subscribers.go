@@ -0,0 +1,305 @@
+package udp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// Subscription protocol
+//
+// In unicast mode clients register by sending a JSON ControlPacket of type
+// "subscribe" from the socket they listen on, optionally filtered by manga
+// IDs and notification types (topics). The server replies "subscribed" and
+// unicasts matching notifications to that address until the subscription
+// expires. Clients keep it alive with "heartbeat" packets and end it with
+// "unsubscribe". A heartbeat for an unknown or expired subscription is
+// answered with "unsubscribed" so the client can subscribe again.
+//
+// In broadcast and multicast mode the server still answers subscribe
+// packets, reporting the mode and group so clients know where to listen.
+//
+// Unicast subscribers may opt into sequenced, chunked delivery with
+// retransmission; see reliable.go.
+
+// Delivery modes, selected with DeliveryConfig.Mode
+const (
+	ModeUnicast   = "unicast"   // To registered subscribers
+	ModeBroadcast = "broadcast" // To 255.255.255.255 on the server port
+	ModeMulticast = "multicast" // To DeliveryConfig.MulticastGroup
+)
+
+// Control packet types
+const (
+	PacketSubscribe    = "subscribe"    // Client -> server
+	PacketHeartbeat    = "heartbeat"    // Client -> server
+	PacketUnsubscribe  = "unsubscribe"  // Client -> server
+	PacketSubscribed   = "subscribed"   // Server -> client, also answers heartbeats
+	PacketUnsubscribed = "unsubscribed" // Server -> client
+	PacketError        = "error"        // Server -> client
+)
+
+// ControlPacket is a subscription request or the server's answer
+type ControlPacket struct {
+	Type      string   `json:"type"`
+	ClientID  string   `json:"client_id,omitempty"`  // For logs
+	MangaIDs  []string `json:"manga_ids,omitempty"`  // Only notifications for these manga
+	Topics    []string `json:"topics,omitempty"`     // Only these notification types
+	Reliable  bool     `json:"reliable,omitempty"`   // Opt into sequenced delivery (unicast mode)
+	Seqs      []uint64 `json:"seqs,omitempty"`       // Sequence numbers of a nack or lost packet
+	NextSeq   uint64   `json:"next_seq,omitempty"`   // Sequence number of the next notification, for reliable subscribers
+	Mode      string   `json:"mode,omitempty"`       // Server delivery mode
+	Group     string   `json:"group,omitempty"`      // Multicast group to join, in multicast mode
+	ExpiresIn int      `json:"expires_in,omitempty"` // Seconds until a heartbeat is due
+	Error     string   `json:"error,omitempty"`
+}
+
+// DeliveryConfig selects how notifications reach clients
+type DeliveryConfig struct {
+	Mode           string        // ModeUnicast (default), ModeBroadcast or ModeMulticast
+	MulticastGroup string        // host:port of the group, e.g. "239.255.77.77:9091"
+	SubscriberTTL  time.Duration // A subscriber without a heartbeat for this long is dropped
+	MaxSubscribers int           // Further subscribe packets are refused
+}
+
+// subscriber is one registered client address
+type subscriber struct {
+	addr     *net.UDPAddr
+	clientID string
+	mangaIDs map[string]bool
+	topics   map[string]bool
+	lastSeen time.Time
+
+	// Reliable delivery state, guarded by subscribers.mu
+	reliable bool
+	nextSeq  uint64         // Last sequence number assigned
+	history  []historyEntry // Ring of recent notifications, indexed by seq
+}
+
+// matches reports whether the subscriber wants a notification. System
+// notifications reach everyone; otherwise each filter that is set must match.
+func (sub *subscriber) matches(notification Notification) bool {
+	if notification.Type == "system" {
+		return true
+	}
+	if len(sub.topics) > 0 && !sub.topics[notification.Type] {
+		return false
+	}
+	if len(sub.mangaIDs) > 0 && (notification.MangaID == nil || !sub.mangaIDs[*notification.MangaID]) {
+		return false
+	}
+	return true
+}
+
+// subscribers tracks unicast subscribers by address
+type subscribers struct {
+	mu   sync.RWMutex
+	ttl  time.Duration
+	max  int
+	subs map[string]*subscriber
+}
+
+func newSubscribers(ttl time.Duration, max int) *subscribers {
+	return &subscribers{ttl: ttl, max: max, subs: make(map[string]*subscriber)}
+}
+
+// subscribe adds the subscription for an address, or updates its filters.
+// An existing subscriber keeps its sequence numbers, so a repeated subscribe
+// does not look like a restart to a reliable client.
+func (r *subscribers) subscribe(addr *net.UDPAddr, packet ControlPacket) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := addr.String()
+	sub, exists := r.subs[key]
+	if !exists {
+		if len(r.subs) >= r.max {
+			return fmt.Errorf("subscriber limit reached")
+		}
+		sub = &subscriber{addr: addr}
+		r.subs[key] = sub
+	}
+
+	sub.clientID = packet.ClientID
+	sub.mangaIDs = toSet(packet.MangaIDs)
+	sub.topics = toSet(packet.Topics)
+	sub.reliable = packet.Reliable
+	sub.lastSeen = time.Now()
+	return nil
+}
+
+// heartbeat refreshes a subscription; false if there is none to refresh
+func (r *subscribers) heartbeat(addr *net.UDPAddr) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sub, ok := r.subs[addr.String()]
+	if !ok {
+		return false
+	}
+	sub.lastSeen = time.Now()
+	return true
+}
+
+func (r *subscribers) unsubscribe(addr *net.UDPAddr) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subs, addr.String())
+}
+
+// matching returns the subscribers that want a notification
+func (r *subscribers) matching(notification Notification) []*subscriber {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var subs []*subscriber
+	for _, sub := range r.subs {
+		if sub.matches(notification) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// isReliable reports whether a subscriber opted into sequenced delivery
+func (r *subscribers) isReliable(sub *subscriber) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sub.reliable
+}
+
+// expire drops subscribers whose last heartbeat is older than the TTL
+func (r *subscribers) expire() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := time.Now().Add(-r.ttl)
+	removed := 0
+	for key, sub := range r.subs {
+		if sub.lastSeen.Before(cutoff) {
+			delete(r.subs, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (r *subscribers) count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.subs)
+}
+
+func toSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// readLoop answers subscription packets until the server stops
+func (s *Server) readLoop() {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, addr, err := s.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-s.stop:
+				return
+			default:
+			}
+			fmt.Printf("❌ UDP read error: %v\n", err)
+			continue
+		}
+
+		s.stats.mu.Lock()
+		s.stats.packetsReceived++
+		s.stats.mu.Unlock()
+
+		var packet ControlPacket
+		if err := json.Unmarshal(buf[:n], &packet); err != nil {
+			s.reply(addr, ControlPacket{Type: PacketError, Error: "invalid packet"})
+			continue
+		}
+		s.handleControl(addr, packet)
+	}
+}
+
+// handleControl applies one subscription packet and answers it
+func (s *Server) handleControl(addr *net.UDPAddr, packet ControlPacket) {
+	switch packet.Type {
+	case PacketSubscribe:
+		if err := s.subs.subscribe(addr, packet); err != nil {
+			s.reply(addr, ControlPacket{Type: PacketError, Error: err.Error()})
+			return
+		}
+		fmt.Printf("📥 UDP subscriber %s (%s) registered\n", addr, packet.ClientID)
+		s.reply(addr, s.subscribed(addr))
+
+	case PacketHeartbeat:
+		if !s.subs.heartbeat(addr) {
+			s.reply(addr, ControlPacket{Type: PacketUnsubscribed})
+			return
+		}
+		s.reply(addr, s.subscribed(addr))
+
+	case PacketNack:
+		s.handleNack(addr, packet)
+
+	case PacketUnsubscribe:
+		s.subs.unsubscribe(addr)
+		s.reply(addr, ControlPacket{Type: PacketUnsubscribed})
+
+	default:
+		s.reply(addr, ControlPacket{Type: PacketError, Error: fmt.Sprintf("unknown packet type %q", packet.Type)})
+	}
+}
+
+// subscribed is the answer to a subscribe or heartbeat packet. NextSeq lets
+// a reliable client notice that the last notifications were lost.
+func (s *Server) subscribed(addr *net.UDPAddr) ControlPacket {
+	packet := ControlPacket{
+		Type:      PacketSubscribed,
+		Mode:      s.delivery.Mode,
+		ExpiresIn: int(s.delivery.SubscriberTTL / time.Second),
+		NextSeq:   s.subs.nextSeq(addr),
+	}
+	if s.delivery.Mode == ModeMulticast {
+		packet.Group = s.delivery.MulticastGroup
+	}
+	return packet
+}
+
+func (s *Server) reply(addr *net.UDPAddr, packet ControlPacket) {
+	data, err := json.Marshal(packet)
+	if err != nil {
+		return
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, err := s.conn.WriteToUDP(data, addr); err != nil {
+		fmt.Printf("_UDP reply error (ignored): %v\n", err)
+	}
+}
+
+// expireLoop drops subscribers that stopped sending heartbeats
+func (s *Server) expireLoop() {
+	ticker := time.NewTicker(s.delivery.SubscriberTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if removed := s.subs.expire(); removed > 0 {
+				fmt.Printf("🧹 UDP: expired %d subscribers\n", removed)
+			}
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"mangahub/internal/repository"
+	"mangahub/pkg/models"
+)
+
+// Backplane
+//
+// With several server instances behind a load balancer, each hub only holds
+// the clients connected to it. When the hub has a ChatBackplane, chat
+// messages and join/leave events are published as envelopes and every
+// instance delivers them to its local room. Each instance also publishes a
+// presence snapshot of its rooms periodically and when asked ("sync"), so a
+// room's presence lists users on all instances and forgets instances that
+// stopped reporting. Without a backplane the hub works as a single instance.
+
+const (
+	backplaneRetryDelay  = 5 * time.Second  // Before subscribing again after a failure
+	presenceSyncInterval = 30 * time.Second // Between presence snapshots
+	presenceExpiry       = 3 * presenceSyncInterval
+	presenceChunkSize    = 50 // Users per snapshot envelope
+)
+
+// Envelope kinds
+const (
+	envelopeMessage  = "message"  // A chat, join or leave Message for a room
+	envelopePresence = "presence" // Part of an instance's presence snapshot for a room
+	envelopeSync     = "sync"     // Asks every instance to publish its snapshots
+)
+
+// envelope is one backplane event
+type envelope struct {
+	Node      string         `json:"node"` // Publishing instance
+	Kind      string         `json:"kind"`
+	MangaID   string         `json:"manga_id,omitempty"`
+	Message   *Message       `json:"message,omitempty"`
+	MessageID string         `json:"message_id,omitempty"` // Set when Content was left out to fit the backplane
+	Users     []presenceUser `json:"users,omitempty"`
+	Reset     bool           `json:"reset,omitempty"` // First part of a snapshot: replaces the instance's users
+}
+
+// presenceUser is a user connected to a room on one instance
+type presenceUser struct {
+	UserID     string    `json:"user_id"`
+	Username   string    `json:"username"`
+	Conns      int       `json:"conns"`
+	LastActive time.Time `json:"last_active"`
+}
+
+// remoteRoom is what one other instance reported for a room
+type remoteRoom struct {
+	users map[string]*presenceUser
+	seen  time.Time
+}
+
+// runBackplane delivers envelopes from other instances until the hub stops,
+// subscribing again after failures
+func (h *Hub) runBackplane() {
+	defer h.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-h.stop
+		cancel()
+	}()
+
+	h.wg.Add(1)
+	go h.presenceLoop(ctx)
+
+	for {
+		err := h.backplane.Subscribe(ctx, h.backplaneReady, h.handleEnvelope)
+		if err != nil {
+			logrus.Warnf("Chat backplane failed, retrying in %s: %v", backplaneRetryDelay, err)
+		}
+		select {
+		case <-h.stop:
+			return
+		case <-time.After(backplaneRetryDelay):
+		}
+	}
+}
+
+// backplaneReady announces this instance after (re)subscribing: others may
+// have expired it while it was away, and it may have missed their snapshots
+func (h *Hub) backplaneReady() {
+	h.publishPresence()
+	h.publishAsync(&envelope{Kind: envelopeSync})
+}
+
+// presenceLoop publishes snapshots and forgets silent instances
+func (h *Hub) presenceLoop(ctx context.Context) {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(presenceSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.publishPresence()
+			h.expireRemote()
+		}
+	}
+}
+
+// publish sends an envelope and waits for the result
+func (h *Hub) publish(ctx context.Context, env *envelope) error {
+	env.Node = h.node
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return h.backplane.Publish(ctx, data)
+}
+
+// publishAsync sends an envelope without blocking the caller, logging failures
+func (h *Hub) publishAsync(env *envelope) {
+	if h.backplane == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := h.publish(ctx, env); err != nil {
+			logrus.Warnf("Chat backplane publish failed: %v", err)
+		}
+	}()
+}
+
+// publishMessage sends a room message to the other instances. A message too
+// large for the backplane goes without its content, which receivers load.
+func (h *Hub) publishMessage(ctx context.Context, msg *Message, messageID string) error {
+	err := h.publish(ctx, &envelope{Kind: envelopeMessage, MangaID: msg.MangaID, Message: msg})
+	if !errors.Is(err, repository.ErrPayloadTooLarge) || messageID == "" {
+		return err
+	}
+
+	ref := *msg
+	ref.Content = ""
+	return h.publish(ctx, &envelope{Kind: envelopeMessage, MangaID: msg.MangaID, Message: &ref, MessageID: messageID})
+}
+
+// handleEnvelope applies an envelope published by another instance
+func (h *Hub) handleEnvelope(payload []byte) {
+	var env envelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		logrus.Warnf("Invalid chat backplane payload: %v", err)
+		return
+	}
+	if env.Node == h.node {
+		return // Already applied locally
+	}
+
+	switch env.Kind {
+	case envelopeMessage:
+		if env.Message == nil {
+			return
+		}
+		switch env.Message.Type {
+		case "join":
+			h.remoteJoin(env.Node, env.MangaID, env.Message)
+		case "leave":
+			h.remoteLeave(env.Node, env.MangaID, env.Message)
+		}
+		if env.MessageID != "" && !h.loadContent(env.Message, env.MessageID) {
+			return
+		}
+		h.deliverLocal(env.MangaID, env.Message)
+		if env.Message.Type == "join" || env.Message.Type == "leave" {
+			h.deliverLocal(env.MangaID, h.presenceMessage(env.MangaID))
+		}
+
+	case envelopePresence:
+		h.remoteSnapshot(env.Node, env.MangaID, env.Users, env.Reset)
+
+	case envelopeSync:
+		h.publishPresence()
+	}
+}
+
+// loadContent fills in the content of a message published by reference
+func (h *Hub) loadContent(msg *Message, messageID string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stored, err := h.chatRepo.GetByID(ctx, messageID)
+	if err != nil {
+		logrus.Warnf("Failed to load chat message %s from backplane: %v", messageID, err)
+		return false
+	}
+	msg.Content = stored.Content
+	return true
+}
+
+// deliverLocal broadcasts a message to the room's clients on this instance
+func (h *Hub) deliverLocal(mangaID string, msg *Message) {
+	h.roomsMu.RLock()
+	room, exists := h.rooms[mangaID]
+	h.roomsMu.RUnlock()
+	if !exists {
+		return
+	}
+
+	select {
+	case room.broadcast <- msg:
+	case <-room.stop:
+	case <-time.After(writeWait):
+		logrus.Warnf("Room %s busy, dropping backplane message", mangaID)
+	}
+}
+
+// publishPresence sends a snapshot of every local room with clients
+func (h *Hub) publishPresence() {
+	if h.backplane == nil {
+		return
+	}
+
+	h.roomsMu.RLock()
+	snapshots := make(map[string][]presenceUser, len(h.rooms))
+	for mangaID, room := range h.rooms {
+		if users := room.presenceUsers(); len(users) > 0 {
+			snapshots[mangaID] = users
+		}
+	}
+	h.roomsMu.RUnlock()
+
+	if len(snapshots) == 0 {
+		return
+	}
+
+	// One goroutine, so the parts of a snapshot arrive in order
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		for mangaID, users := range snapshots {
+			for start := 0; start < len(users); start += presenceChunkSize {
+				end := start + presenceChunkSize
+				if end > len(users) {
+					end = len(users)
+				}
+				err := h.publish(ctx, &envelope{
+					Kind:    envelopePresence,
+					MangaID: mangaID,
+					Users:   users[start:end],
+					Reset:   start == 0,
+				})
+				if err != nil {
+					logrus.Warnf("Chat backplane presence publish failed: %v", err)
+					return
+				}
+			}
+		}
+	}()
+}
+
+// presenceUsers lists the room's users, counting each user's connections
+func (r *Room) presenceUsers() []presenceUser {
+	r.clientsMu.RLock()
+	defer r.clientsMu.RUnlock()
+
+	byUser := make(map[string]*presenceUser)
+	var users []presenceUser
+	for client := range r.clients {
+		lastActive := client.lastActiveAt()
+		if u, ok := byUser[client.userID]; ok {
+			u.Conns++
+			if lastActive.After(u.LastActive) {
+				u.LastActive = lastActive
+			}
+			continue
+		}
+		byUser[client.userID] = &presenceUser{
+			UserID:     client.userID,
+			Username:   client.username,
+			Conns:      1,
+			LastActive: lastActive,
+		}
+	}
+	for _, u := range byUser {
+		users = append(users, *u)
+	}
+	return users
+}
+
+// remoteRoomLocked returns an instance's entry for a room, creating it.
+// The caller holds remoteMu.
+func (h *Hub) remoteRoomLocked(node, mangaID string) *remoteRoom {
+	nodes, ok := h.remote[mangaID]
+	if !ok {
+		nodes = make(map[string]*remoteRoom)
+		h.remote[mangaID] = nodes
+	}
+	rr, ok := nodes[node]
+	if !ok {
+		rr = &remoteRoom{users: make(map[string]*presenceUser)}
+		nodes[node] = rr
+	}
+	rr.seen = time.Now()
+	return rr
+}
+
+func (h *Hub) remoteJoin(node, mangaID string, msg *Message) {
+	h.remoteMu.Lock()
+	defer h.remoteMu.Unlock()
+
+	rr := h.remoteRoomLocked(node, mangaID)
+	u, ok := rr.users[msg.UserID]
+	if !ok {
+		u = &presenceUser{UserID: msg.UserID, Username: msg.Username}
+		rr.users[msg.UserID] = u
+	}
+	u.Conns++
+	u.LastActive = msg.Timestamp
+}
+
+func (h *Hub) remoteLeave(node, mangaID string, msg *Message) {
+	h.remoteMu.Lock()
+	defer h.remoteMu.Unlock()
+
+	rr := h.remoteRoomLocked(node, mangaID)
+	if u, ok := rr.users[msg.UserID]; ok {
+		u.Conns--
+		if u.Conns <= 0 {
+			delete(rr.users, msg.UserID)
+		}
+	}
+}
+
+func (h *Hub) remoteSnapshot(node, mangaID string, users []presenceUser, reset bool) {
+	h.remoteMu.Lock()
+	defer h.remoteMu.Unlock()
+
+	rr := h.remoteRoomLocked(node, mangaID)
+	if reset {
+		rr.users = make(map[string]*presenceUser, len(users))
+	}
+	for i := range users {
+		u := users[i]
+		rr.users[u.UserID] = &u
+	}
+}
+
+// expireRemote forgets instances that stopped reporting a room
+func (h *Hub) expireRemote() {
+	h.remoteMu.Lock()
+	defer h.remoteMu.Unlock()
+
+	cutoff := time.Now().Add(-presenceExpiry)
+	for mangaID, nodes := range h.remote {
+		for node, rr := range nodes {
+			if rr.seen.Before(cutoff) || len(rr.users) == 0 {
+				delete(nodes, node)
+			}
+		}
+		if len(nodes) == 0 {
+			delete(h.remote, mangaID)
+		}
+	}
+}
+
+// remotePresence returns the users other instances report for a room
+func (h *Hub) remotePresence(mangaID string) []*models.UserPresence {
+	h.remoteMu.Lock()
+	defer h.remoteMu.Unlock()
+
+	var presence []*models.UserPresence
+	cutoff := time.Now().Add(-presenceExpiry)
+	for _, rr := range h.remote[mangaID] {
+		if rr.seen.Before(cutoff) {
+			continue
+		}
+		for _, u := range rr.users {
+			presence = append(presence, &models.UserPresence{
+				UserID:     u.UserID,
+				Username:   u.Username,
+				MangaID:    mangaID,
+				LastActive: u.LastActive,
+			})
+		}
+	}
+	return presence
+}
@@ -147,9 +147,14 @@ func (h *Handler) HandleWebSocket(c *gin.Context) {
 	h.updateMetrics(mangaID, true)
 
 	// Register client with hub and start goroutines
-	h.hub.ServeClient(conn, user.ID, user.Username, mangaID, func() {
+	err = h.hub.ServeClient(conn, user.ID, user.Username, mangaID, func() {
 		h.updateMetrics(mangaID, false)
 	})
+	if err != nil {
+		logrus.Warnf("WebSocket client rejected: manga_id=%s user_id=%s: %v",
+			mangaID, user.ID, err)
+		return
+	}
 
 	logrus.Infof("✅ WebSocket client connected: manga_id=%s user_id=%s",
 		mangaID, user.ID)
@@ -0,0 +1,115 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"mangahub/pkg/models"
+)
+
+// History
+//
+// A client receives the newest historyLimit messages as "history" messages
+// when it joins. To scroll back further it sends a "history_request" naming
+// its oldest message in Before (or a time in BeforeTime) and gets one
+// "history_page" with up to Limit older messages, oldest first, and HasMore
+// set while even older ones remain. Pages are read by keyset on
+// (created_at, id), so deep scrollback costs the same as the first page.
+
+// sendChatHistory sends recent chat messages to client
+func (h *Hub) sendChatHistory(client *Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	page, err := h.chatSvc.GetHistoryBefore(ctx, client.mangaID, models.ChatHistoryCursor{}, historyLimit)
+	if err != nil {
+		logrus.Warnf("Failed to get chat history for %s: %v", client.mangaID, err)
+		return
+	}
+
+	// Send history messages (oldest first)
+	for i := len(page.Data) - 1; i >= 0; i-- {
+		select {
+		case client.send <- historyMessage(&page.Data[i]):
+		case <-time.After(2 * time.Second):
+			// Timeout - client might be slow, stop sending history
+			return
+		}
+	}
+
+	logrus.Debugf("📨 Sent %d history messages to client %s", len(page.Data), client.userID)
+}
+
+// RequestHistory sends the client a "history_page" of messages older than
+// the message before, or than beforeTime if before is empty
+func (c *Client) RequestHistory(before string, beforeTime *time.Time, limit int) error {
+	if limit <= 0 || limit > historyLimit {
+		limit = historyLimit
+	}
+
+	cursor := models.ChatHistoryCursor{BeforeID: before}
+	if before == "" {
+		if beforeTime == nil {
+			c.sendError("missing_cursor", "History request needs before or before_time")
+			return ErrMissingID
+		}
+		cursor.Before = beforeTime
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	page, err := c.hub.chatSvc.GetHistoryBefore(ctx, c.mangaID, cursor, limit)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			c.sendError("invalid_cursor", "Message not found in this room")
+			return err
+		}
+		logrus.Warnf("Failed to get chat history for %s: %v", c.mangaID, err)
+		c.sendError("database_error", "Failed to load history")
+		return err
+	}
+
+	reply := &Message{
+		Type:      "history_page",
+		UserID:    "system",
+		Username:  "System",
+		MangaID:   c.mangaID,
+		Timestamp: time.Now(),
+		Messages:  make([]*Message, 0, len(page.Data)),
+		HasMore:   page.HasMore,
+	}
+	for i := len(page.Data) - 1; i >= 0; i-- {
+		reply.Messages = append(reply.Messages, historyMessage(&page.Data[i]))
+	}
+
+	select {
+	case c.send <- reply:
+	case <-time.After(2 * time.Second):
+		logrus.Warnf("Client %s too slow for history page", c.userID)
+	}
+	return nil
+}
+
+// historyMessage converts a stored message to a "history" message
+func historyMessage(msg *models.ChatMessageResponse) *Message {
+	historyMsg := &Message{
+		Type:      "history",
+		ID:        msg.ID,
+		UserID:    msg.User.ID,
+		Username:  msg.User.Username,
+		MangaID:   msg.MangaID,
+		Content:   msg.Content,
+		Timestamp: msg.CreatedAt,
+		EditedAt:  msg.EditedAt,
+		Reactions: msg.Reactions,
+		Mentions:  msg.Mentions,
+	}
+	if msg.ReplyTo != nil {
+		historyMsg.ReplyTo = *msg.ReplyTo
+	}
+	return historyMsg
+}
@@ -7,13 +7,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 
+	"mangahub/internal/core"
 	"mangahub/internal/repository"
-	tcpProtocol "mangahub/internal/protocols/tcp"
 	"mangahub/pkg/models"
 )
 
@@ -23,7 +25,7 @@ const (
 	writeWait         = 10 * time.Second      // Time allowed to write a message
 	pongWait          = 60 * time.Second      // Time allowed to read the next pong
 	pingPeriod        = (pongWait * 9) / 10   // Send pings to client
-	historyLimit      = 50                     // Max chat history messages to send
+	historyLimit      = 50                     // Max chat history messages to send at once
 	maxRoomSize       = 1000                   // Max clients per room
 	cleanupInterval   = 5 * time.Minute        // Room cleanup interval
 )
@@ -32,11 +34,16 @@ const (
 type Hub struct {
 	roomsMu   sync.RWMutex
 	rooms     map[string]*Room // manga_id -> Room
+	chatSvc   core.ChatService // Saves messages; ChatMessageSent brings them back through DeliverOutbox
 	chatRepo  repository.ChatRepository
-	activityRepo repository.ActivityRepository
-	statsAddr string // TCP Stats Service address
 	stop      chan struct{}
 	wg        sync.WaitGroup
+
+	// Multi-instance fan-out; see backplane.go
+	backplane repository.ChatBackplane // nil = single instance
+	node      string                   // This instance's ID on the backplane
+	remoteMu  sync.Mutex
+	remote    map[string]map[string]*remoteRoom // manga_id -> node -> users
 }
 
 // Room represents a chat room for a specific manga
@@ -51,50 +58,79 @@ type Room struct {
 	stop       chan struct{}
 }
 
-// Client represents a WebSocket client connection
+// Client represents a chat participant connected over any Transport
 type Client struct {
 	hub     *Hub
 	room    *Room
-	conn    *websocket.Conn
+	transport Transport
 	send    chan *Message
 	userID  string
 	username string
 	mangaID string
-	lastActive time.Time
+	lastActive atomic.Int64 // Unix nanos; see touch
 	onDisconnect func()
+	admitted chan error   // The room's answer to registration
+	done    chan struct{} // closed when the write loop exits
+
+	typingMu   sync.Mutex
+	typing     bool      // A typing_start was relayed and not yet stopped
+	typingSent time.Time // When the last typing_start was relayed
 }
 
-// Message represents a chat message (schema-aligned)
+// Message represents a chat message (schema-aligned). Server types: "message",
+// "join", "leave", "history", "presence", "typing_start", "typing_stop",
+// "message_edited", "message_deleted", "reaction", "history_page" and "error".
+// Clients send a "message" or typing events, "edit", "delete" or "react" with
+// ID set, and "history_request" (see history.go).
 type Message struct {
-	Type      string    `json:"type"`      // "message", "join", "leave", "history"
+	Type      string    `json:"type"`
+	ID        string    `json:"id,omitempty"` // Chat message ID
 	UserID    string    `json:"user_id"`
 	Username  string    `json:"username"`
 	MangaID   string    `json:"manga_id"`
 	Content   string    `json:"content"`
 	Timestamp time.Time `json:"timestamp"`
+	Users     []*models.UserPresence `json:"users,omitempty"` // Room members, for "presence"
+	EditedAt  *time.Time             `json:"edited_at,omitempty"`
+	Emoji     string                 `json:"emoji,omitempty"`     // For "react" and "reaction"
+	Added     bool                   `json:"added,omitempty"`     // "reaction": added rather than removed
+	Reactions map[string]int         `json:"reactions,omitempty"` // emoji -> count, after a "reaction"
+	ReplyTo   string                 `json:"reply_to,omitempty"`  // ID of the quoted message
+	Mentions  []string               `json:"mentions,omitempty"`  // Mentioned user IDs
+
+	// History paging: the cursor of a "history_request" and the "history_page" reply
+	Before     string     `json:"before,omitempty"`      // Message ID
+	BeforeTime *time.Time `json:"before_time,omitempty"` // Used when Before is empty
+	Limit      int        `json:"limit,omitempty"`
+	Messages   []*Message `json:"messages,omitempty"` // Oldest first
+	HasMore    bool       `json:"has_more,omitempty"`
 }
 
-// NewHub creates a new chat hub with dependencies
-func NewHub(chatRepo repository.ChatRepository, activityRepo repository.ActivityRepository) *Hub {
+// NewHub creates a new chat hub with dependencies. With a backplane, rooms
+// are shared with the other instances subscribed to it.
+func NewHub(chatSvc core.ChatService, chatRepo repository.ChatRepository, backplane repository.ChatBackplane) *Hub {
 	hub := &Hub{
 		rooms:      make(map[string]*Room),
+		chatSvc:    chatSvc,
 		chatRepo:   chatRepo,
-		activityRepo: activityRepo,
 		stop:       make(chan struct{}),
+		backplane:  backplane,
+		node:       uuid.New().String(),
+		remote:     make(map[string]map[string]*remoteRoom),
 	}
 	
 	// Start room cleanup routine
 	hub.wg.Add(1)
 	go hub.cleanupRooms()
+
+	if backplane != nil {
+		hub.wg.Add(1)
+		go hub.runBackplane()
+	}
 	
 	return hub
 }
 
-// SetStatsAddr sets the TCP Stats Service address
-func (h *Hub) SetStatsAddr(addr string) {
-	h.statsAddr = addr
-}
-
 // cleanupRooms periodically removes empty rooms
 func (h *Hub) cleanupRooms() {
 	defer h.wg.Done()
@@ -171,6 +207,7 @@ func (r *Room) run() {
 // handleRegister processes client registration
 func (r *Room) handleRegister(client *Client) {
 	if r.stopped {
+		client.admitted <- ErrRoomClosed
 		return
 	}
 	
@@ -178,11 +215,13 @@ func (r *Room) handleRegister(client *Client) {
 	if len(r.clients) >= maxRoomSize {
 		r.clientsMu.Unlock()
 		logrus.Warnf("Room %s full, rejecting client %s", r.mangaID, client.userID)
+		client.admitted <- ErrRoomFull
 		return
 	}
 	
 	r.clients[client] = true
 	r.clientsMu.Unlock()
+	client.admitted <- nil
 
 	logrus.Debugf("✅ Client %s joined room %s", client.userID, r.mangaID)
 
@@ -195,6 +234,8 @@ func (r *Room) handleRegister(client *Client) {
 		Timestamp: time.Now(),
 	}
 	r.broadcastToAll(joinMsg)
+	client.hub.publishAsync(&envelope{Kind: envelopeMessage, MangaID: r.mangaID, Message: joinMsg})
+	r.broadcastToAll(client.hub.presenceMessage(r.mangaID))
 }
 
 // handleUnregister processes client unregistration
@@ -221,6 +262,8 @@ func (r *Room) handleUnregister(client *Client) {
 		Timestamp: time.Now(),
 	}
 	r.broadcastToAll(leaveMsg)
+	client.hub.publishAsync(&envelope{Kind: envelopeMessage, MangaID: r.mangaID, Message: leaveMsg})
+	r.broadcastToAll(client.hub.presenceMessage(r.mangaID))
 }
 
 // handleBroadcast processes message broadcast
@@ -238,7 +281,7 @@ func (r *Room) handleStop() {
 	r.clientsMu.Lock()
 	for client := range r.clients {
 		close(client.send)
-		client.conn.Close()
+		client.transport.Close()
 	}
 	r.clients = nil
 	r.clientsMu.Unlock()
@@ -246,74 +289,125 @@ func (r *Room) handleStop() {
 	logrus.Infof("🛑 Room stopped: %s", r.mangaID)
 }
 
-// broadcastToAll sends message to all clients in room
+// broadcastToAll sends message to all clients in room. Clients whose send
+// buffer is full are removed on the spot: this runs on the room goroutine, so
+// going through r.unregister would block it on itself.
 func (r *Room) broadcastToAll(message *Message) {
-	r.clientsMu.RLock()
-	defer r.clientsMu.RUnlock()
+	var slow []*Client
 
+	r.clientsMu.RLock()
 	for client := range r.clients {
 		select {
 		case client.send <- message:
 		default:
-			// Client send buffer full, remove client
-			logrus.Warnf("Client %s send buffer full, disconnecting", client.userID)
-			r.unregister <- client
+			slow = append(slow, client)
 		}
 	}
-}
+	r.clientsMu.RUnlock()
 
-// logChatActivity logs chat message activity and emits TCP stats event
-func (h *Hub) logChatActivity(mangaID, userID string, eventTime time.Time, weight int) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	if len(slow) == 0 {
+		return
+	}
 
-	activity := &models.Activity{
-		ID:        generateActivityID(),
-		Type:      models.ActivityTypeChat,
-		UserID:    &userID,
-		MangaID:   &mangaID,
-		CreatedAt: eventTime,
+	// Closing send ends the write loop, which closes the transport; the read
+	// side then detaches and the room announces the leave
+	r.clientsMu.Lock()
+	for _, client := range slow {
+		if _, ok := r.clients[client]; ok {
+			logrus.Warnf("Client %s send buffer full, disconnecting", client.userID)
+			delete(r.clients, client)
+			close(client.send)
+		}
 	}
+	r.clientsMu.Unlock()
+}
 
-	if err := h.activityRepo.Create(ctx, activity); err != nil {
-		logrus.Errorf("Failed to log chat activity: %v", err)
+// DeliverOutbox fans a committed chat message, edit, delete or reaction out
+// to its room on this instance and, through the backplane, on every other
+// instance. The relay
+// delivers each event to one instance only. If publishing fails the event is
+// retried before anyone sees it; once published it is never retried, so no
+// instance gets it twice.
+func (h *Hub) DeliverOutbox(ctx context.Context, event *models.OutboxEvent) error {
+	var payload models.OutboxChatPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid chat payload: %w", err)
 	}
 
-	if h.statsAddr != "" {
-		event := tcpProtocol.StatsEvent{
-			Type:      tcpProtocol.EventTypeChat,
-			MangaID:   mangaID,
-			UserID:    &userID,
-			EventTime: eventTime,
-			Weight:    weight,
-			Source:    "websocket",
+	msg := &Message{
+		Type:      "message",
+		ID:        payload.MessageID,
+		UserID:    payload.UserID,
+		Username:  payload.Username,
+		MangaID:   payload.MangaID,
+		Content:   payload.Content,
+		Timestamp: payload.CreatedAt,
+		EditedAt:  payload.EditedAt,
+		Mentions:  payload.Mentions,
+	}
+	if payload.ReplyTo != nil {
+		msg.ReplyTo = *payload.ReplyTo
+	}
+	// Only messages with content can be published by reference
+	refID := payload.MessageID
+	if payload.Event != "" {
+		msg.Type = payload.Event
+		if payload.Event != models.ChatEventEdited {
+			refID = ""
 		}
-		if err := tcpProtocol.SendStatsEvent(h.statsAddr, event); err != nil {
-			logrus.Errorf("Failed to emit TCP stats event: %v", err)
+	}
+	if payload.Event == models.ChatEventReaction {
+		msg.Emoji = payload.Emoji
+		msg.Added = payload.Added
+		msg.Reactions = payload.Reactions
+	}
+
+	if h.backplane != nil {
+		if err := h.publishMessage(ctx, msg, refID); err != nil {
+			return fmt.Errorf("publish chat message: %w", err)
 		}
+		// A busy local room drops it like any backplane message
+		h.deliverLocal(payload.MangaID, msg)
+		return nil
+	}
+
+	// Rooms with no local clients have nobody to deliver to
+	h.roomsMu.RLock()
+	room, exists := h.rooms[payload.MangaID]
+	h.roomsMu.RUnlock()
+	if !exists {
+		return nil
+	}
+
+	select {
+	case room.broadcast <- msg:
+		return nil
+	case <-room.stop:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("room %s busy: %w", payload.MangaID, ctx.Err())
 	}
 }
 
 // readPump reads messages from WebSocket connection
-func (c *Client) readPump() {
+func (c *Client) readPump(conn *websocket.Conn) {
 	defer func() {
 		if c.onDisconnect != nil {
 			c.onDisconnect()
 		}
-		c.room.unregister <- c
-		c.conn.Close()
+		c.Detach()
 	}()
 
-	c.conn.SetReadLimit(maxMessageSize)
-	c.conn.SetReadDeadline(time.Now().Add(pongWait))
-	c.conn.SetPongHandler(func(string) error {
-		c.conn.SetReadDeadline(time.Now().Add(pongWait))
-		c.lastActive = time.Now()
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		c.touch()
 		return nil
 	})
 
 	for {
-		_, messageData, err := c.conn.ReadMessage()
+		_, messageData, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				logrus.Warnf("WebSocket read error: %v", err)
@@ -335,77 +429,48 @@ func (c *Client) readPump() {
 			continue
 		}
 
-		// Validate content
-		if len(msg.Content) > 5000 { // Reasonable limit for chat messages
-			c.sendError("content_too_long", "Message content too long")
-			continue
-		}
-
-		// Set metadata
-		msg.UserID = c.userID
-		msg.Username = c.username
-		msg.MangaID = c.mangaID
-		msg.Timestamp = time.Now()
-		msg.Type = "message"
-
-		// Save message to database first (atomic operation)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		
-		chatMsg := &models.ChatMessage{
-			ID:        generateMessageID(),
-			MangaID:   c.mangaID,
-			UserID:    c.userID,
-			Content:   msg.Content,
-			CreatedAt: msg.Timestamp,
-		}
-		
-		if _, err := c.hub.chatRepo.Create(ctx, chatMsg); err != nil {
-			cancel()
-			logrus.Errorf("Failed to save chat message: %v", err)
-			c.sendError("database_error", "Failed to save message")
-			continue
+		switch msg.Type {
+		case "typing_start":
+			c.Typing(true)
+		case "typing_stop":
+			c.Typing(false)
+		case "edit":
+			_ = c.Edit(msg.ID, msg.Content)
+		case "delete":
+			_ = c.Delete(msg.ID)
+		case "react":
+			_ = c.React(msg.ID, msg.Emoji)
+		case "history_request":
+			_ = c.RequestHistory(msg.Before, msg.BeforeTime, msg.Limit)
+		default:
+			// Rejections are reported back to the client by Submit
+			_ = c.submit(msg.Content, msg.ReplyTo)
 		}
-		cancel()
-
-		// Log activity + emit TCP stats
-		c.hub.logChatActivity(c.mangaID, c.userID, msg.Timestamp, 2)
-
-		// Broadcast to room
-		c.room.broadcast <- &msg
 	}
 }
 
-// writePump writes messages to WebSocket connection
+// writePump writes queued messages to the client's transport
 func (c *Client) writePump() {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
 		ticker.Stop()
-		c.conn.Close()
+		c.transport.Close()
 	}()
 
 	for {
 		select {
 		case message, ok := <-c.send:
-			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
 				// Client was unregistered
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
 
-			data, err := json.Marshal(message)
-			if err != nil {
-				logrus.Errorf("Failed to marshal message: %v", err)
-				continue
-			}
-
-			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			if err := c.transport.WriteMessage(message); err != nil {
 				return
 			}
 
 		case <-ticker.C:
-			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			if err := c.transport.Ping(); err != nil {
 				return
 			}
 
@@ -433,96 +498,75 @@ func (c *Client) sendError(code, message string) {
 	}
 }
 
-// ServeClient handles WebSocket connection for a client
-func (h *Hub) ServeClient(conn *websocket.Conn, userID, username, mangaID string, onDisconnect func()) {
-	room := h.GetOrCreateRoom(mangaID)
-
-	client := &Client{
-		hub:     h,
-		room:    room,
-		conn:    conn,
-		send:    make(chan *Message, 256),
-		userID:  userID,
-		username: username,
-		mangaID: mangaID,
-		lastActive: time.Now(),
-		onDisconnect: onDisconnect,
+// ServeClient handles WebSocket connection for a client. If the room does not
+// admit it, the connection is closed with a "try again later" close frame.
+func (h *Hub) ServeClient(conn *websocket.Conn, userID, username, mangaID string, onDisconnect func()) error {
+	client, err := h.Attach(newWSTransport(conn), userID, username, mangaID)
+	if err != nil {
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, err.Error())
+		_ = conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(writeWait))
+		conn.Close()
+		if onDisconnect != nil {
+			onDisconnect()
+		}
+		return err
 	}
+	client.onDisconnect = onDisconnect
 
-	room.register <- client
-
-	// Start goroutines for reading and writing
-	h.wg.Add(2)
+	h.wg.Add(1)
 	go func() {
 		defer h.wg.Done()
-		client.writePump()
+		client.readPump(conn)
 	}()
-	go func() {
-		defer h.wg.Done()
-		client.readPump()
-	}()
-
-	// Send chat history to newly connected client
-	go h.sendChatHistory(client)
+	return nil
 }
 
-// sendChatHistory sends recent chat messages to client
-func (h *Hub) sendChatHistory(client *Client) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// GetRoomClientCount returns number of clients in a room, on all instances
+func (h *Hub) GetRoomClientCount(mangaID string) int {
+	count := 0
 
-	messages, _, err := h.chatRepo.ListByMangaID(ctx, client.mangaID, historyLimit, 0)
-	if err != nil {
-		logrus.Warnf("Failed to get chat history for %s: %v", client.mangaID, err)
-		return
+	h.roomsMu.RLock()
+	if room, exists := h.rooms[mangaID]; exists {
+		room.clientsMu.RLock()
+		count = len(room.clients)
+		room.clientsMu.RUnlock()
 	}
+	h.roomsMu.RUnlock()
 
-	// Send history messages (newest first)
-	for i := len(messages) - 1; i >= 0; i-- {
-		msg := messages[i]
-		
-		// Get username for the message
-		username := "Anonymous"
-		// In production, we'd join with users table or cache usernames
-		if msg.User.ID == client.userID {
-			username = client.username
-		}
-
-		historyMsg := &Message{
-			Type:      "history",
-			UserID:    msg.User.ID,
-			Username:  username,
-			MangaID:   msg.MangaID,
-			Content:   msg.Content,
-			Timestamp: msg.CreatedAt,
-		}
-
-		select {
-		case client.send <- historyMsg:
-		case <-time.After(2 * time.Second):
-			// Timeout - client might be slow, stop sending history
-			return
+	h.remoteMu.Lock()
+	for _, rr := range h.remote[mangaID] {
+		for _, u := range rr.users {
+			count += u.Conns
 		}
 	}
+	h.remoteMu.Unlock()
 
-	logrus.Debugf("📨 Sent %d history messages to client %s", len(messages), client.userID)
+	return count
 }
 
-// GetRoomClientCount returns number of clients in a room
-func (h *Hub) GetRoomClientCount(mangaID string) int {
-	h.roomsMu.RLock()
-	defer h.roomsMu.RUnlock()
+// GetRoomPresence returns current user presence for a room, including users
+// connected to other instances
+func (h *Hub) GetRoomPresence(mangaID string) ([]*models.UserPresence, error) {
+	presence := h.localPresence(mangaID)
 
-	if room, exists := h.rooms[mangaID]; exists {
-		room.clientsMu.RLock()
-		defer room.clientsMu.RUnlock()
-		return len(room.clients)
+	local := make(map[string]bool, len(presence))
+	for _, p := range presence {
+		local[p.UserID] = true
 	}
-	return 0
+	now := time.Now()
+	for _, p := range h.remotePresence(mangaID) {
+		if local[p.UserID] {
+			continue
+		}
+		p.Status = presenceStatus(p.LastActive, now)
+		presence = append(presence, p)
+	}
+
+	return presence, nil
 }
 
-// GetRoomPresence returns current user presence for a room
-func (h *Hub) GetRoomPresence(mangaID string) ([]*models.UserPresence, error) {
+// localPresence returns the users connected to this instance, once per user
+func (h *Hub) localPresence(mangaID string) []*models.UserPresence {
 	h.roomsMu.RLock()
 	defer h.roomsMu.RUnlock()
 
@@ -531,27 +575,35 @@ func (h *Hub) GetRoomPresence(mangaID string) ([]*models.UserPresence, error) {
 		defer room.clientsMu.RUnlock()
 		
 		presence := make([]*models.UserPresence, 0, len(room.clients))
+		byUser := make(map[string]*models.UserPresence, len(room.clients))
 		now := time.Now()
 		
 		for client := range room.clients {
-			status := "online"
-			if now.Sub(client.lastActive) > 5*time.Minute {
-				status = "away"
+			lastActive := client.lastActiveAt()
+			if p, ok := byUser[client.userID]; ok {
+				// Same user on several connections: most recent activity wins
+				if lastActive.After(p.LastActive) {
+					p.LastActive = lastActive
+					p.Status = presenceStatus(lastActive, now)
+				}
+				continue
 			}
 			
-			presence = append(presence, &models.UserPresence{
+			p := &models.UserPresence{
 				UserID:     client.userID,
 				Username:   client.username,
 				MangaID:    mangaID,
-				Status:     status,
-				LastActive: client.lastActive,
-			})
+				Status:     presenceStatus(lastActive, now),
+				LastActive: lastActive,
+			}
+			byUser[client.userID] = p
+			presence = append(presence, p)
 		}
 		
-		return presence, nil
+		return presence
 	}
 	
-	return []*models.UserPresence{}, nil
+	return []*models.UserPresence{}
 }
 
 // Stop gracefully shuts down the hub
@@ -569,12 +621,3 @@ func (h *Hub) Stop() {
 	h.wg.Wait()
 	logrus.Info("✅ WebSocket hub stopped")
 }
-
-// Helper functions
-func generateMessageID() string {
-	return fmt.Sprintf("msg-%d", time.Now().UnixNano())
-}
-
-func generateActivityID() string {
-	return fmt.Sprintf("act-%d", time.Now().UnixNano())
-}
\ No newline at end of file
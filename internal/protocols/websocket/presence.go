@@ -0,0 +1,106 @@
+package websocket
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Presence and typing
+//
+// Presence comes from the room's registered clients (and, with a backplane,
+// other instances' clients). After every join and leave the room receives a
+// "presence" message listing all members. Clients send "typing_start" while
+// the user types and "typing_stop" when they give up; the hub relays them to
+// the room, at most one typing_start per client per typingThrottle. Clients
+// should treat a typing_start as expired after a few seconds without another.
+
+const (
+	typingThrottle = 3 * time.Second // Min interval between relayed typing_start per client
+	awayAfter      = 5 * time.Minute // A member without activity for this long is "away"
+)
+
+// touch records client activity (a pong, message or typing event)
+func (c *Client) touch() {
+	c.lastActive.Store(time.Now().UnixNano())
+}
+
+// lastActiveAt returns when the client was last active
+func (c *Client) lastActiveAt() time.Time {
+	return time.Unix(0, c.lastActive.Load())
+}
+
+// Typing relays a typing_start or typing_stop from this client to its room.
+// Repeated starts within typingThrottle and stops without a start are dropped.
+func (c *Client) Typing(active bool) {
+	now := time.Now()
+	c.touch()
+
+	c.typingMu.Lock()
+	if active {
+		if c.typing && now.Sub(c.typingSent) < typingThrottle {
+			c.typingMu.Unlock()
+			return
+		}
+		c.typing = true
+		c.typingSent = now
+	} else {
+		if !c.typing {
+			c.typingMu.Unlock()
+			return
+		}
+		c.typing = false
+	}
+	c.typingMu.Unlock()
+
+	msgType := "typing_stop"
+	if active {
+		msgType = "typing_start"
+	}
+	msg := &Message{
+		Type:      msgType,
+		UserID:    c.userID,
+		Username:  c.username,
+		MangaID:   c.mangaID,
+		Timestamp: now,
+	}
+
+	select {
+	case c.room.broadcast <- msg:
+	case <-c.room.stop:
+		return
+	default:
+		logrus.Debugf("Room %s busy, dropping typing event from %s", c.mangaID, c.userID)
+		return
+	}
+	c.hub.publishAsync(&envelope{Kind: envelopeMessage, MangaID: c.mangaID, Message: msg})
+}
+
+// resetTyping forgets a typing_start once the client sends its message;
+// receivers clear the indicator when the message arrives
+func (c *Client) resetTyping() {
+	c.typingMu.Lock()
+	c.typing = false
+	c.typingMu.Unlock()
+}
+
+// presenceMessage lists every member of a room
+func (h *Hub) presenceMessage(mangaID string) *Message {
+	users, _ := h.GetRoomPresence(mangaID)
+	return &Message{
+		Type:      "presence",
+		UserID:    "system",
+		Username:  "System",
+		MangaID:   mangaID,
+		Users:     users,
+		Timestamp: time.Now(),
+	}
+}
+
+// presenceStatus classifies a member by their last activity
+func presenceStatus(lastActive, now time.Time) string {
+	if now.Sub(lastActive) > awayAfter {
+		return "away"
+	}
+	return "online"
+}
@@ -0,0 +1,238 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"mangahub/pkg/models"
+)
+
+// Transport delivers room messages to one client, independent of the wire
+// protocol. WriteMessage and Ping are only called from the client's write loop;
+// Close may be called from any goroutine and must be idempotent.
+type Transport interface {
+	WriteMessage(msg *Message) error
+	Ping() error
+	Close() error
+}
+
+// Errors returned by Hub.Attach and by Client.Submit, Edit, Delete and React
+var (
+	ErrRoomFull        = errors.New("chat room is full")
+	ErrRoomClosed      = errors.New("chat room is closed")
+	ErrEmptyMessage    = errors.New("message content is empty")
+	ErrMessageTooLong  = errors.New("message content too long")
+	ErrMessageNotSaved = errors.New("failed to save message")
+	ErrMissingID       = errors.New("message id is required")
+)
+
+// wsTransport adapts a gorilla WebSocket connection to Transport
+type wsTransport struct {
+	conn      *websocket.Conn
+	closeOnce sync.Once
+}
+
+func newWSTransport(conn *websocket.Conn) *wsTransport {
+	return &wsTransport{conn: conn}
+}
+
+// WriteMessage sends a message as a JSON text frame
+func (t *wsTransport) WriteMessage(msg *Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		logrus.Errorf("Failed to marshal message: %v", err)
+		return nil
+	}
+
+	t.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return t.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Ping sends a WebSocket ping frame
+func (t *wsTransport) Ping() error {
+	t.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return t.conn.WriteMessage(websocket.PingMessage, nil)
+}
+
+// Close sends a close frame and closes the connection. WriteControl and Close
+// are safe to call concurrently with the write loop.
+func (t *wsTransport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		_ = t.conn.WriteControl(websocket.CloseMessage, []byte{}, time.Now().Add(writeWait))
+		err = t.conn.Close()
+	})
+	return err
+}
+
+// Attach registers a client in the manga's room over any transport, starts its
+// write loop and replays recent history. The caller owns the read side: it
+// feeds incoming content to Submit and calls Detach when the peer goes away.
+// A full room returns ErrRoomFull; the transport is left to the caller.
+func (h *Hub) Attach(transport Transport, userID, username, mangaID string) (*Client, error) {
+	room := h.GetOrCreateRoom(mangaID)
+
+	client := &Client{
+		hub:        h,
+		room:       room,
+		transport:  transport,
+		send:       make(chan *Message, 256),
+		userID:     userID,
+		username:   username,
+		mangaID:    mangaID,
+		admitted:   make(chan error, 1),
+		done:       make(chan struct{}),
+	}
+	client.touch()
+
+	select {
+	case room.register <- client:
+	case <-room.stop:
+		return nil, ErrRoomClosed
+	}
+	if err := <-client.admitted; err != nil {
+		return nil, err
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		defer close(client.done)
+		client.writePump()
+	}()
+
+	// Send chat history to newly connected client
+	go h.sendChatHistory(client)
+
+	return client, nil
+}
+
+// Submit validates and persists a chat message from this client. The chat
+// room subscriber enqueues it and the outbox relay broadcasts it to the room
+// (see Hub.DeliverOutbox).
+// Rejections are also reported back to the client as an "error" message.
+func (c *Client) Submit(content string) error {
+	return c.submit(content, "")
+}
+
+// submit is Submit for a message that may reply to another (replyTo)
+func (c *Client) submit(content, replyTo string) error {
+	if content == "" {
+		return ErrEmptyMessage
+	}
+	if len(content) > models.MaxChatMessageLength {
+		c.sendError("content_too_long", "Message content too long")
+		return ErrMessageTooLong
+	}
+
+	c.touch()
+	c.resetTyping()
+
+	// Save message to database (atomic with its activity row)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := models.SendChatMessageRequest{MangaID: c.mangaID, Content: content, ReplyTo: replyTo}
+	if _, err := c.hub.chatSvc.SendMessage(ctx, c.mangaID, c.userID, req); err != nil {
+		if replyTo != "" && errors.Is(err, models.ErrNotFound) {
+			c.sendError("invalid_reply", "Replied-to message not found in this room")
+			return err
+		}
+		logrus.Errorf("Failed to save chat message: %v", err)
+		c.sendError("database_error", "Failed to save message")
+		return ErrMessageNotSaved
+	}
+
+	return nil
+}
+
+// Edit replaces the content of one of this client's messages. Like Submit,
+// the change reaches the room through the outbox as "message_edited".
+func (c *Client) Edit(messageID, content string) error {
+	if messageID == "" {
+		c.sendError("missing_id", "Message ID is required")
+		return ErrMissingID
+	}
+	if content == "" {
+		return ErrEmptyMessage
+	}
+	if len(content) > models.MaxChatMessageLength {
+		c.sendError("content_too_long", "Message content too long")
+		return ErrMessageTooLong
+	}
+
+	c.touch()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := models.EditChatMessageRequest{Content: content}
+	if _, err := c.hub.chatSvc.EditMessage(ctx, messageID, c.userID, req); err != nil {
+		logrus.Warnf("Failed to edit chat message %s: %v", messageID, err)
+		c.sendError("edit_failed", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Delete removes a message (the client's own, or any for an admin); the room
+// receives "message_deleted"
+func (c *Client) Delete(messageID string) error {
+	if messageID == "" {
+		c.sendError("missing_id", "Message ID is required")
+		return ErrMissingID
+	}
+
+	c.touch()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := c.hub.chatSvc.DeleteMessage(ctx, messageID, c.userID); err != nil {
+		logrus.Warnf("Failed to delete chat message %s: %v", messageID, err)
+		c.sendError("delete_failed", err.Error())
+		return err
+	}
+	return nil
+}
+
+// React toggles this client's emoji reaction on a message; the room receives
+// "reaction" with the new counts
+func (c *Client) React(messageID, emoji string) error {
+	if messageID == "" {
+		c.sendError("missing_id", "Message ID is required")
+		return ErrMissingID
+	}
+
+	c.touch()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := c.hub.chatSvc.ToggleReaction(ctx, messageID, c.userID, emoji); err != nil {
+		logrus.Warnf("Failed to react to chat message %s: %v", messageID, err)
+		c.sendError("reaction_failed", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Detach removes the client from its room and closes its transport
+func (c *Client) Detach() {
+	select {
+	case c.room.unregister <- c:
+	case <-c.room.stop:
+	}
+	c.transport.Close()
+}
+
+// Done is closed once the client's write loop has exited
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
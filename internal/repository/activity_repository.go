@@ -392,8 +392,8 @@ func (r *activityRepository) LogNotificationEvent(ctx context.Context, notificat
 	return r.WithTransaction(ctx, func(tx pgx.Tx) error {
 		// Log to notifications table
 		notifQuery := `
-			INSERT INTO notifications (id, message, created_at)
-			VALUES ($1, $2, $3)
+			INSERT INTO notifications (id, message, type, manga_id, created_at)
+			VALUES ($1, $2, COALESCE(NULLIF($3, ''), 'system'), $4, $5)
 			RETURNING id
 		`
 		
@@ -401,6 +401,8 @@ func (r *activityRepository) LogNotificationEvent(ctx context.Context, notificat
 		err := tx.QueryRow(ctx, notifQuery,
 			generateUUID("notif"),
 			notification.Message,
+			notification.Type,
+			notification.MangaID,
 			notification.Timestamp,
 		).Scan(&notifID)
 		if err != nil {
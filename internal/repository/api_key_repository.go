@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"mangahub/pkg/models"
+)
+
+// APIKeyRepository handles API key persistence
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	List(ctx context.Context) ([]*models.APIKey, error)
+	Revoke(ctx context.Context, id string) error
+	TouchLastUsed(ctx context.Context, id string) error
+}
+
+type apiKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAPIKeyRepository creates a new PostgreSQL API key repository
+func NewAPIKeyRepository(pool *pgxpool.Pool) APIKeyRepository {
+	return &apiKeyRepository{pool: pool}
+}
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, created_by, created_at, last_used_at, revoked_at`
+
+// Create inserts a new API key record
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	if key.ID == "" {
+		key.ID = generateUUID("key")
+	}
+
+	query := `
+		INSERT INTO api_keys (id, name, prefix, key_hash, scopes, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
+		RETURNING created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.CreatedBy,
+	).Scan(&key.CreatedAt)
+	if err != nil {
+		return r.mapDBError(err, "create_api_key")
+	}
+	return nil
+}
+
+// GetByHash retrieves an API key by the hash of its plaintext value
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	key, err := scanAPIKey(r.pool.QueryRow(ctx, query, keyHash))
+	if err != nil {
+		return nil, r.mapDBError(err, "get_api_key")
+	}
+	return key, nil
+}
+
+// List returns all API keys, newest first
+func (r *apiKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, r.mapDBError(err, "list_api_keys")
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, r.mapDBError(err, "scan_api_key")
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// Revoke marks an API key as revoked; revoking twice is a not-found
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string) error {
+	query := `UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return r.mapDBError(err, "revoke_api_key")
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("revoke_api_key: %w", models.ErrNotFound)
+	}
+	return nil
+}
+
+// TouchLastUsed records that an API key was just used
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1`, id)
+	if err != nil {
+		return r.mapDBError(err, "touch_api_key")
+	}
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var key models.APIKey
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.Scopes,
+		&key.CreatedBy,
+		&key.CreatedAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) mapDBError(err error, operation string) error {
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("%s: %w", operation, models.ErrNotFound)
+	}
+
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		switch pgErr.Code {
+		case "23505": // unique_violation
+			return fmt.Errorf("duplicate api key: %w", err)
+		case "23503": // foreign_key_violation
+			return fmt.Errorf("invalid creator reference: %w", err)
+		}
+	}
+
+	return fmt.Errorf("database error during %s: %w", operation, err)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// chatChannel is the NOTIFY channel chat hubs exchange room events on
+const chatChannel = "chat_events"
+
+// MaxNotifyPayload is the largest payload Postgres accepts for NOTIFY
+const MaxNotifyPayload = 7999
+
+// ErrPayloadTooLarge is returned by a backplane that cannot carry a payload
+var ErrPayloadTooLarge = errors.New("backplane payload too large")
+
+// ChatBackplane carries chat room events between server instances, so every
+// instance's rooms see messages and presence from all of them
+type ChatBackplane interface {
+	// Publish sends a payload to every subscribed instance, including this one
+	Publish(ctx context.Context, payload []byte) error
+	// Subscribe calls ready once subscribed, then handle with every published
+	// payload, until ctx ends or the connection fails
+	Subscribe(ctx context.Context, ready func(), handle func(payload []byte)) error
+}
+
+type postgresChatBackplane struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresChatBackplane creates a backplane on Postgres LISTEN/NOTIFY.
+// Payloads are limited to MaxNotifyPayload bytes.
+func NewPostgresChatBackplane(pool *pgxpool.Pool) ChatBackplane {
+	return &postgresChatBackplane{pool: pool}
+}
+
+func (b *postgresChatBackplane) Publish(ctx context.Context, payload []byte) error {
+	if len(payload) > MaxNotifyPayload {
+		return ErrPayloadTooLarge
+	}
+	if _, err := b.pool.Exec(ctx, `SELECT pg_notify($1, $2)`, chatChannel, string(payload)); err != nil {
+		return fmt.Errorf("database error during notify_chat: %w", err)
+	}
+	return nil
+}
+
+// Subscribe holds a dedicated connection on LISTEN until ctx ends or it fails
+func (b *postgresChatBackplane) Subscribe(ctx context.Context, ready func(), handle func(payload []byte)) error {
+	conn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("database error during acquire_chat_listen_conn: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+chatChannel); err != nil {
+		return fmt.Errorf("database error during listen_chat: %w", err)
+	}
+	defer func() {
+		// Leave the pooled session clean; a failed UNLISTEN closes it instead
+		if _, err := conn.Exec(context.Background(), "UNLISTEN "+chatChannel); err != nil {
+			conn.Conn().Close(context.Background())
+		}
+	}()
+
+	ready()
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("database error during wait_chat_notification: %w", err)
+		}
+		handle([]byte(notification.Payload))
+	}
+}
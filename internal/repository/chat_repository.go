@@ -17,11 +17,13 @@ type ChatRepository interface {
 	Create(ctx context.Context, message *models.ChatMessage) (*models.ChatMessageResponse, error)
 	GetByID(ctx context.Context, id string) (*models.ChatMessage, error)
 	ListByMangaID(ctx context.Context, mangaID string, limit, offset int) ([]*models.ChatMessageResponse, int, error)
+	ListBefore(ctx context.Context, mangaID string, cursor models.ChatHistoryCursor, limit int) ([]*models.ChatMessageResponse, error)
+	Update(ctx context.Context, id, content string) (*models.ChatMessageResponse, error)
 	Delete(ctx context.Context, id string) error
+	ToggleReaction(ctx context.Context, messageID, userID, emoji string) (*models.ChatReactionResponse, error)
+	ListMentions(ctx context.Context, userID string, limit, offset int) ([]*models.ChatMentionResponse, int, error)
 	
-	// Protocol-specific methods
-	StreamMessages(ctx context.Context, mangaID string, lastMessageID *string) (<-chan *models.ChatMessageResponse, error)
-	GetRoomPresence(ctx context.Context, mangaID string) ([]*models.UserPresence, error)
+	// Protocol-specific methods (live fan-out between instances is ChatBackplane)
 	BroadcastMessage(ctx context.Context, message *models.ChatMessage) error
 	
 	// Stats & Activity integration
@@ -36,12 +38,26 @@ type chatRepository struct {
 	pool *pgxpool.Pool
 }
 
+// mentionIDsQuery lists the user IDs mentioned by message cm
+const mentionIDsQuery = `ARRAY(SELECT user_id FROM chat_mentions WHERE message_id = cm.id ORDER BY user_id)`
+
+// reactionCountsQuery aggregates a message's reactions into a JSON object of
+// emoji -> count (NULL without reactions); %s is the message ID expression
+const reactionCountsQuery = `
+	SELECT jsonb_object_agg(emoji, n)
+	FROM (
+		SELECT emoji, COUNT(*) AS n
+		FROM chat_reactions
+		WHERE message_id = %s
+		GROUP BY emoji
+	) counts`
+
 // NewChatRepository creates a new PostgreSQL chat repository
 func NewChatRepository(pool *pgxpool.Pool) ChatRepository {
 	return &chatRepository{pool: pool}
 }
 
-// Create inserts a new chat message with activity logging and stats events
+// Create inserts a new chat message with its mentions and activity logging
 func (r *chatRepository) Create(ctx context.Context, message *models.ChatMessage) (*models.ChatMessageResponse, error) {
 	var response *models.ChatMessageResponse
 	
@@ -50,10 +66,20 @@ func (r *chatRepository) Create(ctx context.Context, message *models.ChatMessage
 			message.ID = generateUUID("chat")
 		}
 
+		// A reply quotes a message from the same room and mentions its author
+		var replyAuthor string
+		if message.ReplyTo != nil {
+			replyQuery := `SELECT user_id FROM chat_messages WHERE id = $1 AND manga_id = $2`
+			err := tx.QueryRow(ctx, replyQuery, *message.ReplyTo, message.MangaID).Scan(&replyAuthor)
+			if err != nil {
+				return r.mapDBError(err, "get_chat_reply")
+			}
+		}
+
 		// Insert chat message
 		insertQuery := `
-			INSERT INTO chat_messages (id, manga_id, user_id, content, created_at)
-			VALUES ($1, $2, $3, $4, COALESCE($5, CURRENT_TIMESTAMP))
+			INSERT INTO chat_messages (id, manga_id, user_id, content, created_at, reply_to)
+			VALUES ($1, $2, $3, $4, COALESCE($5, CURRENT_TIMESTAMP), $6)
 			RETURNING id, created_at
 		`
 		
@@ -63,11 +89,17 @@ func (r *chatRepository) Create(ctx context.Context, message *models.ChatMessage
 			message.UserID,
 			message.Content,
 			message.CreatedAt,
+			message.ReplyTo,
 		).Scan(&message.ID, &message.CreatedAt)
 		
 		if err != nil {
 			return r.mapDBError(err, "create_chat_message")
 		}
+
+		mentions, err := r.insertMentions(ctx, tx, message, replyAuthor)
+		if err != nil {
+			return err
+		}
 		
 		// Log to activity feed
 		activity := &models.Activity{
@@ -94,19 +126,6 @@ func (r *chatRepository) Create(ctx context.Context, message *models.ChatMessage
 			return r.mapDBError(err, "log_chat_activity")
 		}
 		
-		statsQuery := `
-			INSERT INTO manga_stats (manga_id, chat_count, weekly_score, updated_at)
-			VALUES ($1, 1, 2, CURRENT_TIMESTAMP)
-			ON CONFLICT (manga_id) DO UPDATE
-			SET chat_count = manga_stats.chat_count + 1,
-				weekly_score = manga_stats.weekly_score + 2,
-				updated_at = CURRENT_TIMESTAMP
-		`
-		_, err = tx.Exec(ctx, statsQuery, message.MangaID)
-		if err != nil {
-			return r.mapDBError(err, "update_chat_stats")
-		}
-
 		// Get user info for response
 		userQuery := `SELECT username FROM users WHERE id = $1`
 		var username string
@@ -114,13 +133,15 @@ func (r *chatRepository) Create(ctx context.Context, message *models.ChatMessage
 		if err != nil {
 			return r.mapDBError(err, "get_chat_user")
 		}
-		
+
 		response = &models.ChatMessageResponse{
 			ID:        message.ID,
 			MangaID:   message.MangaID,
 			User:      models.ChatUser{ID: message.UserID, Username: username},
 			Content:   message.Content,
 			CreatedAt: message.CreatedAt,
+			ReplyTo:   message.ReplyTo,
+			Mentions:  mentions,
 		}
 		
 		return nil
@@ -136,7 +157,7 @@ func (r *chatRepository) Create(ctx context.Context, message *models.ChatMessage
 // GetByID retrieves a chat message by ID
 func (r *chatRepository) GetByID(ctx context.Context, id string) (*models.ChatMessage, error) {
 	query := `
-		SELECT id, manga_id, user_id, content, created_at
+		SELECT id, manga_id, user_id, content, created_at, edited_at, reply_to
 		FROM chat_messages
 		WHERE id = $1
 	`
@@ -147,6 +168,8 @@ func (r *chatRepository) GetByID(ctx context.Context, id string) (*models.ChatMe
 		&message.UserID,
 		&message.Content,
 		&message.CreatedAt,
+		&message.EditedAt,
+		&message.ReplyTo,
 	)
 	
 	if err == pgx.ErrNoRows {
@@ -169,14 +192,9 @@ func (r *chatRepository) ListByMangaID(ctx context.Context, mangaID string, limi
 	}
 	
 	// Get paginated results with user info
-	query := `
-		SELECT 
-			cm.id, cm.manga_id, cm.user_id, cm.content, cm.created_at,
-			u.username
-		FROM chat_messages cm
-		INNER JOIN users u ON cm.user_id = u.id
+	query := chatMessageSelect + `
 		WHERE cm.manga_id = $1
-		ORDER BY cm.created_at DESC
+		ORDER BY cm.created_at DESC, cm.id DESC
 		LIMIT $2 OFFSET $3
 	`
 	
@@ -184,6 +202,58 @@ func (r *chatRepository) ListByMangaID(ctx context.Context, mangaID string, limi
 	if err != nil {
 		return nil, 0, r.mapDBError(err, "list_chat_messages")
 	}
+	
+	messages, err := r.scanChatMessages(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return messages, total, nil
+}
+
+// ListBefore retrieves up to limit messages of a manga older than the cursor,
+// newest first, by keyset on (created_at, id). An empty cursor starts from
+// the newest message; a BeforeID outside the room is not found.
+func (r *chatRepository) ListBefore(ctx context.Context, mangaID string, cursor models.ChatHistoryCursor, limit int) ([]*models.ChatMessageResponse, error) {
+	before := cursor.Before
+	var beforeID string // Empty sorts before every ID, so a bare time is exclusive
+	if cursor.BeforeID != "" {
+		var createdAt time.Time
+		cursorQuery := `SELECT created_at FROM chat_messages WHERE id = $1 AND manga_id = $2`
+		err := r.pool.QueryRow(ctx, cursorQuery, cursor.BeforeID, mangaID).Scan(&createdAt)
+		if err != nil {
+			return nil, r.mapDBError(err, "get_chat_history_cursor")
+		}
+		before = &createdAt
+		beforeID = cursor.BeforeID
+	}
+
+	query := chatMessageSelect + `
+		WHERE cm.manga_id = $1
+			AND ($2::timestamp IS NULL OR (cm.created_at, cm.id) < ($2::timestamp, $3::text))
+		ORDER BY cm.created_at DESC, cm.id DESC
+		LIMIT $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, mangaID, before, beforeID, limit)
+	if err != nil {
+		return nil, r.mapDBError(err, "list_chat_messages_before")
+	}
+	return r.scanChatMessages(rows)
+}
+
+// chatMessageSelect selects messages as read by scanChatMessages
+var chatMessageSelect = `
+		SELECT 
+			cm.id, cm.manga_id, cm.user_id, cm.content, cm.created_at, cm.edited_at,
+			u.username,
+			(` + fmt.Sprintf(reactionCountsQuery, "cm.id") + `) AS reactions,
+			cm.reply_to,
+			` + mentionIDsQuery + ` AS mentions
+		FROM chat_messages cm
+		INNER JOIN users u ON cm.user_id = u.id`
+
+// scanChatMessages reads the rows of a chatMessageSelect query and closes them
+func (r *chatRepository) scanChatMessages(rows pgx.Rows) ([]*models.ChatMessageResponse, error) {
 	defer rows.Close()
 	
 	var messages []*models.ChatMessageResponse
@@ -197,78 +267,24 @@ func (r *chatRepository) ListByMangaID(ctx context.Context, mangaID string, limi
 			&msg.User.ID,
 			&msg.Content,
 			&msg.CreatedAt,
+			&msg.EditedAt,
 			&username,
+			&msg.Reactions,
+			&msg.ReplyTo,
+			&msg.Mentions,
 		)
 		if err != nil {
-			return nil, 0, r.mapDBError(err, "scan_chat_message")
+			return nil, r.mapDBError(err, "scan_chat_message")
 		}
 		
 		msg.User.Username = username
 		messages = append(messages, &msg)
 	}
-	
-	return messages, total, nil
-}
-
-// StreamMessages provides a channel for real-time message streaming (WebSocket optimized)
-func (r *chatRepository) StreamMessages(ctx context.Context, mangaID string, lastMessageID *string) (<-chan *models.ChatMessageResponse, error) {
-	// This would typically use LISTEN/NOTIFY or a cursor-based approach
-	// For simplicity, we'll create a channel that can be used by WebSocket hub
-	messageChan := make(chan *models.ChatMessageResponse, 100)
-	
-	// In production, this would set up a PostgreSQL LISTEN on a channel
-	// For now, we'll close the channel immediately as this is repository-only
-	go func() {
-		defer close(messageChan)
-		// In production: listen for new messages and push to channel
-	}()
-	
-	return messageChan, nil
-}
-
-// GetRoomPresence gets current user presence for a manga chat room
-func (r *chatRepository) GetRoomPresence(ctx context.Context, mangaID string) ([]*models.UserPresence, error) {
-	// In production, this would query a presence cache or session table
-	// For SPEC.md compliance, we'll return a placeholder with active users from recent chat
-	
-	query := `
-		SELECT DISTINCT ON (cm.user_id) 
-			cm.user_id, u.username, cm.created_at as last_active
-		FROM chat_messages cm
-		INNER JOIN users u ON cm.user_id = u.id
-		WHERE cm.manga_id = $1
-			AND cm.created_at >= NOW() - INTERVAL '5 minutes'
-		ORDER BY cm.user_id, cm.created_at DESC
-		LIMIT 50
-	`
-	
-	rows, err := r.pool.Query(ctx, query, mangaID)
-	if err != nil {
-		return nil, r.mapDBError(err, "get_room_presence")
-	}
-	defer rows.Close()
-	
-	var presences []*models.UserPresence
-	for rows.Next() {
-		var presence models.UserPresence
-		var lastActive time.Time
-		
-		err := rows.Scan(
-			&presence.UserID,
-			&presence.Username,
-			&lastActive,
-		)
-		if err != nil {
-			return nil, r.mapDBError(err, "scan_presence")
-		}
-		
-		presence.MangaID = mangaID
-		presence.Status = "[ONLINE]"
-		presence.LastActive = lastActive
-		presences = append(presences, &presence)
+	if err := rows.Err(); err != nil {
+		return nil, r.mapDBError(err, "scan_chat_message")
 	}
 	
-	return presences, nil
+	return messages, nil
 }
 
 // BroadcastMessage broadcasts a message to all connected clients (admin use)
@@ -348,8 +364,249 @@ func (r *chatRepository) Delete(ctx context.Context, id string) error {
  			return r.mapDBError(err, "update_chat_stats")
 		}
 
+		// Connected clients drop the message once the delete is committed
+		return insertOutbox(ctx, tx, models.OutboxChat, models.OutboxChatPayload{
+			Event:     models.ChatEventDeleted,
+			MessageID: id,
+			MangaID:   mangaID,
+			UserID:    userID,
+			CreatedAt: time.Now(),
+		})
+	})
+}
+
+// Update replaces a message's content and marks it edited
+func (r *chatRepository) Update(ctx context.Context, id, content string) (*models.ChatMessageResponse, error) {
+	var response *models.ChatMessageResponse
+
+	err := r.WithTransaction(ctx, func(tx pgx.Tx) error {
+		query := `
+			UPDATE chat_messages cm
+			SET content = $2, edited_at = CURRENT_TIMESTAMP
+			FROM users u
+			WHERE cm.id = $1 AND u.id = cm.user_id
+			RETURNING cm.manga_id, cm.user_id, cm.created_at, cm.edited_at, u.username,
+				(` + fmt.Sprintf(reactionCountsQuery, "cm.id") + `),
+				cm.reply_to,
+				(SELECT user_id FROM chat_messages WHERE id = cm.reply_to)
+		`
+		msg := &models.ChatMessageResponse{ID: id, Content: content}
+		var replyAuthor *string
+		err := tx.QueryRow(ctx, query, id, content).Scan(
+			&msg.MangaID,
+			&msg.User.ID,
+			&msg.CreatedAt,
+			&msg.EditedAt,
+			&msg.User.Username,
+			&msg.Reactions,
+			&msg.ReplyTo,
+			&replyAuthor,
+		)
+		if err != nil {
+			return r.mapDBError(err, "update_chat_message")
+		}
+
+		// The edit may add or drop @mentions
+		if _, err := tx.Exec(ctx, `DELETE FROM chat_mentions WHERE message_id = $1`, id); err != nil {
+			return r.mapDBError(err, "clear_chat_mentions")
+		}
+		edited := &models.ChatMessage{ID: id, UserID: msg.User.ID, Content: content, CreatedAt: msg.CreatedAt}
+		var replyUserID string
+		if replyAuthor != nil {
+			replyUserID = *replyAuthor
+		}
+		msg.Mentions, err = r.insertMentions(ctx, tx, edited, replyUserID)
+		if err != nil {
+			return err
+		}
+
+		err = insertOutbox(ctx, tx, models.OutboxChat, models.OutboxChatPayload{
+			Event:     models.ChatEventEdited,
+			MessageID: id,
+			MangaID:   msg.MangaID,
+			UserID:    msg.User.ID,
+			Username:  msg.User.Username,
+			Content:   content,
+			CreatedAt: msg.CreatedAt,
+			EditedAt:  msg.EditedAt,
+			ReplyTo:   msg.ReplyTo,
+			Mentions:  msg.Mentions,
+		})
+		if err != nil {
+			return err
+		}
+
+		response = msg
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// insertMentions records the users a message mentions by @username, plus
+// the author of the message it replies to, and returns their IDs. Authors
+// never mention themselves; unknown usernames are ignored.
+func (r *chatRepository) insertMentions(ctx context.Context, tx pgx.Tx, message *models.ChatMessage, replyAuthor string) ([]string, error) {
+	usernames := models.ParseMentions(message.Content)
+	if len(usernames) == 0 && replyAuthor == "" {
+		return nil, nil
+	}
+
+	query := `
+		INSERT INTO chat_mentions (message_id, user_id, created_at)
+		SELECT $1, id, $2
+		FROM users
+		WHERE (LOWER(username) = ANY($3) OR id = $4) AND id <> $5
+		ON CONFLICT DO NOTHING
+		RETURNING user_id
+	`
+	rows, err := tx.Query(ctx, query, message.ID, message.CreatedAt, usernames, replyAuthor, message.UserID)
+	if err != nil {
+		return nil, r.mapDBError(err, "insert_chat_mentions")
+	}
+	defer rows.Close()
+
+	var mentions []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, r.mapDBError(err, "scan_chat_mention")
+		}
+		mentions = append(mentions, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapDBError(err, "insert_chat_mentions")
+	}
+	return mentions, nil
+}
+
+// ListMentions retrieves the messages mentioning a user, newest first
+func (r *chatRepository) ListMentions(ctx context.Context, userID string, limit, offset int) ([]*models.ChatMentionResponse, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM chat_mentions WHERE user_id = $1`
+	if err := r.pool.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, r.mapDBError(err, "count_chat_mentions")
+	}
+
+	query := `
+		SELECT
+			cm.id, cm.manga_id, cm.user_id, u.username, cm.content, cm.created_at, cm.edited_at,
+			cm.reply_to, ` + mentionIDsQuery + `,
+			m.title
+		FROM chat_mentions mn
+		INNER JOIN chat_messages cm ON cm.id = mn.message_id
+		INNER JOIN users u ON u.id = cm.user_id
+		INNER JOIN manga m ON m.id = cm.manga_id
+		WHERE mn.user_id = $1
+		ORDER BY mn.created_at DESC, cm.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, r.mapDBError(err, "list_chat_mentions")
+	}
+	defer rows.Close()
+
+	var mentions []*models.ChatMentionResponse
+	for rows.Next() {
+		var mention models.ChatMentionResponse
+		err := rows.Scan(
+			&mention.ID,
+			&mention.MangaID,
+			&mention.User.ID,
+			&mention.User.Username,
+			&mention.Content,
+			&mention.CreatedAt,
+			&mention.EditedAt,
+			&mention.ReplyTo,
+			&mention.Mentions,
+			&mention.MangaTitle,
+		)
+		if err != nil {
+			return nil, 0, r.mapDBError(err, "scan_chat_mention")
+		}
+		mentions = append(mentions, &mention)
+	}
+
+	return mentions, total, nil
+}
+
+// ToggleReaction adds the user's emoji reaction to a message, or removes it
+// if present, and returns the message's reaction counts afterwards
+func (r *chatRepository) ToggleReaction(ctx context.Context, messageID, userID, emoji string) (*models.ChatReactionResponse, error) {
+	var response *models.ChatReactionResponse
+
+	err := r.WithTransaction(ctx, func(tx pgx.Tx) error {
+		result := &models.ChatReactionResponse{MessageID: messageID, Emoji: emoji}
+
+		// Locks the message so concurrent toggles count correctly
+		err := tx.QueryRow(ctx, `SELECT manga_id FROM chat_messages WHERE id = $1 FOR SHARE`, messageID).Scan(&result.MangaID)
+		if err != nil {
+			return r.mapDBError(err, "toggle_chat_reaction")
+		}
+
+		deleted, err := tx.Exec(ctx, `
+			DELETE FROM chat_reactions
+			WHERE message_id = $1 AND user_id = $2 AND emoji = $3
+		`, messageID, userID, emoji)
+		if err != nil {
+			return r.mapDBError(err, "remove_chat_reaction")
+		}
+
+		if deleted.RowsAffected() == 0 {
+			_, err = tx.Exec(ctx, `
+				INSERT INTO chat_reactions (message_id, user_id, emoji)
+				VALUES ($1, $2, $3)
+				ON CONFLICT DO NOTHING
+			`, messageID, userID, emoji)
+			if err != nil {
+				return r.mapDBError(err, "add_chat_reaction")
+			}
+			result.Added = true
+		}
+
+		err = tx.QueryRow(ctx, fmt.Sprintf(reactionCountsQuery, "$1"), messageID).Scan(&result.Reactions)
+		if err != nil {
+			return r.mapDBError(err, "count_chat_reactions")
+		}
+		if result.Reactions == nil {
+			result.Reactions = map[string]int{}
+		}
+
+		var username string
+		err = tx.QueryRow(ctx, `SELECT username FROM users WHERE id = $1`, userID).Scan(&username)
+		if err != nil {
+			return r.mapDBError(err, "get_chat_user")
+		}
+
+		err = insertOutbox(ctx, tx, models.OutboxChat, models.OutboxChatPayload{
+			Event:     models.ChatEventReaction,
+			MessageID: messageID,
+			MangaID:   result.MangaID,
+			UserID:    userID,
+			Username:  username,
+			CreatedAt: time.Now(),
+			Emoji:     emoji,
+			Added:     result.Added,
+			Reactions: result.Reactions,
+		})
+		if err != nil {
+			return err
+		}
+
+		response = result
 		return nil
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
 // WithTransaction executes a function within a database transaction
@@ -378,7 +635,7 @@ func (r *chatRepository) WithTransaction(ctx context.Context, fn func(tx pgx.Tx)
 func (r *chatRepository) mapDBError(err error, operation string) error {
 	if err == pgx.ErrNoRows {
 		switch operation {
-		case "get_chat_message_by_id", "delete_chat_message":
+		case "get_chat_message_by_id", "delete_chat_message", "update_chat_message", "toggle_chat_reaction", "get_chat_reply", "get_chat_history_cursor":
 			return fmt.Errorf("%s: %w", operation, models.ErrNotFound)
 		default:
 			return fmt.Errorf("resource not found: %w", err)
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mangahub/pkg/database"
+	"mangahub/pkg/models"
+)
+
+func TestChatListBefore(t *testing.T) {
+	// This test requires a running PostgreSQL instance
+	config := database.Config{
+		Host:            "localhost",
+		Port:            5432,
+		User:            "mangahub",
+		Password:        "mangahub_dev_password",
+		Database:        "mangahub_dev",
+		SSLMode:         "disable",
+		MaxOpenConns:    5,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 5 * time.Minute,
+		ConnMaxIdleTime: 2 * time.Minute,
+		Timeout:         10 * time.Second,
+	}
+
+	pool, err := database.NewPGXPool(config)
+	if err != nil {
+		t.Skipf("Skipping test: PostgreSQL not available: %v", err)
+		return
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewChatRepository(pool)
+
+	// Two rooms; deleting them and the user removes their messages
+	prefix := fmt.Sprintf("test-history-%d", time.Now().UnixNano())
+	userID, room, otherRoom := prefix+"-user", prefix+"-a", prefix+"-b"
+	_, err = pool.Exec(ctx, `INSERT INTO users (id, username, password_hash) VALUES ($1, $1, 'x')`, userID)
+	require.NoError(t, err)
+	defer pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	for _, id := range []string{room, otherRoom} {
+		_, err = pool.Exec(ctx, `INSERT INTO manga (id, title) VALUES ($1, $1)`, id)
+		require.NoError(t, err)
+		defer pool.Exec(ctx, `DELETE FROM manga WHERE id = $1`, id)
+	}
+
+	// Timestamps are written and read as UTC wall clock; m3 and m4 share
+	// one, so the ID breaks the tie
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		ts := base.Add(time.Duration(minutes) * time.Minute)
+		return &ts
+	}
+	m := func(n int) string { return fmt.Sprintf("%s-m%d", room, n) }
+	seed := []struct {
+		id      string
+		mangaID string
+		minute  int
+	}{
+		{m(1), room, 0},
+		{m(2), room, 1},
+		{m(3), room, 2},
+		{m(4), room, 2},
+		{otherRoom + "-m1", otherRoom, 1},
+	}
+	for _, s := range seed {
+		_, err = pool.Exec(ctx, `
+			INSERT INTO chat_messages (id, manga_id, user_id, content, created_at)
+			VALUES ($1, $2, $3, 'hello', $4)
+		`, s.id, s.mangaID, userID, *at(s.minute))
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name    string
+		cursor  models.ChatHistoryCursor
+		limit   int
+		want    []string
+		wantErr error
+	}{
+		{name: "newest first", limit: 50, want: []string{m(4), m(3), m(2), m(1)}},
+		{name: "limit", limit: 2, want: []string{m(4), m(3)}},
+		{name: "before the newest of a tie", cursor: models.ChatHistoryCursor{BeforeID: m(4)}, limit: 50, want: []string{m(3), m(2), m(1)}},
+		{name: "before the oldest of a tie", cursor: models.ChatHistoryCursor{BeforeID: m(3)}, limit: 50, want: []string{m(2), m(1)}},
+		{name: "before the first message", cursor: models.ChatHistoryCursor{BeforeID: m(1)}, limit: 50},
+		{name: "ID wins over time", cursor: models.ChatHistoryCursor{BeforeID: m(2), Before: at(10)}, limit: 50, want: []string{m(1)}},
+		{name: "time only is exclusive", cursor: models.ChatHistoryCursor{Before: at(2)}, limit: 50, want: []string{m(2), m(1)}},
+		{name: "time only after every message", cursor: models.ChatHistoryCursor{Before: at(10)}, limit: 50, want: []string{m(4), m(3), m(2), m(1)}},
+		{name: "time only before every message", cursor: models.ChatHistoryCursor{Before: at(-1)}, limit: 50},
+		{name: "ID from another room", cursor: models.ChatHistoryCursor{BeforeID: otherRoom + "-m1"}, limit: 50, wantErr: models.ErrNotFound},
+		{name: "unknown ID", cursor: models.ChatHistoryCursor{BeforeID: room + "-missing"}, limit: 50, wantErr: models.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, err := repo.ListBefore(ctx, room, tt.cursor, tt.limit)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var ids []string
+			for _, msg := range messages {
+				ids = append(ids, msg.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
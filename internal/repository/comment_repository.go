@@ -17,7 +17,7 @@ type CommentRepository interface {
 	Create(ctx context.Context, comment *models.Comment) (*models.CommentResponse, error)
 	GetByID(ctx context.Context, id string) (*models.Comment, error)
 	ListByMangaID(ctx context.Context, mangaID string, limit, offset int) ([]*models.CommentResponse, int, error)
-	LikeComment(ctx context.Context, commentID string, userID string) (*models.CommentResponse, error)
+	LikeComment(ctx context.Context, commentID string, userID string, likedAt time.Time) (*models.CommentResponse, error)
 	Delete(ctx context.Context, id string) error
 	
 	// Protocol-specific methods
@@ -40,7 +40,7 @@ func NewCommentRepository(pool *pgxpool.Pool) CommentRepository {
 	return &commentRepository{pool: pool}
 }
 
-// Create inserts a new comment with activity logging and stats events
+// Create inserts a new comment with activity logging
 func (r *commentRepository) Create(ctx context.Context, comment *models.Comment) (*models.CommentResponse, error) {
 	var response *models.CommentResponse
 	
@@ -94,20 +94,6 @@ func (r *commentRepository) Create(ctx context.Context, comment *models.Comment)
 			return r.mapDBError(err, "log_comment_activity")
 		}
 		
-		// Update manga stats
-		statsQuery := `
-			INSERT INTO manga_stats (manga_id, comment_count, weekly_score, updated_at)
-			VALUES ($1, 1, 1, CURRENT_TIMESTAMP)
-			ON CONFLICT (manga_id) DO UPDATE
-			SET comment_count = manga_stats.comment_count + 1,
-				weekly_score = manga_stats.weekly_score + 1,
-				updated_at = CURRENT_TIMESTAMP
-		`
-		_, err = tx.Exec(ctx, statsQuery, comment.MangaID)
-		if err != nil {
-			return r.mapDBError(err, "update_comment_stats")
-		}
-
 		// Get user info for response
 		userQuery := `SELECT username FROM users WHERE id = $1`
 		var username string
@@ -214,8 +200,9 @@ func (r *commentRepository) ListByMangaID(ctx context.Context, mangaID string, l
 	return comments, total, nil
 }
 
-// LikeComment increments the like count for a comment with activity logging
-func (r *commentRepository) LikeComment(ctx context.Context, commentID string, userID string) (*models.CommentResponse, error) {
+// LikeComment increments the like count for a comment and records the like
+// made at likedAt, with activity logging
+func (r *commentRepository) LikeComment(ctx context.Context, commentID string, userID string, likedAt time.Time) (*models.CommentResponse, error) {
     var response *models.CommentResponse
 
     err := r.WithTransaction(ctx, func(tx pgx.Tx) error {
@@ -251,18 +238,13 @@ func (r *commentRepository) LikeComment(ctx context.Context, commentID string, u
 			return r.mapDBError(err, "update_comment_likes")
 		}
 		
-		// Update manga stats
-		statsQuery := `
-			INSERT INTO manga_stats (manga_id, like_count, weekly_score, updated_at)
-			VALUES ($1, 1, 1, CURRENT_TIMESTAMP)
-			ON CONFLICT (manga_id) DO UPDATE
-			SET like_count = manga_stats.like_count + 1,
-				weekly_score = manga_stats.weekly_score + 1,
-				updated_at = CURRENT_TIMESTAMP
-		`
-		_, err = tx.Exec(ctx, statsQuery, mangaID)
+		// Record when the like was made; trending scores it from then
+		_, err = tx.Exec(ctx, `
+			INSERT INTO comment_likes (id, comment_id, manga_id, user_id, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`, generateUUID("like"), commentID, mangaID, userID, likedAt)
 		if err != nil {
-			return r.mapDBError(err, "update_like_stats")
+			return r.mapDBError(err, "record_comment_like")
 		}
 
 		// Get user info for the comment author (not the liker)
@@ -288,7 +270,7 @@ func (r *commentRepository) LikeComment(ctx context.Context, commentID string, u
             Type:      models.ActivityTypeComment,
             UserID:    &userID,          // liker
             MangaID:   &comment.MangaID,  // affected manga
-            CreatedAt: time.Now(),
+            CreatedAt: likedAt,
         }
         _, err = tx.Exec(ctx, `
             INSERT INTO activity_feed (id, type, user_id, manga_id, created_at)
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"mangahub/pkg/models"
+)
+
+// dayFormat is the layout of models.DailyStats.Day
+const dayFormat = "2006-01-02"
+
+// DailyStatsRepository reads per-day manga activity for time series. Live
+// rows are written by StatsRepository.BatchUpdateStats.
+type DailyStatsRepository interface {
+	// GetMangaSeries returns one manga's days from since to today, zero-filled
+	GetMangaSeries(ctx context.Context, mangaID string, since time.Time) ([]models.DailyStats, error)
+	// GetGlobalSeries returns every manga's activity summed per day, zero-filled
+	GetGlobalSeries(ctx context.Context, since time.Time) ([]models.DailyStats, error)
+	// Backfill derives missing days since a time from activity_feed and comments
+	Backfill(ctx context.Context, since time.Time, formula models.ScoringFormula) error
+}
+
+type dailyStatsRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDailyStatsRepository creates a new PostgreSQL daily stats repository
+func NewDailyStatsRepository(pool *pgxpool.Pool) DailyStatsRepository {
+	return &dailyStatsRepository{pool: pool}
+}
+
+// GetMangaSeries returns one manga's daily stats, oldest first
+func (r *dailyStatsRepository) GetMangaSeries(ctx context.Context, mangaID string, since time.Time) ([]models.DailyStats, error) {
+	query := `
+		SELECT TO_CHAR(d, 'YYYY-MM-DD'),
+		       COALESCE(s.comment_count, 0), COALESCE(s.chat_count, 0),
+		       COALESCE(s.like_count, 0), COALESCE(s.score, 0)
+		FROM generate_series($2::date, $3::date, INTERVAL '1 day') d
+		LEFT JOIN manga_stats_daily s ON s.day = d::date AND s.manga_id = $1
+		ORDER BY d
+	`
+
+	return r.querySeries(ctx, "get_manga_daily_stats", mangaID, query,
+		mangaID, since.Format(dayFormat), time.Now().Format(dayFormat))
+}
+
+// GetGlobalSeries returns daily stats summed over every manga, oldest first
+func (r *dailyStatsRepository) GetGlobalSeries(ctx context.Context, since time.Time) ([]models.DailyStats, error) {
+	query := `
+		SELECT TO_CHAR(d, 'YYYY-MM-DD'),
+		       COALESCE(s.comment_count, 0), COALESCE(s.chat_count, 0),
+		       COALESCE(s.like_count, 0), COALESCE(s.score, 0)
+		FROM generate_series($1::date, $2::date, INTERVAL '1 day') d
+		LEFT JOIN (
+			SELECT day,
+			       SUM(comment_count)::int AS comment_count, SUM(chat_count)::int AS chat_count,
+			       SUM(like_count)::int AS like_count, SUM(score)::int AS score
+			FROM manga_stats_daily
+			WHERE day >= $1::date
+			GROUP BY day
+		) s ON s.day = d::date
+		ORDER BY d
+	`
+
+	return r.querySeries(ctx, "get_global_daily_stats", "", query,
+		since.Format(dayFormat), time.Now().Format(dayFormat))
+}
+
+// querySeries scans (day, comments, chats, likes, score) rows
+func (r *dailyStatsRepository) querySeries(ctx context.Context, operation, mangaID, query string, args ...interface{}) ([]models.DailyStats, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error during %s: %w", operation, err)
+	}
+	defer rows.Close()
+
+	series := []models.DailyStats{}
+	for rows.Next() {
+		point := models.DailyStats{MangaID: mangaID}
+		if err := rows.Scan(&point.Day, &point.CommentCount, &point.ChatCount, &point.LikeCount, &point.Score); err != nil {
+			return nil, fmt.Errorf("database error during scan_daily_stats: %w", err)
+		}
+		series = append(series, point)
+	}
+
+	return series, rows.Err()
+}
+
+// Backfill inserts days that have no row yet, so live counts are never
+// overwritten. Likes count on the day they were made.
+func (r *dailyStatsRepository) Backfill(ctx context.Context, since time.Time, formula models.ScoringFormula) error {
+	query := `
+		INSERT INTO manga_stats_daily (manga_id, day, comment_count, chat_count, like_count, score)
+		SELECT manga_id, day, SUM(comments), SUM(chats), SUM(likes), ROUND(SUM(points))::int
+		FROM (
+			SELECT manga_id, created_at::date AS day,
+				(type = 'comment')::int AS comments,
+				(type = 'chat')::int AS chats,
+				0 AS likes,
+				CASE type
+					WHEN 'comment' THEN $1::float8
+					WHEN 'chat' THEN $3::float8
+					WHEN 'manga_update' THEN $4::float8
+					ELSE 0
+				END AS points
+			FROM activity_feed
+			WHERE manga_id IS NOT NULL AND created_at >= $5::date
+			UNION ALL
+			SELECT manga_id, created_at::date, 0, 0, 1, $2::float8
+			FROM comment_likes
+			WHERE created_at >= $5::date
+		) e
+		WHERE EXISTS (SELECT 1 FROM manga m WHERE m.id = e.manga_id)
+		GROUP BY manga_id, day
+		ON CONFLICT (manga_id, day) DO NOTHING
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		formula.CommentWeight,
+		formula.LikeWeight,
+		formula.ChatWeight,
+		formula.UpdateWeight,
+		since.Format(dayFormat),
+	)
+	if err != nil {
+		return fmt.Errorf("database error during backfill_daily_stats: %w", err)
+	}
+
+	return nil
+}
@@ -2,9 +2,10 @@ package repository
 
 import (
     "fmt"
-    "time"
+
+    "github.com/google/uuid"
 )
 
 func generateUUID(prefix string) string {
-    return fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
-}
\ No newline at end of file
+    return fmt.Sprintf("%s-%s", prefix, uuid.New().String())
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"mangahub/pkg/models"
+)
+
+// JobRepository handles scheduled job locking and run history
+type JobRepository interface {
+	// TryLock takes a session-level advisory lock for a job so only one
+	// replica runs it at a time. When acquired, release must be called.
+	TryLock(ctx context.Context, jobName string) (release func(), acquired bool, err error)
+	SaveRun(ctx context.Context, run *models.JobRun) error
+	GetLastRun(ctx context.Context, jobName string) (*models.JobRun, error)
+}
+
+type jobRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewJobRepository creates a new PostgreSQL job repository
+func NewJobRepository(pool *pgxpool.Pool) JobRepository {
+	return &jobRepository{pool: pool}
+}
+
+// TryLock acquires a dedicated connection and tries pg_try_advisory_lock on it.
+// Advisory locks belong to the session, so the connection is held until release.
+func (r *jobRepository) TryLock(ctx context.Context, jobName string) (func(), bool, error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, r.mapDBError(err, "acquire_job_lock_conn")
+	}
+
+	lockKey := "mangahub:job:" + jobName
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, lockKey).Scan(&acquired); err != nil {
+		conn.Release()
+		return nil, false, r.mapDBError(err, "try_job_lock")
+	}
+	if !acquired {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	release := func() {
+		// Unlock even if the job's context was cancelled
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, lockKey); err != nil {
+			// Closing the session drops the lock
+			conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}
+	return release, true, nil
+}
+
+// SaveRun upserts the latest run for a job
+func (r *jobRepository) SaveRun(ctx context.Context, run *models.JobRun) error {
+	query := `
+		INSERT INTO job_runs (job_name, status, triggered_by, instance, started_at, finished_at, duration_ms, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (job_name) DO UPDATE SET
+			status = EXCLUDED.status,
+			triggered_by = EXCLUDED.triggered_by,
+			instance = EXCLUDED.instance,
+			started_at = EXCLUDED.started_at,
+			finished_at = EXCLUDED.finished_at,
+			duration_ms = EXCLUDED.duration_ms,
+			error = EXCLUDED.error
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		run.JobName,
+		string(run.Status),
+		run.TriggeredBy,
+		run.Instance,
+		run.StartedAt,
+		run.FinishedAt,
+		run.DurationMs,
+		run.Error,
+	)
+	if err != nil {
+		return r.mapDBError(err, "save_job_run")
+	}
+	return nil
+}
+
+// GetLastRun returns the latest recorded run for a job
+func (r *jobRepository) GetLastRun(ctx context.Context, jobName string) (*models.JobRun, error) {
+	query := `
+		SELECT job_name, status, triggered_by, instance, started_at, finished_at, duration_ms, error
+		FROM job_runs
+		WHERE job_name = $1
+	`
+
+	var run models.JobRun
+	var status string
+	err := r.pool.QueryRow(ctx, query, jobName).Scan(
+		&run.JobName,
+		&status,
+		&run.TriggeredBy,
+		&run.Instance,
+		&run.StartedAt,
+		&run.FinishedAt,
+		&run.DurationMs,
+		&run.Error,
+	)
+	if err != nil {
+		return nil, r.mapDBError(err, "get_last_job_run")
+	}
+	run.Status = models.JobStatus(status)
+	return &run, nil
+}
+
+func (r *jobRepository) mapDBError(err error, operation string) error {
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("%s: %w", operation, models.ErrNotFound)
+	}
+	return fmt.Errorf("database error during %s: %w", operation, err)
+}
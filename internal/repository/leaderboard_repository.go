@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"mangahub/pkg/models"
+)
+
+// LeaderboardRepository computes user contribution rankings
+type LeaderboardRepository interface {
+	// GetLeaderboard ranks users for a category. A nil since means all time.
+	GetLeaderboard(ctx context.Context, category string, since *time.Time, limit int) ([]models.LeaderboardEntry, error)
+}
+
+type leaderboardRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLeaderboardRepository creates a new PostgreSQL leaderboard repository
+func NewLeaderboardRepository(pool *pgxpool.Pool) LeaderboardRepository {
+	return &leaderboardRepository{pool: pool}
+}
+
+// leaderboardScoreQueries yields (user_id, score) per user for each category;
+// $1 is the optional window start
+var leaderboardScoreQueries = map[string]string{
+	models.LeaderboardCommenters: `
+		SELECT user_id, COUNT(*) AS score
+		FROM comments
+		WHERE $1::timestamp IS NULL OR created_at >= $1
+		GROUP BY user_id
+	`,
+	// Likes are counted on comments written within the window
+	models.LeaderboardLikedCommenters: `
+		SELECT user_id, SUM(like_count) AS score
+		FROM comments
+		WHERE $1::timestamp IS NULL OR created_at >= $1
+		GROUP BY user_id
+		HAVING SUM(like_count) > 0
+	`,
+	models.LeaderboardChatters: `
+		SELECT user_id, COUNT(*) AS score
+		FROM chat_messages
+		WHERE $1::timestamp IS NULL OR created_at >= $1
+		GROUP BY user_id
+	`,
+}
+
+// GetLeaderboard returns the top users for a category; ties share a rank
+func (r *leaderboardRepository) GetLeaderboard(ctx context.Context, category string, since *time.Time, limit int) ([]models.LeaderboardEntry, error) {
+	scoreQuery, ok := leaderboardScoreQueries[category]
+	if !ok {
+		return nil, fmt.Errorf("unknown leaderboard category %q: %w", category, models.ErrInvalidInput)
+	}
+
+	query := `
+		SELECT s.user_id, u.username, s.score::int, RANK() OVER (ORDER BY s.score DESC)::int AS rank
+		FROM (` + scoreQuery + `) s
+		JOIN users u ON u.id = s.user_id
+		ORDER BY rank, u.username
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("database error during get_leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.LeaderboardEntry{}
+	for rows.Next() {
+		entry := models.LeaderboardEntry{Category: category}
+		if err := rows.Scan(&entry.UserID, &entry.Username, &entry.Score, &entry.Rank); err != nil {
+			return nil, fmt.Errorf("database error during scan_leaderboard_entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -14,7 +14,8 @@ import (
 // MangaRepository handles manga data persistence with protocol-aware methods
 type MangaRepository interface {
 	// Core CRUD operations
-	Create(ctx context.Context, manga *models.Manga, genreIDs []string) error
+	// Create inserts a manga; creatorID is the user who added it, empty for imports
+	Create(ctx context.Context, manga *models.Manga, genreIDs []string, creatorID string) error
 	GetByID(ctx context.Context, id string) (*models.Manga, error)
 	GetWithGenres(ctx context.Context, id string) (*models.MangaWithGenres, error)
 	List(ctx context.Context, limit, offset int) ([]models.Manga, int, error)
@@ -40,7 +41,7 @@ func NewMangaRepository(pool *pgxpool.Pool) MangaRepository {
 }
 
 // Create inserts a new manga with genres and initializes stats
-func (r *mangaRepository) Create(ctx context.Context, manga *models.Manga, genreIDs []string) error {
+func (r *mangaRepository) Create(ctx context.Context, manga *models.Manga, genreIDs []string, creatorID string) error {
 	return r.WithTransaction(ctx, func(tx pgx.Tx) error {
 		// Insert manga - note: updated_at will be set by trigger or default
 		mangaQuery := `
@@ -77,7 +78,30 @@ func (r *mangaRepository) Create(ctx context.Context, manga *models.Manga, genre
 			return r.mapDBError(err, "initialize_manga_stats")
 		}
 
-		return nil
+		// Announce the new manga and score it once committed
+		message := fmt.Sprintf("New manga '%s' added", manga.Title)
+		if creatorID != "" {
+			var username string
+			err = tx.QueryRow(ctx, `SELECT username FROM users WHERE id = $1`, creatorID).Scan(&username)
+			if err != nil {
+				return r.mapDBError(err, "get_manga_creator")
+			}
+			message = fmt.Sprintf("New manga '%s' added by %s", manga.Title, username)
+		}
+		err = insertOutbox(ctx, tx, models.OutboxNotification, models.OutboxNotificationPayload{
+			Type:    models.ActivityTypeMangaUpdate,
+			MangaID: &manga.ID,
+			Title:   manga.Title,
+			Message: message,
+		})
+		if err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, models.OutboxStats, models.OutboxStatsPayload{
+			Type:      models.ActivityTypeMangaUpdate,
+			MangaID:   manga.ID,
+			EventTime: manga.CreatedAt,
+		})
 	})
 }
 
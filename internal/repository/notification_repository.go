@@ -2,6 +2,7 @@ package repository
 
 import (
     "context"
+    "time"
 
     "github.com/jackc/pgx/v5"
     "github.com/jackc/pgx/v5/pgconn"
@@ -9,10 +10,24 @@ import (
     "mangahub/pkg/models"
 )
 
+// notificationChannel is the NOTIFY channel the notifications insert trigger
+// raises, with the new row ID as payload
+const notificationChannel = "notifications"
+
 // NotificationRepository handles notification persistence
 type NotificationRepository interface {
-    Create(ctx context.Context, notification *models.Notification) error
-    GetNewNotifications(ctx context.Context, lastID string) ([]*models.Notification, error)
+    // Create inserts a notification; it reports false, without error, when
+    // a notification with the same ID already exists
+    Create(ctx context.Context, notification *models.Notification) (bool, error)
+    GetByID(ctx context.Context, id string) (*models.Notification, error)
+    // GetSince returns up to limit notifications after the (createdAt, id)
+    // position, oldest first
+    GetSince(ctx context.Context, createdAt time.Time, id string, limit int) ([]*models.Notification, error)
+    // GetLatest returns the newest notification, or nil if there is none
+    GetLatest(ctx context.Context) (*models.Notification, error)
+    // Listen calls ready once listening, then notify with the ID of every
+    // inserted notification, until ctx ends or the connection fails
+    Listen(ctx context.Context, ready func(), notify func(id string)) error
 }
 
 type notificationRepository struct {
@@ -24,54 +39,136 @@ func NewNotificationRepository(pool *pgxpool.Pool) NotificationRepository {
     return &notificationRepository{pool: pool}
 }
 
-// Create inserts a notification record
-func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+// Create inserts a notification record, skipping an existing ID so callers
+// can key notifications on the event that caused them
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) (bool, error) {
     query := `
-        INSERT INTO notifications (id, message, created_at)
-        VALUES ($1, $2, COALESCE($3, CURRENT_TIMESTAMP))
+        INSERT INTO notifications (id, message, type, manga_id, title, created_at)
+        VALUES ($1, $2, COALESCE(NULLIF($3, ''), 'system'), $4, NULLIF($5, ''), COALESCE($6, CURRENT_TIMESTAMP))
+        ON CONFLICT (id) DO NOTHING
         RETURNING id, created_at
     `
 
     err := r.pool.QueryRow(ctx, query,
         notification.ID,
         notification.Message,
+        notification.Type,
+        notification.MangaID,
+        notification.Title,
         notification.CreatedAt,
     ).Scan(&notification.ID, &notification.CreatedAt)
+    if err == pgx.ErrNoRows {
+        return false, nil
+    }
     if err != nil {
-        return r.mapDBError(err, "create_notification")
+        return false, r.mapDBError(err, "create_notification")
     }
-    return nil
+    return true, nil
 }
 
-func (r *notificationRepository) GetNewNotifications(ctx context.Context, lastID string) ([]*models.Notification, error) {
-    if lastID == "" {
-        lastID = "notif-0"
+func (r *notificationRepository) GetByID(ctx context.Context, id string) (*models.Notification, error) {
+    query := `
+        SELECT id, message, type, manga_id, COALESCE(title, ''), created_at
+        FROM notifications
+        WHERE id = $1
+    `
+
+    n, err := scanNotification(r.pool.QueryRow(ctx, query, id))
+    if err != nil {
+        return nil, r.mapDBError(err, "get_notification")
     }
+    return n, nil
+}
 
+// GetSince pages by (created_at, id) so rows sharing a timestamp are neither
+// skipped nor repeated
+func (r *notificationRepository) GetSince(ctx context.Context, createdAt time.Time, id string, limit int) ([]*models.Notification, error) {
     query := `
-        SELECT id, message, created_at
+        SELECT id, message, type, manga_id, COALESCE(title, ''), created_at
         FROM notifications
-        WHERE id > $1
-        ORDER BY created_at ASC
+        WHERE (created_at, id) > ($1, $2)
+        ORDER BY created_at ASC, id ASC
+        LIMIT $3
     `
-    rows, err := r.pool.Query(ctx, query, lastID)
+    rows, err := r.pool.Query(ctx, query, createdAt, id, limit)
     if err != nil {
-        return nil, r.mapDBError(err, "get_new_notifications")
+        return nil, r.mapDBError(err, "get_notifications_since")
     }
     defer rows.Close()
 
     var out []*models.Notification
     for rows.Next() {
-        var n models.Notification
-        if err := rows.Scan(&n.ID, &n.Message, &n.CreatedAt); err != nil {
+        n, err := scanNotification(rows)
+        if err != nil {
             return nil, r.mapDBError(err, "scan_notification")
         }
-        out = append(out, &n)
+        out = append(out, n)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, r.mapDBError(err, "get_notifications_since")
     }
 
     return out, nil
 }
 
+func (r *notificationRepository) GetLatest(ctx context.Context) (*models.Notification, error) {
+    query := `
+        SELECT id, message, type, manga_id, COALESCE(title, ''), created_at
+        FROM notifications
+        ORDER BY created_at DESC, id DESC
+        LIMIT 1
+    `
+
+    n, err := scanNotification(r.pool.QueryRow(ctx, query))
+    if err == pgx.ErrNoRows {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, r.mapDBError(err, "get_latest_notification")
+    }
+    return n, nil
+}
+
+// scanNotification reads the columns selected by the notification queries
+func scanNotification(row pgx.Row) (*models.Notification, error) {
+    var n models.Notification
+    if err := row.Scan(&n.ID, &n.Message, &n.Type, &n.MangaID, &n.Title, &n.CreatedAt); err != nil {
+        return nil, err
+    }
+    return &n, nil
+}
+
+// Listen holds a dedicated connection on LISTEN until ctx ends or it fails
+func (r *notificationRepository) Listen(ctx context.Context, ready func(), notify func(id string)) error {
+    conn, err := r.pool.Acquire(ctx)
+    if err != nil {
+        return r.mapDBError(err, "acquire_notification_listen_conn")
+    }
+    defer conn.Release()
+
+    if _, err := conn.Exec(ctx, "LISTEN "+notificationChannel); err != nil {
+        return r.mapDBError(err, "listen_notifications")
+    }
+    defer func() {
+        // Leave the pooled session clean; a failed UNLISTEN closes it instead
+        if _, err := conn.Exec(context.Background(), "UNLISTEN "+notificationChannel); err != nil {
+            conn.Conn().Close(context.Background())
+        }
+    }()
+
+    ready()
+    for {
+        notification, err := conn.Conn().WaitForNotification(ctx)
+        if err != nil {
+            if ctx.Err() != nil {
+                return nil
+            }
+            return r.mapDBError(err, "wait_notification")
+        }
+        notify(notification.Payload)
+    }
+}
+
 func (r *notificationRepository) mapDBError(err error, operation string) error {
     if err == pgx.ErrNoRows {
         return models.NewHTTPError(models.ErrCodeNotFound, "resource not found", 404, err)
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"mangahub/pkg/models"
+)
+
+// outboxChannel is the NOTIFY channel raised when outbox rows are committed
+const outboxChannel = "outbox_events"
+
+// OutboxRepository claims and settles outbox events for the relay. Events are
+// written with insertOutbox inside the transaction that causes them, or with
+// Enqueue by event subscribers once the change has committed.
+type OutboxRepository interface {
+	// Enqueue records an event for a destination in its own transaction
+	Enqueue(ctx context.Context, destination string, payload interface{}) error
+	// Claim leases up to limit due pending events for a destination, oldest
+	// first, and counts the attempt. A leased event is not claimed again until
+	// the lease ends.
+	Claim(ctx context.Context, destination string, limit int, lease time.Duration) ([]*models.OutboxEvent, error)
+	// MarkDelivered settles a round of delivered events in one statement
+	MarkDelivered(ctx context.Context, ids []string) error
+	// MarkFailed records an error and schedules the next attempt
+	MarkFailed(ctx context.Context, id, lastError string, retryAt time.Time) error
+	// MarkDead dead-letters an event so it is never claimed again
+	MarkDead(ctx context.Context, id, lastError string) error
+	// PurgeDelivered deletes events delivered before a time
+	PurgeDelivered(ctx context.Context, before time.Time) (int64, error)
+	// Listen calls notify with the destination whenever outbox rows are
+	// committed, until ctx ends
+	Listen(ctx context.Context, notify func(destination string)) error
+}
+
+type outboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOutboxRepository creates a new PostgreSQL outbox repository
+func NewOutboxRepository(pool *pgxpool.Pool) OutboxRepository {
+	return &outboxRepository{pool: pool}
+}
+
+// insertOutbox records a side effect in the caller's transaction and wakes
+// relays once it commits
+func insertOutbox(ctx context.Context, tx pgx.Tx, destination string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal outbox payload: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox_events (id, destination, payload)
+		VALUES ($1, $2, $3)
+	`
+	if _, err := tx.Exec(ctx, query, uuid.New().String(), destination, data); err != nil {
+		return fmt.Errorf("database error during insert_outbox_event: %w", err)
+	}
+
+	// Notifications are delivered on commit and collapsed per transaction and
+	// destination, so only the destination's worker wakes
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, outboxChannel, destination); err != nil {
+		return fmt.Errorf("database error during notify_outbox: %w", err)
+	}
+	return nil
+}
+
+// Enqueue writes one event with insertOutbox and commits it
+func (r *outboxRepository) Enqueue(ctx context.Context, destination string, payload interface{}) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return r.mapDBError(err, "begin_transaction")
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertOutbox(ctx, tx, destination, payload); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return r.mapDBError(err, "commit_outbox_event")
+	}
+	return nil
+}
+
+// Claim leases due events with SKIP LOCKED so concurrent relays split the work
+func (r *outboxRepository) Claim(ctx context.Context, destination string, limit int, lease time.Duration) ([]*models.OutboxEvent, error) {
+	query := `
+		UPDATE outbox_events
+		SET attempts = attempts + 1,
+		    available_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE status = 'pending' AND destination = $3 AND available_at <= CURRENT_TIMESTAMP
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, destination, payload, status, attempts, last_error, available_at, created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, limit, lease.Seconds(), destination)
+	if err != nil {
+		return nil, r.mapDBError(err, "claim_outbox_events")
+	}
+	defer rows.Close()
+
+	var events []*models.OutboxEvent
+	for rows.Next() {
+		event := &models.OutboxEvent{}
+		var status string
+		if err := rows.Scan(
+			&event.ID,
+			&event.Destination,
+			&event.Payload,
+			&status,
+			&event.Attempts,
+			&event.LastError,
+			&event.AvailableAt,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, r.mapDBError(err, "scan_outbox_event")
+		}
+		event.Status = models.OutboxStatus(status)
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapDBError(err, "claim_outbox_events")
+	}
+
+	// RETURNING does not keep the subquery's order
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	return events, nil
+}
+
+// MarkDelivered settles events
+func (r *outboxRepository) MarkDelivered(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE outbox_events
+		SET status = 'delivered', delivered_at = CURRENT_TIMESTAMP, last_error = NULL
+		WHERE id = ANY($1)
+	`
+	if _, err := r.pool.Exec(ctx, query, ids); err != nil {
+		return r.mapDBError(err, "mark_outbox_delivered")
+	}
+	return nil
+}
+
+// MarkFailed keeps an event pending until retryAt
+func (r *outboxRepository) MarkFailed(ctx context.Context, id, lastError string, retryAt time.Time) error {
+	query := `
+		UPDATE outbox_events
+		SET last_error = $2, available_at = $3
+		WHERE id = $1
+	`
+	if _, err := r.pool.Exec(ctx, query, id, lastError, retryAt); err != nil {
+		return r.mapDBError(err, "mark_outbox_failed")
+	}
+	return nil
+}
+
+// MarkDead dead-letters an event
+func (r *outboxRepository) MarkDead(ctx context.Context, id, lastError string) error {
+	query := `
+		UPDATE outbox_events
+		SET status = 'dead', last_error = $2
+		WHERE id = $1
+	`
+	if _, err := r.pool.Exec(ctx, query, id, lastError); err != nil {
+		return r.mapDBError(err, "mark_outbox_dead")
+	}
+	return nil
+}
+
+// PurgeDelivered removes settled events; dead letters are kept for inspection
+func (r *outboxRepository) PurgeDelivered(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM outbox_events WHERE status = 'delivered' AND delivered_at < $1`, before)
+	if err != nil {
+		return 0, r.mapDBError(err, "purge_outbox_events")
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Listen holds a dedicated connection on LISTEN until ctx ends or it fails
+func (r *outboxRepository) Listen(ctx context.Context, notify func(destination string)) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return r.mapDBError(err, "acquire_outbox_listen_conn")
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+outboxChannel); err != nil {
+		return r.mapDBError(err, "listen_outbox")
+	}
+	defer func() {
+		// Leave the pooled session clean; a failed UNLISTEN closes it instead
+		if _, err := conn.Exec(context.Background(), "UNLISTEN "+outboxChannel); err != nil {
+			conn.Conn().Close(context.Background())
+		}
+	}()
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return r.mapDBError(err, "wait_outbox_notification")
+		}
+		notify(n.Payload)
+	}
+}
+
+func (r *outboxRepository) mapDBError(err error, operation string) error {
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("%s: %w", operation, models.ErrNotFound)
+	}
+	return fmt.Errorf("database error during %s: %w", operation, err)
+}
@@ -23,15 +23,17 @@ type StatsRepository interface {
 	// Ranking & Stats endpoints
 	GetTopByWeeklyScore(ctx context.Context, limit, offset int) ([]*models.MangaStats, int, error)
 	GetHotManga(ctx context.Context, limit, offset int) ([]*models.MangaStats, int, error)
-	GetTrendingManga(ctx context.Context, hours int, limit int) ([]*models.TrendingManga, error)
+	GetTrendingManga(ctx context.Context, since time.Time, formula models.ScoringFormula, limit int) ([]*models.TrendingManga, error)
+	GetScore(ctx context.Context, mangaID string, since time.Time, formula models.ScoringFormula) (float64, error)
 	
 	// TCP Stats Service integration
 	ProcessActivityEvent(ctx context.Context, event *models.ActivityEvent) error
-	RecalculateWeeklyScores(ctx context.Context, decayFactor float64) error
+	RecalculateWeeklyScores(ctx context.Context, formula models.ScoringFormula) error
 	GetRecentActivityForStats(ctx context.Context, hours int) ([]*models.ActivityEvent, error)
 	
 	// Batch operations
-	BatchUpdateStats(ctx context.Context, updates []models.StatsUpdate) error
+	BatchUpdateStats(ctx context.Context, updates []models.StatsUpdate, eventIDs []string) error
+	PurgeProcessedEvents(ctx context.Context, before time.Time) (int64, error)
 	RebuildAllStats(ctx context.Context) error
 	
 	// Transaction support
@@ -112,25 +114,22 @@ func (r *statsRepository) GetByMangaID(ctx context.Context, mangaID string) (*mo
 // IncrementCommentCount increments the comment count for a manga
 func (r *statsRepository) IncrementCommentCount(ctx context.Context, mangaID string) error {
 	return r.WithTransaction(ctx, func(tx pgx.Tx) error {
-		// Get current stats to calculate new weekly score
+		// Weekly score is left to the scoring engine
 		stats, err := r.getStatsForUpdate(ctx, tx, mangaID)
 		if err != nil {
 			return err
 		}
 		
-		// Increment comment count and update weekly score
-		newWeeklyScore := stats.WeeklyScore + 1 // Each comment adds 1 point
 		newCommentCount := stats.CommentCount + 1
 		
 		query := `
 			UPDATE manga_stats
 			SET comment_count = $2,
-			    weekly_score = $3,
 			    updated_at = CURRENT_TIMESTAMP
 			WHERE manga_id = $1
 		`
 		
-		result, err := tx.Exec(ctx, query, mangaID, newCommentCount, newWeeklyScore)
+		result, err := tx.Exec(ctx, query, mangaID, newCommentCount)
 		if err != nil {
 			return r.mapDBError(err, "increment_comment_count")
 		}
@@ -151,18 +150,16 @@ func (r *statsRepository) IncrementLikeCount(ctx context.Context, mangaID string
 			return err
 		}
 		
-		newWeeklyScore := stats.WeeklyScore + 1 // Each like adds 1 point
 		newLikeCount := stats.LikeCount + 1
 		
 		query := `
 			UPDATE manga_stats
 			SET like_count = $2,
-			    weekly_score = $3,
 			    updated_at = CURRENT_TIMESTAMP
 			WHERE manga_id = $1
 		`
 		
-		result, err := tx.Exec(ctx, query, mangaID, newLikeCount, newWeeklyScore)
+		result, err := tx.Exec(ctx, query, mangaID, newLikeCount)
 		if err != nil {
 			return r.mapDBError(err, "increment_like_count")
 		}
@@ -183,18 +180,16 @@ func (r *statsRepository) IncrementChatCount(ctx context.Context, mangaID string
 			return err
 		}
 		
-		newWeeklyScore := stats.WeeklyScore + 2 // Each chat message adds 2 points (higher weight)
 		newChatCount := stats.ChatCount + 1
 		
 		query := `
 			UPDATE manga_stats
 			SET chat_count = $2,
-			    weekly_score = $3,
 			    updated_at = CURRENT_TIMESTAMP
 			WHERE manga_id = $1
 		`
 		
-		result, err := tx.Exec(ctx, query, mangaID, newChatCount, newWeeklyScore)
+		result, err := tx.Exec(ctx, query, mangaID, newChatCount)
 		if err != nil {
 			return r.mapDBError(err, "increment_chat_count")
 		}
@@ -274,44 +269,89 @@ func (r *statsRepository) GetHotManga(ctx context.Context, limit, offset int) ([
     return r.GetTopByWeeklyScore(ctx, limit, offset)
 }
 
-// GetTrendingManga gets trending manga based on recent activity
-func (r *statsRepository) GetTrendingManga(ctx context.Context, hours int, limit int) ([]*models.TrendingManga, error) {
+// scoredEventsSQL yields (manga_id, events, points) for every scored event
+// since $6: activity_feed rows plus the comment likes made in the window. $1-$4 are the comment, like, chat and update weights and $5 the
+// half-life in seconds; an event's weight halves with every half-life of age.
+const scoredEventsSQL = `
+	SELECT manga_id, events,
+		weight * POWER(0.5, EXTRACT(EPOCH FROM (NOW() - created_at))::float8 / $5::float8) AS points
+	FROM (
+		SELECT manga_id, created_at, 1 AS events,
+			CASE type
+				WHEN 'comment' THEN $1::float8
+				WHEN 'chat' THEN $3::float8
+				WHEN 'manga_update' THEN $4::float8
+				ELSE 0
+			END AS weight
+		FROM activity_feed
+		WHERE manga_id IS NOT NULL AND created_at >= $6::timestamp
+		UNION ALL
+		SELECT manga_id, created_at, 1 AS events, $2::float8 AS weight
+		FROM comment_likes
+		WHERE created_at >= $6::timestamp
+	) e
+`
+
+// scoringArgs returns the formula parameters for scoredEventsSQL
+func scoringArgs(formula models.ScoringFormula, since time.Time) []interface{} {
+	return []interface{}{
+		formula.CommentWeight,
+		formula.LikeWeight,
+		formula.ChatWeight,
+		formula.UpdateWeight,
+		formula.HalfLife.Seconds(),
+		since,
+	}
+}
+
+// GetTrendingManga ranks manga by their decayed activity score since a time
+func (r *statsRepository) GetTrendingManga(ctx context.Context, since time.Time, formula models.ScoringFormula, limit int) ([]*models.TrendingManga, error) {
 	query := `
-		SELECT 
-			a.manga_id,
-			COUNT(*) as activity_count
-		FROM activity_feed a
-		WHERE a.created_at >= NOW() - INTERVAL '1 hour' * $1
-			AND a.type IN ('comment', 'chat')
-			AND a.manga_id IS NOT NULL
-		GROUP BY a.manga_id
-		ORDER BY activity_count DESC
-		LIMIT $2
+		SELECT e.manga_id, m.title, SUM(e.events)::int AS activity_count, SUM(e.points) AS score
+		FROM (` + scoredEventsSQL + `) e
+		JOIN manga m ON m.id = e.manga_id
+		GROUP BY e.manga_id, m.title
+		HAVING SUM(e.points) > 0
+		ORDER BY score DESC, m.title
+		LIMIT $7
 	`
-	
-	rows, err := r.pool.Query(ctx, query, hours, limit)
+
+	rows, err := r.pool.Query(ctx, query, append(scoringArgs(formula, since), limit)...)
 	if err != nil {
 		return nil, r.mapDBError(err, "get_trending_manga")
 	}
 	defer rows.Close()
-	
-	var trending []*models.TrendingManga
+
+	trending := []*models.TrendingManga{}
 	for rows.Next() {
 		var manga models.TrendingManga
-		err := rows.Scan(
-			&manga.MangaID,
-			&manga.ActivityCount,
-		)
-		if err != nil {
+		if err := rows.Scan(&manga.MangaID, &manga.Title, &manga.ActivityCount, &manga.Score); err != nil {
 			return nil, r.mapDBError(err, "scan_trending_manga")
 		}
 		trending = append(trending, &manga)
 	}
-	
-	return trending, nil
+
+	return trending, rows.Err()
 }
 
-// ProcessActivityEvent processes an activity event for stats aggregation
+// GetScore returns one manga's decayed activity score since a time
+func (r *statsRepository) GetScore(ctx context.Context, mangaID string, since time.Time, formula models.ScoringFormula) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(e.points), 0)
+		FROM (` + scoredEventsSQL + `) e
+		WHERE e.manga_id = $7
+	`
+
+	var score float64
+	if err := r.pool.QueryRow(ctx, query, append(scoringArgs(formula, since), mangaID)...).Scan(&score); err != nil {
+		return 0, r.mapDBError(err, "get_score")
+	}
+
+	return score, nil
+}
+
+// ProcessActivityEvent processes an activity event for stats aggregation.
+// The caller sets event.Weight from the scoring engine.
 func (r *statsRepository) ProcessActivityEvent(ctx context.Context, event *models.ActivityEvent) error {
 	return r.WithTransaction(ctx, func(tx pgx.Tx) error {
 		if event.MangaID == nil {
@@ -326,15 +366,10 @@ func (r *statsRepository) ProcessActivityEvent(ctx context.Context, event *model
 		switch event.Type {
 		case "comment":
 			stats.CommentCount++
-			stats.WeeklyScore += 1
 		case "chat":
 			stats.ChatCount++
-			stats.WeeklyScore += 2
-		case "manga_update":
-			stats.WeeklyScore += 5
-		default:
-			stats.WeeklyScore += event.Weight
 		}
+		stats.WeeklyScore += event.Weight
 
 		updateQuery := `
 			UPDATE manga_stats
@@ -359,83 +394,29 @@ func (r *statsRepository) ProcessActivityEvent(ctx context.Context, event *model
 	})
 }
 
-// RecalculateWeeklyScores recalculates all weekly scores with decay factor
-func (r *statsRepository) RecalculateWeeklyScores(ctx context.Context, decayFactor float64) error {
+// RecalculateWeeklyScores recomputes every weekly score from the last 7 days of
+// activity using the scoring formula. The result only depends on the activity
+// window, so running it repeatedly never inflates scores.
+func (r *statsRepository) RecalculateWeeklyScores(ctx context.Context, formula models.ScoringFormula) error {
 	return r.WithTransaction(ctx, func(tx pgx.Tx) error {
-		// Apply decay to all scores first
-		decayQuery := `
-			UPDATE manga_stats
-			SET weekly_score = GREATEST(weekly_score * $1, 0),
+		query := `
+			UPDATE manga_stats ms
+			SET weekly_score = recent.score,
 			    updated_at = CURRENT_TIMESTAMP
+			FROM (
+				SELECT m.id AS manga_id, COALESCE(ROUND(SUM(e.points)), 0)::int AS score
+				FROM manga m
+				LEFT JOIN (` + scoredEventsSQL + `) e ON e.manga_id = m.id
+				GROUP BY m.id
+			) recent
+			WHERE ms.manga_id = recent.manga_id
 		`
-		
-		_, err := tx.Exec(ctx, decayQuery, decayFactor)
-		if err != nil {
-			return r.mapDBError(err, "apply_score_decay")
-		}
-		
-		// Get recent activity to add back fresh points
-		activityQuery := `
-			SELECT 
-				a.manga_id,
-				a.type,
-				COUNT(*) as count
-			FROM activity_feed a
-			WHERE a.created_at >= NOW() - INTERVAL '7 days'
-				AND a.manga_id IS NOT NULL
-			GROUP BY a.manga_id, a.type
-		`
-		
-		rows, err := tx.Query(ctx, activityQuery)
+
+		_, err := tx.Exec(ctx, query, scoringArgs(formula, time.Now().AddDate(0, 0, -7))...)
 		if err != nil {
-			return r.mapDBError(err, "get_recent_activity")
+			return r.mapDBError(err, "recalculate_weekly_scores")
 		}
-		defer rows.Close()
-		
-		// Process each manga's activity
-		for rows.Next() {
-			var mangaID string
-			var activityType string
-			var count int
-			
-			err := rows.Scan(&mangaID, &activityType, &count)
-			if err != nil {
-				return r.mapDBError(err, "scan_activity_row")
-			}
-			
-			// Get current stats
-			stats, err := r.getStatsForUpdate(ctx, tx, mangaID)
-			if err != nil {
-				return err
-			}
-			
-			// Add points based on activity type
-			pointsToAdd := 0
-			switch activityType {
-			case "comment":
-				pointsToAdd = count * 1
-			case "chat":
-				pointsToAdd = count * 2
-			case "manga_update":
-				pointsToAdd = count * 5
-			}
-			
-			newScore := stats.WeeklyScore + pointsToAdd
-			
-			// Update the score
-			updateQuery := `
-				UPDATE manga_stats
-				SET weekly_score = $2,
-				    updated_at = CURRENT_TIMESTAMP
-				WHERE manga_id = $1
-			`
-			
-			_, err = tx.Exec(ctx, updateQuery, mangaID, newScore)
-			if err != nil {
-				return r.mapDBError(err, "update_recalculated_score")
-			}
-		}
-		
+
 		return nil
 	})
 }
@@ -500,112 +481,165 @@ func (r *statsRepository) GetRecentActivityForStats(ctx context.Context, hours i
 	return events, nil
 }
 
-// BatchUpdateStats performs batch updates for performance
-func (r *statsRepository) BatchUpdateStats(ctx context.Context, updates []models.StatsUpdate) error {
+// DuplicateEventsError is returned by BatchUpdateStats when some event IDs
+// were already processed. Nothing was written; the caller retries without
+// those events.
+type DuplicateEventsError struct {
+	IDs []string
+}
+
+func (e *DuplicateEventsError) Error() string {
+	return fmt.Sprintf("%d stats events already processed", len(e.IDs))
+}
+
+// BatchUpdateStats applies counter increments to manga_stats and the matching
+// manga_stats_daily rows in one transaction. Increments are done in SQL, so
+// concurrent batches never lose updates. Updates for manga that no longer
+// exist are skipped rather than failing the whole batch.
+//
+// eventIDs are the IDs of the events the updates were built from. They are
+// recorded in stats_processed_events in the same transaction; if any was
+// recorded before, nothing is written and a *DuplicateEventsError lists them.
+func (r *statsRepository) BatchUpdateStats(ctx context.Context, updates []models.StatsUpdate, eventIDs []string) error {
 	return r.WithTransaction(ctx, func(tx pgx.Tx) error {
+		if len(eventIDs) > 0 {
+			if err := r.recordProcessedEvents(ctx, tx, eventIDs); err != nil {
+				return err
+			}
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		statsQuery := `
+			INSERT INTO manga_stats (manga_id, comment_count, like_count, chat_count, weekly_score, updated_at)
+			SELECT $1, $2, $3, $4, $5, CURRENT_TIMESTAMP
+			WHERE EXISTS (SELECT 1 FROM manga WHERE id = $1)
+			ON CONFLICT (manga_id) DO UPDATE
+			SET comment_count = manga_stats.comment_count + EXCLUDED.comment_count,
+			    like_count = manga_stats.like_count + EXCLUDED.like_count,
+			    chat_count = manga_stats.chat_count + EXCLUDED.chat_count,
+			    weekly_score = manga_stats.weekly_score + EXCLUDED.weekly_score,
+			    updated_at = CURRENT_TIMESTAMP
+		`
+		dailyQuery := `
+			INSERT INTO manga_stats_daily (manga_id, day, comment_count, chat_count, like_count, score)
+			SELECT $1, $2::date, $3, $4, $5, $6
+			WHERE EXISTS (SELECT 1 FROM manga WHERE id = $1)
+			ON CONFLICT (manga_id, day) DO UPDATE
+			SET comment_count = manga_stats_daily.comment_count + EXCLUDED.comment_count,
+			    chat_count = manga_stats_daily.chat_count + EXCLUDED.chat_count,
+			    like_count = manga_stats_daily.like_count + EXCLUDED.like_count,
+			    score = manga_stats_daily.score + EXCLUDED.score
+		`
+
+		batch := &pgx.Batch{}
 		for _, update := range updates {
-			query := `
-				UPDATE manga_stats
-				SET comment_count = COALESCE($2, comment_count),
-				    like_count = COALESCE($3, like_count),
-				    chat_count = COALESCE($4, chat_count),
-				    weekly_score = COALESCE($5, weekly_score),
-				    updated_at = CURRENT_TIMESTAMP
-				WHERE manga_id = $1
-			`
-			
-			_, err := tx.Exec(ctx, query,
+			batch.Queue(statsQuery,
 				update.MangaID,
 				update.CommentCount,
 				update.LikeCount,
 				update.ChatCount,
 				update.WeeklyScore,
 			)
-			if err != nil {
-				return r.mapDBError(err, "batch_update_stats")
-			}
+			batch.Queue(dailyQuery,
+				update.MangaID,
+				update.Day,
+				update.CommentCount,
+				update.ChatCount,
+				update.LikeCount,
+				update.WeeklyScore,
+			)
+		}
+
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			return r.mapDBError(err, "batch_update_stats")
 		}
 		return nil
 	})
 }
 
-// RebuildAllStats recalculates all stats from scratch
-func (r *statsRepository) RebuildAllStats(ctx context.Context) error {
-	return r.WithTransaction(ctx, func(tx pgx.Tx) error {
-		// Reset all stats to zero
-		resetQuery := `
-			UPDATE manga_stats
-			SET comment_count = 0, like_count = 0, chat_count = 0, weekly_score = 0, updated_at = CURRENT_TIMESTAMP
-		`
-		_, err := tx.Exec(ctx, resetQuery)
-		if err != nil {
-			return r.mapDBError(err, "reset_all_stats")
-		}
-		
-		// Count comments per manga
-		commentQuery := `
-			SELECT manga_id, COUNT(*) as count
-			FROM comments
-			GROUP BY manga_id
-		`
-		commentRows, err := tx.Query(ctx, commentQuery)
-		if err != nil {
-			return r.mapDBError(err, "count_comments_for_rebuild")
-		}
-		defer commentRows.Close()
-		
-		for commentRows.Next() {
-			var mangaID string
-			var count int
-			err := commentRows.Scan(&mangaID, &count)
-			if err != nil {
-				return r.mapDBError(err, "scan_comment_count")
-			}
-			
-			updateQuery := `
-				UPDATE manga_stats
-				SET comment_count = $2, weekly_score = weekly_score + $2
-				WHERE manga_id = $1
-			`
-			_, err = tx.Exec(ctx, updateQuery, mangaID, count)
-			if err != nil {
-				return r.mapDBError(err, "update_comment_count")
-			}
-		}
-		
-		// Count chat messages per manga
-		chatQuery := `
-			SELECT manga_id, COUNT(*) as count
-			FROM chat_messages
-			GROUP BY manga_id
-		`
-		chatRows, err := tx.Query(ctx, chatQuery)
-		if err != nil {
-			return r.mapDBError(err, "count_chats_for_rebuild")
+// recordProcessedEvents inserts event IDs, failing with a
+// *DuplicateEventsError if any already exist
+func (r *statsRepository) recordProcessedEvents(ctx context.Context, tx pgx.Tx, eventIDs []string) error {
+	query := `
+		INSERT INTO stats_processed_events (event_id)
+		SELECT unnest($1::text[])
+		ON CONFLICT (event_id) DO NOTHING
+		RETURNING event_id
+	`
+	rows, err := tx.Query(ctx, query, eventIDs)
+	if err != nil {
+		return r.mapDBError(err, "record_processed_events")
+	}
+	defer rows.Close()
+
+	inserted := make(map[string]bool, len(eventIDs))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return r.mapDBError(err, "record_processed_events")
 		}
-		defer chatRows.Close()
-		
-		for chatRows.Next() {
-			var mangaID string
-			var count int
-			err := chatRows.Scan(&mangaID, &count)
-			if err != nil {
-				return r.mapDBError(err, "scan_chat_count")
-			}
-			
-			updateQuery := `
-				UPDATE manga_stats
-				SET chat_count = $2, weekly_score = weekly_score + ($2 * 2)
-				WHERE manga_id = $1
-			`
-			_, err = tx.Exec(ctx, updateQuery, mangaID, count)
-			if err != nil {
-				return r.mapDBError(err, "update_chat_count")
-			}
+		inserted[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return r.mapDBError(err, "record_processed_events")
+	}
+
+	var dupes []string
+	for _, id := range eventIDs {
+		if !inserted[id] {
+			dupes = append(dupes, id)
 		}
-		
-		return nil
-	})
+	}
+	if len(dupes) > 0 {
+		return &DuplicateEventsError{IDs: dupes}
+	}
+	return nil
+}
+
+// PurgeProcessedEvents forgets event IDs processed before the given time
+func (r *statsRepository) PurgeProcessedEvents(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM stats_processed_events WHERE processed_at < $1`, before)
+	if err != nil {
+		return 0, r.mapDBError(err, "purge_processed_events")
+	}
+	return result.RowsAffected(), nil
+}
+
+// RebuildAllStats recounts every manga's comments, likes and chat messages
+// from the source tables. Weekly scores are left to RecalculateWeeklyScores.
+func (r *statsRepository) RebuildAllStats(ctx context.Context) error {
+	query := `
+		UPDATE manga_stats ms
+		SET comment_count = counts.comments,
+		    like_count = counts.likes,
+		    chat_count = counts.chats,
+		    updated_at = CURRENT_TIMESTAMP
+		FROM (
+			SELECT m.id AS manga_id,
+				COALESCE(c.comments, 0) AS comments,
+				COALESCE(c.likes, 0) AS likes,
+				COALESCE(ch.chats, 0) AS chats
+			FROM manga m
+			LEFT JOIN (
+				SELECT manga_id, COUNT(*) AS comments, SUM(like_count) AS likes
+				FROM comments
+				GROUP BY manga_id
+			) c ON c.manga_id = m.id
+			LEFT JOIN (
+				SELECT manga_id, COUNT(*) AS chats
+				FROM chat_messages
+				GROUP BY manga_id
+			) ch ON ch.manga_id = m.id
+		) counts
+		WHERE ms.manga_id = counts.manga_id
+	`
+
+	if _, err := r.pool.Exec(ctx, query); err != nil {
+		return r.mapDBError(err, "rebuild_all_stats")
+	}
+	return nil
 }
 
 // WithTransaction executes a function within a database transaction
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mangahub/pkg/database"
+	"mangahub/pkg/models"
+)
+
+func TestGetScoreDecay(t *testing.T) {
+	// This test requires a running PostgreSQL instance
+	config := database.Config{
+		Host:            "localhost",
+		Port:            5432,
+		User:            "mangahub",
+		Password:        "mangahub_dev_password",
+		Database:        "mangahub_dev",
+		SSLMode:         "disable",
+		MaxOpenConns:    5,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 5 * time.Minute,
+		ConnMaxIdleTime: 2 * time.Minute,
+		Timeout:         10 * time.Second,
+	}
+
+	pool, err := database.NewPGXPool(config)
+	if err != nil {
+		t.Skipf("Skipping test: PostgreSQL not available: %v", err)
+		return
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewStatsRepository(pool)
+
+	mangaID := fmt.Sprintf("test-score-%d", time.Now().UnixNano())
+	_, err = pool.Exec(ctx, `INSERT INTO manga (id, title) VALUES ($1, $1)`, mangaID)
+	require.NoError(t, err)
+	defer pool.Exec(ctx, `DELETE FROM manga WHERE id = $1`, mangaID)
+	defer pool.Exec(ctx, `DELETE FROM activity_feed WHERE manga_id = $1`, mangaID)
+
+	formula := models.ScoringFormula{
+		CommentWeight: 8,
+		ChatWeight:    1,
+		UpdateWeight:  4,
+		HalfLife:      time.Hour,
+	}
+	since := time.Now().UTC().Add(-5 * 24 * time.Hour)
+
+	// Each event is added to the same manga, so the expected score accumulates
+	tests := []struct {
+		name      string
+		eventType string
+		age       time.Duration
+		points    float64
+	}{
+		{name: "fresh comment", eventType: models.ActivityTypeComment, age: 0, points: 8},
+		{name: "comment one half-life old", eventType: models.ActivityTypeComment, age: time.Hour, points: 4},
+		{name: "comment two half-lives old", eventType: models.ActivityTypeComment, age: 2 * time.Hour, points: 2},
+		{name: "chat half a half-life old", eventType: models.ActivityTypeChat, age: 30 * time.Minute, points: 0.7071},
+		{name: "update three half-lives old", eventType: models.ActivityTypeMangaUpdate, age: 3 * time.Hour, points: 0.5},
+		{name: "comment before since", eventType: models.ActivityTypeComment, age: 10 * 24 * time.Hour, points: 0},
+	}
+
+	var want float64
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := pool.Exec(ctx, `
+				INSERT INTO activity_feed (id, type, manga_id, created_at)
+				VALUES ($1, $2, $3, NOW() - make_interval(secs => $4))
+			`, fmt.Sprintf("%s-%d", mangaID, i), tt.eventType, mangaID, tt.age.Seconds())
+			require.NoError(t, err)
+			want += tt.points
+
+			score, err := repo.GetScore(ctx, mangaID, since, formula)
+			require.NoError(t, err)
+			assert.InDelta(t, want, score, 0.01)
+		})
+	}
+}
+
+func TestRebuildAllStatsKeepsLikes(t *testing.T) {
+	// This test requires a running PostgreSQL instance
+	config := database.Config{
+		Host:            "localhost",
+		Port:            5432,
+		User:            "mangahub",
+		Password:        "mangahub_dev_password",
+		Database:        "mangahub_dev",
+		SSLMode:         "disable",
+		MaxOpenConns:    5,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 5 * time.Minute,
+		ConnMaxIdleTime: 2 * time.Minute,
+		Timeout:         10 * time.Second,
+	}
+
+	pool, err := database.NewPGXPool(config)
+	if err != nil {
+		t.Skipf("Skipping test: PostgreSQL not available: %v", err)
+		return
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewStatsRepository(pool)
+
+	prefix := fmt.Sprintf("test-rebuild-%d", time.Now().UnixNano())
+	userID, mangaID := prefix+"-user", prefix+"-manga"
+	_, err = pool.Exec(ctx, `INSERT INTO users (id, username, password_hash) VALUES ($1, $1, 'x')`, userID)
+	require.NoError(t, err)
+	defer pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	_, err = pool.Exec(ctx, `INSERT INTO manga (id, title) VALUES ($1, $1)`, mangaID)
+	require.NoError(t, err)
+	defer pool.Exec(ctx, `DELETE FROM manga WHERE id = $1`, mangaID)
+
+	// Two comments with 3 and 2 likes, one chat message, and counters that
+	// have drifted from them
+	_, err = pool.Exec(ctx, `
+		INSERT INTO comments (id, manga_id, user_id, content, like_count)
+		VALUES ($1 || '-c1', $2, $3, 'first', 3), ($1 || '-c2', $2, $3, 'second', 2)
+	`, prefix, mangaID, userID)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `INSERT INTO chat_messages (id, manga_id, user_id, content) VALUES ($1 || '-m1', $2, $3, 'hi')`, prefix, mangaID, userID)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `
+		INSERT INTO manga_stats (manga_id, comment_count, like_count, chat_count, weekly_score)
+		VALUES ($1, 9, 9, 9, 42)
+		ON CONFLICT (manga_id) DO UPDATE
+		SET comment_count = 9, like_count = 9, chat_count = 9, weekly_score = 42
+	`, mangaID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.RebuildAllStats(ctx))
+
+	stats, err := repo.GetByMangaID(ctx, mangaID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.CommentCount)
+	assert.Equal(t, 5, stats.LikeCount)
+	assert.Equal(t, 1, stats.ChatCount)
+	assert.Equal(t, 42, stats.WeeklyScore, "weekly score is left to RecalculateWeeklyScores")
+}
+
+func TestGetScoreCountsLikesWhenMade(t *testing.T) {
+	// This test requires a running PostgreSQL instance
+	config := database.Config{
+		Host:            "localhost",
+		Port:            5432,
+		User:            "mangahub",
+		Password:        "mangahub_dev_password",
+		Database:        "mangahub_dev",
+		SSLMode:         "disable",
+		MaxOpenConns:    5,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 5 * time.Minute,
+		ConnMaxIdleTime: 2 * time.Minute,
+		Timeout:         10 * time.Second,
+	}
+
+	pool, err := database.NewPGXPool(config)
+	if err != nil {
+		t.Skipf("Skipping test: PostgreSQL not available: %v", err)
+		return
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewStatsRepository(pool)
+	commentRepo := NewCommentRepository(pool)
+
+	prefix := fmt.Sprintf("test-likes-%d", time.Now().UnixNano())
+	userID, mangaID := prefix+"-user", prefix+"-manga"
+	_, err = pool.Exec(ctx, `INSERT INTO users (id, username, password_hash) VALUES ($1, $1, 'x')`, userID)
+	require.NoError(t, err)
+	defer pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	_, err = pool.Exec(ctx, `INSERT INTO manga (id, title) VALUES ($1, $1)`, mangaID)
+	require.NoError(t, err)
+	defer pool.Exec(ctx, `DELETE FROM manga WHERE id = $1`, mangaID)
+	defer pool.Exec(ctx, `DELETE FROM activity_feed WHERE manga_id = $1`, mangaID)
+	defer pool.Exec(ctx, `DELETE FROM outbox_events WHERE payload->>'manga_id' = $1`, mangaID)
+
+	// An old comment liked today, and a new comment whose likes predate the
+	// comment_likes history
+	_, err = pool.Exec(ctx, `
+		INSERT INTO comments (id, manga_id, user_id, content, like_count, created_at)
+		VALUES ($1 || '-old', $2, $3, 'old', 0, NOW() - INTERVAL '30 days'),
+		       ($1 || '-new', $2, $3, 'new', 5, NOW())
+	`, prefix, mangaID, userID)
+	require.NoError(t, err)
+
+	_, err = commentRepo.LikeComment(ctx, prefix+"-old", userID, time.Now())
+	require.NoError(t, err)
+
+	// Only likes are weighted, so the like's activity row scores nothing
+	formula := models.ScoringFormula{LikeWeight: 3, HalfLife: 24 * time.Hour}
+	score, err := repo.GetScore(ctx, mangaID, time.Now().UTC().Add(-7*24*time.Hour), formula)
+	require.NoError(t, err)
+	assert.InDelta(t, 3, score, 0.01)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"mangahub/pkg/models"
@@ -299,24 +300,23 @@ func (c *Client) SearchManga(ctx context.Context, query string, page, limit int)
 	return &result, nil
 }
 
-// GetTrending retrieves trending manga
+// GetTrending retrieves the 7-day trending manga
 func (c *Client) GetTrending(ctx context.Context, limit int) ([]models.Manga, error) {
-	path := fmt.Sprintf("/manga/trending?limit=%d", limit)
+	path := fmt.Sprintf("/manga/trending?window=%s&limit=%d", models.TrendingWindowWeek, limit)
 	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// API returns RankedMangaResponse with pagination metadata
-	var result models.RankedMangaResponse
+	var result models.TrendingResponse
 	if err := decodeAPIResponse(resp, &result); err != nil {
 		return nil, err
 	}
 
-	// Extract manga from RankedManga entries
+	// Extract manga from ranked entries
 	manga := make([]models.Manga, len(result.Data))
-	for i, ranked := range result.Data {
-		manga[i] = ranked.Manga
+	for i, entry := range result.Data {
+		manga[i] = entry.Manga
 	}
 
 	return manga, nil
@@ -479,4 +479,53 @@ func (c *Client) GetTopManga(ctx context.Context, limit int) (*models.RankedMang
 	}
 
 	return &result, nil
-}
\ No newline at end of file
+}
+
+// GetLeaderboard retrieves a user leaderboard for a category and time window
+func (c *Client) GetLeaderboard(ctx context.Context, category, window string, limit int) (*models.LeaderboardResponse, error) {
+	path := fmt.Sprintf("/stats/leaderboard?category=%s&window=%s&limit=%d",
+		url.QueryEscape(category), url.QueryEscape(window), limit)
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.LeaderboardResponse
+	if err := decodeAPIResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetGlobalTimeSeries retrieves daily activity summed over every manga
+func (c *Client) GetGlobalTimeSeries(ctx context.Context, days int) (*models.StatsTimeSeries, error) {
+	path := fmt.Sprintf("/stats/daily?days=%d", days)
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.StatsTimeSeries
+	if err := decodeAPIResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetMangaTimeSeries retrieves daily activity for a manga
+func (c *Client) GetMangaTimeSeries(ctx context.Context, mangaID string, days int) (*models.StatsTimeSeries, error) {
+	path := fmt.Sprintf("/manga/%s/stats/daily?days=%d", mangaID, days)
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.StatsTimeSeries
+	if err := decodeAPIResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
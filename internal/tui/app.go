@@ -2,7 +2,10 @@ package tui
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,6 +14,7 @@ import (
 	"mangahub/internal/tui/focus"
 	"mangahub/internal/tui/grpc"
 	"mangahub/internal/tui/styles"
+	"mangahub/internal/tui/udp"
 	"mangahub/internal/tui/views"
 )
 
@@ -25,8 +29,20 @@ const (
 	ViewDetail
 	ViewChat
 	ViewStats
+	ViewNotifications
 )
 
+const (
+	toastDuration = 5 * time.Second
+	maxToasts     = 3
+)
+
+// toast is a notification popup shown above the status bar
+type toast struct {
+	id           int
+	notification udp.Notification
+}
+
 // Model is the root Bubble Tea model
 type Model struct {
 	// Configuration
@@ -38,6 +54,10 @@ type Model struct {
 	// gRPC client for streaming search
 	grpcClient *grpc.Client
 	
+	// UDP notification listener; nil when UDP is disabled
+	udpListener *udp.Listener
+	udpStarted  bool
+
 	// Focus manager
 	focusManager *focus.Manager
 
@@ -66,6 +86,12 @@ type Model struct {
 	detailModel    views.DetailModel
 	chatModel      views.ChatModel
 	statsModel     views.StatsModel
+	notificationsModel views.NotificationsModel
+
+	// Notification toasts and the count not yet seen in the panel
+	toasts      []toast
+	nextToastID int
+	unseen      int
 
 	// Error state
 	err error
@@ -83,10 +109,16 @@ func New(cfg *config.Config) *Model {
 		grpcClient = nil
 	}
 
+	var udpListener *udp.Listener
+	if cfg.Protocol.EnableUDP {
+		udpListener = udp.NewListener(cfg.GetUDPAddr(), "mangahub-tui")
+	}
+
 	m := &Model{
 		config:          cfg,
 		apiClient:       apiClient,
 		grpcClient:      grpcClient,
+		udpListener:     udpListener,
 		focusManager:    focusMgr,
 		currentView:     ViewAuth,
 		keys:            DefaultKeyMap(),
@@ -101,6 +133,7 @@ func New(cfg *config.Config) *Model {
 	m.detailModel = views.NewDetailModel(apiClient)
 	m.chatModel = views.NewChatModel(apiClient, cfg.GetWebSocketURL(), "")
 	m.statsModel = views.NewStatsModel(apiClient, "")
+	m.notificationsModel = views.NewNotificationsModel()
 
 	return m
 }
@@ -125,6 +158,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.detailModel, _ = m.detailModel.Update(msg)
 		m.chatModel, _ = m.chatModel.Update(msg)
 		m.statsModel, _ = m.statsModel.Update(msg)
+		m.notificationsModel, _ = m.notificationsModel.Update(msg)
 		return m, nil
 
 	case tea.KeyMsg:
@@ -132,6 +166,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			m.chatModel.Close()
+			if m.udpListener != nil {
+				m.udpListener.Close()
+			}
 			return m, tea.Quit
 
 		case key.Matches(msg, m.keys.Dashboard):
@@ -169,6 +206,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.statsModel.Init()
 			}
 
+		case key.Matches(msg, m.keys.Notifications):
+			if m.isAuthenticated && m.currentView != ViewAuth {
+				m.previousView = m.currentView
+				m.currentView = ViewNotifications
+				m.unseen = 0
+				return m, m.notificationsModel.Init()
+			}
+
 		}
 
 	// Handle auth messages
@@ -178,17 +223,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.token = msg.Token
 		m.apiClient.SetToken(msg.Token)
 		m.chatModel.SetToken(msg.Token)
+		if m.grpcClient != nil {
+			m.grpcClient.SetToken(msg.Token)
+		}
 		if msg.User != nil {
 			m.currentUserID = msg.User.ID
 			m.statsModel.SetUserID(msg.User.ID)
+			m.chatModel.SetUserID(msg.User.ID)
 		}
 		m.currentView = ViewDashboard
-		return m, m.dashboardModel.Init()
+		listen := m.startNotifications()
+		return m, tea.Batch(m.dashboardModel.Init(), listen)
 
 	case views.AuthErrorMsg:
 		m.err = msg.Err
 		return m, nil
 
+	// Handle UDP notifications (any view)
+	case views.NotificationReceivedMsg:
+		m.notificationsModel.Add(msg.Notification)
+		if m.currentView != ViewNotifications {
+			m.unseen++
+		}
+
+		m.nextToastID++
+		id := m.nextToastID
+		m.toasts = append(m.toasts, toast{id: id, notification: msg.Notification})
+		if len(m.toasts) > maxToasts {
+			m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+		}
+		dismiss := tea.Tick(toastDuration, func(time.Time) tea.Msg {
+			return toastExpiredMsg{id: id}
+		})
+		return m, tea.Batch(m.waitForNotification(), dismiss)
+
+	case toastExpiredMsg:
+		for i, t := range m.toasts {
+			if t.id == msg.id {
+				m.toasts = append(m.toasts[:i:i], m.toasts[i+1:]...)
+				break
+			}
+		}
+		return m, nil
+
 	// Handle navigation from views
 	case views.SelectMangaMsg:
 		m.previousView = m.currentView
@@ -219,6 +296,8 @@ func (m Model) updateCurrentView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.chatModel, cmd = m.chatModel.Update(msg)
 	case ViewStats:
 		m.statsModel, cmd = m.statsModel.Update(msg)
+	case ViewNotifications:
+		m.notificationsModel, cmd = m.notificationsModel.Update(msg)
 	}
 
 	return m, cmd
@@ -247,14 +326,16 @@ func (m Model) View() string {
 		content = m.chatModel.View()
 	case ViewStats:
 		content = m.statsModel.View()
+	case ViewNotifications:
+		content = m.notificationsModel.View()
 	default:
 		content = "Unknown view"
 	}
 
-	// Add status bar if authenticated
+	// Add toasts and status bar if authenticated
 	var statusBar string
 	if m.isAuthenticated {
-		statusBar = m.renderStatusBar()
+		statusBar = m.renderToasts() + m.renderStatusBar()
 	}
 
 	// Apply app style and combine
@@ -278,10 +359,15 @@ func (m Model) renderStatusBar() string {
 		viewName = "Chat"
 	case ViewStats:
 		viewName = "Statistics"
+	case ViewNotifications:
+		viewName = "Notifications"
 	}
 
 	left := styles.StatusBarActiveStyle.Render("● " + viewName)
-	right := styles.StatusBarStyle.Render("User: " + m.currentUser + " | 1-5 views | ? help | q quit")
+	if m.unseen > 0 {
+		left += " " + styles.BadgeWarningStyle.Render(fmt.Sprintf("🔔 %d", m.unseen))
+	}
+	right := styles.StatusBarStyle.Render("User: " + m.currentUser + " | 1-6 views | ? help | q quit")
 
 	// Calculate spacing
 	spacing := m.width - len(left) - len(right) - 4
@@ -296,8 +382,57 @@ func (m Model) renderStatusBar() string {
 	return left + spaces + right
 }
 
+// renderToasts renders active notification toasts, oldest first
+func (m Model) renderToasts() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, t := range m.toasts {
+		n := t.notification
+		text := views.NotificationIcon(n.Type) + " " + styles.Truncate(n.Message, 60)
+		if views.NotificationMangaID(n) != "" {
+			text += styles.HelpStyle.Render("  (6 to open)")
+		}
+		b.WriteString(styles.ToastStyle.Render(text))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// startNotifications subscribes to UDP notifications once per session
+func (m *Model) startNotifications() tea.Cmd {
+	if m.udpListener == nil || m.udpStarted {
+		return nil
+	}
+	if err := m.udpListener.Start(); err != nil {
+		log.Printf("UDP notifications unavailable: %v", err)
+		return nil
+	}
+	m.udpStarted = true
+	return m.waitForNotification()
+}
+
+// waitForNotification delivers the next UDP notification as a message
+func (m Model) waitForNotification() tea.Cmd {
+	listener := m.udpListener
+	return func() tea.Msg {
+		n, ok := <-listener.Notifications()
+		if !ok {
+			return nil
+		}
+		return views.NotificationReceivedMsg{Notification: n}
+	}
+}
+
 // Messages
 
+// toastExpiredMsg dismisses a toast
+type toastExpiredMsg struct {
+	id int
+}
+
 // AuthSuccessMsg is sent when authentication succeeds
 type AuthSuccessMsg struct {
 	Username string
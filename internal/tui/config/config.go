@@ -212,6 +212,14 @@ func (c *Config) GetGRPCAddr() string {
 	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.GRPC.Port)
 }
 
+// GetUDPAddr returns the computed UDP notification server address
+func (c *Config) GetUDPAddr() string {
+	if c.Server.UDP.Addr != "" {
+		return c.Server.UDP.Addr
+	}
+	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.UDP.Port)
+}
+
 // GetWebSocketURL returns the computed WebSocket URL
 func (c *Config) GetWebSocketURL() string {
 	if c.Server.WS.URL != "" {
@@ -7,6 +7,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	pb "mangahub/internal/protocols/grpc/pb"
 	"mangahub/pkg/models"
@@ -16,6 +17,7 @@ import (
 type Client struct {
 	conn   *grpc.ClientConn
 	client pb.MangaServiceClient
+	token  string
 }
 
 // NewClient creates a new gRPC client
@@ -31,6 +33,19 @@ func NewClient(addr string) (*Client, error) {
 	}, nil
 }
 
+// SetToken sets the JWT sent as "authorization" metadata on every call
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+// withAuth attaches the bearer token to the outgoing context
+func (c *Client) withAuth(ctx context.Context) context.Context {
+	if c.token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+}
+
 // Close closes the gRPC connection
 func (c *Client) Close() error {
 	return c.conn.Close()
@@ -47,7 +62,7 @@ func (c *Client) StreamSearch(ctx context.Context, query string, results chan<-
 		Offset: 0,
 	}
 
-	stream, err := c.client.StreamSearch(ctx, req)
+	stream, err := c.client.StreamSearch(c.withAuth(ctx), req)
 	if err != nil {
 		done <- fmt.Errorf("failed to start stream: %w", err)
 		return
@@ -108,7 +123,7 @@ func (c *Client) Search(ctx context.Context, query string, limit, offset int) ([
 		Offset: int32(offset),
 	}
 
-	resp, err := c.client.SearchManga(ctx, req)
+	resp, err := c.client.SearchManga(c.withAuth(ctx), req)
 	if err != nil {
 		return nil, 0, fmt.Errorf("search failed: %w", err)
 	}
@@ -29,6 +29,7 @@ type KeyMap struct {
 	Search    key.Binding
 	Chat      key.Binding
 	Stats     key.Binding
+	Notifications key.Binding
 
 	// Tab navigation
 	NextTab key.Binding
@@ -132,6 +133,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("5"),
 			key.WithHelp("5", "stats"),
 		),
+		Notifications: key.NewBinding(
+			key.WithKeys("6"),
+			key.WithHelp("6", "notifications"),
+		),
 
 		// Tab navigation
 		NextTab: key.NewBinding(
@@ -168,6 +173,6 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Up, k.Down, k.Left, k.Right},
 		{k.PageUp, k.PageDown, k.Enter, k.Back},
 		{k.Dashboard, k.Browse, k.Search, k.Chat},
-		{k.Stats, k.Refresh, k.Quit},
+		{k.Stats, k.Notifications, k.Refresh, k.Quit},
 	}
 }
@@ -248,6 +248,14 @@ var (
 				Foreground(lipgloss.Color(Pink)).
 				Bold(true).
 				Align(lipgloss.Center)
+
+	// Toast styles
+	ToastStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(Foreground)).
+			Background(lipgloss.Color(CurrentLine)).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color(Cyan)).
+			Padding(0, 1)
 )
 
 // Helper functions for common operations
@@ -308,6 +316,29 @@ func RenderProgressBar(current, total, width int) string {
 	return ProgressBarFilled.Render(filled) + ProgressBarEmpty.Render(empty)
 }
 
+// RenderSparkline renders values as a one-line bar chart scaled to the maximum
+func RenderSparkline(values []int) string {
+	bars := []rune("▁▂▃▄▅▆▇█")
+
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+
+	line := make([]rune, len(values))
+	for i, v := range values {
+		level := 0
+		if max > 0 && v > 0 {
+			level = 1 + v*(len(bars)-2)/max
+		}
+		line[i] = bars[level]
+	}
+
+	return ProgressBarFilled.Render(string(line))
+}
+
 // RenderKeyValue renders a key-value pair with styling
 func RenderKeyValue(key, value string) string {
 	return MetaKeyStyle.Render(key+":") + " " + MetaValueStyle.Render(value)
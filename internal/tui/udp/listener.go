@@ -0,0 +1,268 @@
+package udp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	udpProtocol "mangahub/internal/protocols/udp"
+)
+
+// Notification is a notification pushed by the server
+type Notification = udpProtocol.Notification
+
+const (
+	defaultHeartbeat = 30 * time.Second
+	readBufferSize   = 2048
+	queueSize        = 64
+)
+
+// Listener subscribes to the server's UDP notifications and keeps the
+// subscription alive. In unicast mode it opts into sequenced delivery and
+// recovers lost notifications with NACKs. In broadcast and multicast mode it
+// also listens where the server announces notifications.
+type Listener struct {
+	serverAddr    string
+	clientID      string
+	conn          *net.UDPConn
+	notifications chan Notification
+	receiver      *receiver
+
+	mu         sync.Mutex
+	subscribed bool
+	heartbeat  time.Duration
+	group      *net.UDPConn // Broadcast or multicast socket, once joined
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewListener creates a listener for the server at serverAddr (host:port)
+func NewListener(serverAddr, clientID string) *Listener {
+	return &Listener{
+		serverAddr:    serverAddr,
+		clientID:      clientID,
+		notifications: make(chan Notification, queueSize),
+		receiver:      newReceiver(),
+		heartbeat:     defaultHeartbeat,
+		stop:          make(chan struct{}),
+	}
+}
+
+// Start subscribes and begins receiving in the background
+func (l *Listener) Start() error {
+	addr, err := net.ResolveUDPAddr("udp", l.serverAddr)
+	if err != nil {
+		return fmt.Errorf("resolve udp server: %w", err)
+	}
+	conn, err := net.DialUDP("udp", nil, addr)
+	if err != nil {
+		return fmt.Errorf("dial udp server: %w", err)
+	}
+	l.conn = conn
+
+	l.send(l.subscribePacket())
+	go l.readLoop(conn, true)
+	go l.heartbeatLoop()
+	go l.nackLoop()
+	return nil
+}
+
+// Notifications delivers received notifications. When the consumer falls
+// behind, new notifications are dropped.
+func (l *Listener) Notifications() <-chan Notification {
+	return l.notifications
+}
+
+// Close unsubscribes and stops the listener
+func (l *Listener) Close() {
+	l.closeOnce.Do(func() {
+		close(l.stop)
+		if l.conn == nil {
+			return
+		}
+		l.send(udpProtocol.ControlPacket{Type: udpProtocol.PacketUnsubscribe})
+		l.conn.Close()
+
+		l.mu.Lock()
+		if l.group != nil {
+			l.group.Close()
+		}
+		l.mu.Unlock()
+	})
+}
+
+func (l *Listener) subscribePacket() udpProtocol.ControlPacket {
+	return udpProtocol.ControlPacket{Type: udpProtocol.PacketSubscribe, ClientID: l.clientID, Reliable: true}
+}
+
+// deliver queues notifications for the consumer
+func (l *Listener) deliver(notifications ...Notification) {
+	for _, notification := range notifications {
+		select {
+		case l.notifications <- notification:
+		default:
+		}
+	}
+}
+
+func (l *Listener) send(packet udpProtocol.ControlPacket) {
+	data, err := json.Marshal(packet)
+	if err != nil {
+		return
+	}
+	l.conn.Write(data)
+}
+
+// heartbeatLoop keeps the subscription alive, subscribing again until the
+// server answers (it may have restarted or not be up yet)
+func (l *Listener) heartbeatLoop() {
+	for {
+		l.mu.Lock()
+		interval := l.heartbeat
+		l.mu.Unlock()
+
+		select {
+		case <-l.stop:
+			return
+		case <-time.After(interval):
+		}
+
+		l.mu.Lock()
+		subscribed := l.subscribed
+		l.subscribed = false // Set again by the answer
+		l.mu.Unlock()
+
+		if subscribed {
+			l.send(udpProtocol.ControlPacket{Type: udpProtocol.PacketHeartbeat})
+		} else {
+			l.send(l.subscribePacket())
+		}
+	}
+}
+
+// nackLoop asks the server to resend missing sequenced notifications
+func (l *Listener) nackLoop() {
+	ticker := time.NewTicker(nackInterval / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case now := <-ticker.C:
+			seqs, released := l.receiver.nacks(now)
+			l.deliver(released...)
+			if len(seqs) > 0 {
+				l.send(udpProtocol.ControlPacket{Type: udpProtocol.PacketNack, Seqs: seqs})
+			}
+		}
+	}
+}
+
+// readLoop receives packets on one socket. Only the server socket carries
+// control answers.
+func (l *Listener) readLoop(conn *net.UDPConn, control bool) {
+	buf := make([]byte, readBufferSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			select {
+			case <-l.stop:
+				return
+			default:
+			}
+			// ICMP port unreachable while the server is down; keep waiting
+			if control {
+				time.Sleep(time.Second)
+				continue
+			}
+			return
+		}
+
+		var probe struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(buf[:n], &probe); err != nil {
+			continue
+		}
+
+		switch probe.Type {
+		case udpProtocol.PacketSubscribed, udpProtocol.PacketUnsubscribed, udpProtocol.PacketLost, udpProtocol.PacketError:
+			if control {
+				var packet udpProtocol.ControlPacket
+				if err := json.Unmarshal(buf[:n], &packet); err == nil {
+					l.handleControl(packet)
+				}
+			}
+		case udpProtocol.PacketData:
+			var packet udpProtocol.DataPacket
+			if control && json.Unmarshal(buf[:n], &packet) == nil {
+				l.deliver(l.receiver.data(packet)...)
+			}
+		default:
+			var notification Notification
+			if err := json.Unmarshal(buf[:n], &notification); err != nil {
+				continue
+			}
+			l.deliver(notification)
+		}
+	}
+}
+
+// handleControl applies the server's answer to a subscribe, heartbeat or NACK
+func (l *Listener) handleControl(packet udpProtocol.ControlPacket) {
+	switch packet.Type {
+	case udpProtocol.PacketSubscribed:
+		l.deliver(l.receiver.sync(packet.NextSeq)...)
+	case udpProtocol.PacketLost:
+		l.deliver(l.receiver.lost(packet.Seqs)...)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch packet.Type {
+	case udpProtocol.PacketSubscribed:
+		l.subscribed = true
+		l.heartbeat = defaultHeartbeat
+		if expires := time.Duration(packet.ExpiresIn) * time.Second; expires > 0 && expires/3 < l.heartbeat {
+			l.heartbeat = expires / 3
+		}
+		if l.group == nil && packet.Mode != udpProtocol.ModeUnicast {
+			l.joinGroup(packet)
+		}
+	case udpProtocol.PacketUnsubscribed:
+		// Expired on the server; the next heartbeat subscribes again
+		l.subscribed = false
+	}
+}
+
+// joinGroup listens where the server sends in broadcast or multicast mode
+func (l *Listener) joinGroup(packet udpProtocol.ControlPacket) {
+	var (
+		group *net.UDPConn
+		err   error
+	)
+	switch packet.Mode {
+	case udpProtocol.ModeMulticast:
+		var addr *net.UDPAddr
+		if addr, err = net.ResolveUDPAddr("udp", packet.Group); err == nil {
+			group, err = net.ListenMulticastUDP("udp", nil, addr)
+		}
+	case udpProtocol.ModeBroadcast:
+		group, err = net.ListenUDP("udp", &net.UDPAddr{Port: l.conn.RemoteAddr().(*net.UDPAddr).Port})
+	default:
+		return
+	}
+	if err != nil {
+		// Notifications cannot be received; stay subscribed for a later retry
+		return
+	}
+
+	l.group = group
+	go l.readLoop(group, false)
+}
@@ -0,0 +1,206 @@
+package udp
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	udpProtocol "mangahub/internal/protocols/udp"
+)
+
+const (
+	nackInterval    = 300 * time.Millisecond // Between NACKs for the same sequence number
+	maxNackAttempts = 3                      // Then the notification is skipped
+	maxReorder      = 128                    // Matches the server's history; older gaps are skipped
+)
+
+// reassembly collects the parts of one sequenced notification
+type reassembly struct {
+	parts    [][]byte
+	received int
+}
+
+// nackState tracks retransmission requests for one missing sequence number
+type nackState struct {
+	attempts int
+	last     time.Time
+}
+
+// receiver orders sequenced notifications, reassembles chunked ones and
+// decides which sequence numbers to NACK. Delivery is best effort: a
+// notification still missing after maxNackAttempts is skipped.
+type receiver struct {
+	mu       sync.Mutex
+	expected uint64 // Next sequence number to deliver; 0 until known
+	highest  uint64 // Highest sequence number seen or announced
+	partial  map[uint64]*reassembly
+	ready    map[uint64]*Notification // nil marks a skipped sequence number
+	missing  map[uint64]*nackState
+}
+
+func newReceiver() *receiver {
+	return &receiver{
+		partial: make(map[uint64]*reassembly),
+		ready:   make(map[uint64]*Notification),
+		missing: make(map[uint64]*nackState),
+	}
+}
+
+// data adds one part and returns the notifications now deliverable in order
+func (r *receiver) data(packet udpProtocol.DataPacket) []Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if packet.Seq == 0 || packet.Parts <= 0 || packet.Part < 0 || packet.Part >= packet.Parts {
+		return nil
+	}
+	if r.expected == 0 {
+		r.expected = packet.Seq
+	}
+	if packet.Seq < r.expected {
+		return nil // Duplicate of a delivered or skipped notification
+	}
+	if _, done := r.ready[packet.Seq]; done {
+		return nil
+	}
+	if packet.Seq > r.highest {
+		r.highest = packet.Seq
+	}
+
+	asm, ok := r.partial[packet.Seq]
+	if !ok || len(asm.parts) != packet.Parts {
+		asm = &reassembly{parts: make([][]byte, packet.Parts)}
+		r.partial[packet.Seq] = asm
+	}
+	if asm.parts[packet.Part] == nil {
+		asm.parts[packet.Part] = packet.Data
+		asm.received++
+	}
+
+	if asm.received == len(asm.parts) {
+		delete(r.partial, packet.Seq)
+		delete(r.missing, packet.Seq)
+
+		var payload []byte
+		for _, part := range asm.parts {
+			payload = append(payload, part...)
+		}
+		var notification Notification
+		if err := json.Unmarshal(payload, &notification); err == nil {
+			r.ready[packet.Seq] = &notification
+		} else {
+			r.skip(packet.Seq)
+		}
+	}
+
+	return r.flush()
+}
+
+// sync applies the server's next sequence number from a "subscribed" answer,
+// exposing notifications lost at the tail of the stream
+func (r *receiver) sync(nextSeq uint64) []Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if nextSeq == 0 {
+		return nil
+	}
+	if r.expected == 0 || nextSeq < r.expected {
+		// First answer, or the server forgot us and started over
+		r.reset(nextSeq)
+		return nil
+	}
+	if nextSeq-1 > r.highest {
+		r.highest = nextSeq - 1
+	}
+	return r.flush()
+}
+
+// lost skips notifications the server no longer holds
+func (r *receiver) lost(seqs []uint64) []Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, seq := range seqs {
+		r.skip(seq)
+	}
+	return r.flush()
+}
+
+// nacks returns the sequence numbers due for a NACK, and the notifications
+// released by giving up on the ones that ran out of attempts
+func (r *receiver) nacks(now time.Time) ([]uint64, []Notification) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.expected == 0 {
+		return nil, nil
+	}
+
+	// Gaps older than the server's history cannot be recovered
+	if r.highest >= r.expected+maxReorder {
+		for seq := r.expected; seq <= r.highest-maxReorder; seq++ {
+			r.skip(seq)
+		}
+	}
+
+	var due []uint64
+	for seq := r.expected; seq <= r.highest; seq++ {
+		if _, ok := r.ready[seq]; ok {
+			continue
+		}
+		state, ok := r.missing[seq]
+		if !ok {
+			// Give in-flight parts one interval before asking
+			state = &nackState{last: now}
+			r.missing[seq] = state
+			continue
+		}
+		if now.Sub(state.last) < nackInterval {
+			continue
+		}
+		if state.attempts >= maxNackAttempts {
+			r.skip(seq)
+			continue
+		}
+		state.attempts++
+		state.last = now
+		due = append(due, seq)
+	}
+
+	return due, r.flush()
+}
+
+// skip gives up on a sequence number; the caller flushes
+func (r *receiver) skip(seq uint64) {
+	delete(r.partial, seq)
+	delete(r.missing, seq)
+	if seq >= r.expected {
+		r.ready[seq] = nil
+	}
+}
+
+// flush returns ready notifications in sequence order, stopping at a gap
+func (r *receiver) flush() []Notification {
+	var out []Notification
+	for {
+		notification, ok := r.ready[r.expected]
+		if !ok {
+			return out
+		}
+		delete(r.ready, r.expected)
+		delete(r.missing, r.expected)
+		r.expected++
+		if notification != nil {
+			out = append(out, *notification)
+		}
+	}
+}
+
+func (r *receiver) reset(expected uint64) {
+	r.expected = expected
+	r.highest = expected - 1
+	r.partial = make(map[uint64]*reassembly)
+	r.ready = make(map[uint64]*Notification)
+	r.missing = make(map[uint64]*nackState)
+}
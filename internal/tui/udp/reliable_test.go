@@ -0,0 +1,266 @@
+package udp
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	udpProtocol "mangahub/internal/protocols/udp"
+)
+
+// packets splits a notification carrying message into parts data packets
+func packets(t *testing.T, seq uint64, message string, parts int) []udpProtocol.DataPacket {
+	t.Helper()
+
+	payload, err := json.Marshal(Notification{Type: "system", Message: message})
+	require.NoError(t, err)
+
+	size := (len(payload) + parts - 1) / parts
+	out := make([]udpProtocol.DataPacket, 0, parts)
+	for part := 0; part < parts; part++ {
+		end := min((part+1)*size, len(payload))
+		out = append(out, udpProtocol.DataPacket{
+			Type:  udpProtocol.PacketData,
+			Seq:   seq,
+			Part:  part,
+			Parts: parts,
+			Data:  payload[part*size : end],
+		})
+	}
+	return out
+}
+
+func packet(t *testing.T, seq uint64) udpProtocol.DataPacket {
+	return packets(t, seq, fmt.Sprintf("n%d", seq), 1)[0]
+}
+
+func seqRange(from, to uint64) []uint64 {
+	var out []uint64
+	for seq := from; seq <= to; seq++ {
+		out = append(out, seq)
+	}
+	return out
+}
+
+func messages(notifications []Notification) []string {
+	var out []string
+	for _, n := range notifications {
+		out = append(out, n.Message)
+	}
+	return out
+}
+
+func TestReceiverData(t *testing.T) {
+	tests := []struct {
+		name    string
+		packets func(t *testing.T) []udpProtocol.DataPacket
+		want    []string
+	}{
+		{
+			name: "in order",
+			packets: func(t *testing.T) []udpProtocol.DataPacket {
+				return []udpProtocol.DataPacket{packet(t, 1), packet(t, 2), packet(t, 3)}
+			},
+			want: []string{"n1", "n2", "n3"},
+		},
+		{
+			name: "out of order sequence numbers",
+			packets: func(t *testing.T) []udpProtocol.DataPacket {
+				return []udpProtocol.DataPacket{packet(t, 5), packet(t, 7), packet(t, 6)}
+			},
+			want: []string{"n5", "n6", "n7"},
+		},
+		{
+			name: "chunks out of order",
+			packets: func(t *testing.T) []udpProtocol.DataPacket {
+				p := packets(t, 1, "a long notification split in three", 3)
+				return []udpProtocol.DataPacket{p[2], p[0], p[1]}
+			},
+			want: []string{"a long notification split in three"},
+		},
+		{
+			name: "chunks of two notifications interleaved",
+			packets: func(t *testing.T) []udpProtocol.DataPacket {
+				a := packets(t, 1, "first notification", 2)
+				b := packets(t, 2, "second notification", 2)
+				return []udpProtocol.DataPacket{a[1], b[0], b[1], a[0]}
+			},
+			want: []string{"first notification", "second notification"},
+		},
+		{
+			name: "duplicates are ignored",
+			packets: func(t *testing.T) []udpProtocol.DataPacket {
+				p := packets(t, 2, "chunked", 2)
+				return []udpProtocol.DataPacket{packet(t, 1), packet(t, 1), p[0], p[0], p[1], p[1], packet(t, 1)}
+			},
+			want: []string{"n1", "chunked"},
+		},
+		{
+			name: "invalid packets are ignored",
+			packets: func(t *testing.T) []udpProtocol.DataPacket {
+				zero := packet(t, 1)
+				zero.Seq = 0
+				outOfRange := packet(t, 1)
+				outOfRange.Part = 1
+				noParts := packet(t, 1)
+				noParts.Parts = 0
+				return []udpProtocol.DataPacket{zero, outOfRange, noParts, packet(t, 1)}
+			},
+			want: []string{"n1"},
+		},
+		{
+			name: "undecodable notification is skipped",
+			packets: func(t *testing.T) []udpProtocol.DataPacket {
+				bad := udpProtocol.DataPacket{Type: udpProtocol.PacketData, Seq: 1, Parts: 1, Data: []byte("{")}
+				return []udpProtocol.DataPacket{packet(t, 2), bad}
+			},
+			want: []string{"n2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newReceiver()
+			var got []string
+			for _, p := range tt.packets(t) {
+				got = append(got, messages(r.data(p))...)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReceiverNacks(t *testing.T) {
+	start := time.Now()
+
+	tests := []struct {
+		name      string
+		received  []uint64
+		lost      []uint64
+		rounds    int // nacks calls, one interval apart
+		wantNacks [][]uint64
+		want      []string // Released by the last round
+	}{
+		{
+			name:      "no gap",
+			received:  []uint64{1, 2},
+			rounds:    2,
+			wantNacks: [][]uint64{nil, nil},
+		},
+		{
+			name:      "gap waits one interval before the first NACK",
+			received:  []uint64{1, 3, 5},
+			rounds:    2,
+			wantNacks: [][]uint64{nil, {2, 4}},
+		},
+		{
+			name:      "gap skipped after the last attempt",
+			received:  []uint64{1, 3},
+			rounds:    maxNackAttempts + 2,
+			wantNacks: [][]uint64{nil, {2}, {2}, {2}, nil},
+			want:      []string{"n3"},
+		},
+		{
+			name:      "lost sequence numbers are skipped at once",
+			received:  []uint64{1, 3, 5},
+			lost:      []uint64{2, 4},
+			rounds:    1,
+			wantNacks: [][]uint64{nil},
+		},
+		{
+			name:      "gaps beyond the server's history are skipped",
+			received:  []uint64{1, maxReorder + 3},
+			rounds:    2,
+			wantNacks: [][]uint64{nil, seqRange(4, maxReorder+2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newReceiver()
+			for _, seq := range tt.received {
+				r.data(packet(t, seq))
+			}
+			r.lost(tt.lost)
+
+			var released []string
+			for round := 0; round < tt.rounds; round++ {
+				due, ready := r.nacks(start.Add(time.Duration(round) * nackInterval))
+				assert.Equal(t, tt.wantNacks[round], due, "round %d", round)
+				released = messages(ready)
+			}
+			assert.Equal(t, tt.want, released)
+		})
+	}
+}
+
+func TestReceiverLostReleasesLaterNotifications(t *testing.T) {
+	r := newReceiver()
+	r.data(packet(t, 1))
+	r.data(packet(t, 3))
+	r.data(packet(t, 4))
+
+	assert.Equal(t, []string{"n3", "n4"}, messages(r.lost([]uint64{2})))
+	assert.Empty(t, r.data(packet(t, 2)), "late copy of a skipped notification delivered")
+}
+
+func TestReceiverSync(t *testing.T) {
+	tests := []struct {
+		name      string
+		received  []uint64
+		nextSeq   uint64
+		after     uint64 // Delivered next
+		want      []string
+		wantNacks []uint64
+	}{
+		{
+			name:    "first answer sets the start",
+			nextSeq: 10,
+			after:   10,
+			want:    []string{"n10"},
+		},
+		{
+			name:      "tail loss is NACKed",
+			received:  []uint64{1, 2},
+			nextSeq:   5,
+			wantNacks: []uint64{3, 4},
+		},
+		{
+			name:     "up to date",
+			received: []uint64{1, 2},
+			nextSeq:  3,
+			after:    3,
+			want:     []string{"n3"},
+		},
+		{
+			name:     "server restarted",
+			received: []uint64{40, 41},
+			nextSeq:  1,
+			after:    1,
+			want:     []string{"n1"},
+		},
+	}
+
+	start := time.Now()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newReceiver()
+			for _, seq := range tt.received {
+				r.data(packet(t, seq))
+			}
+			assert.Empty(t, r.sync(tt.nextSeq))
+
+			if tt.after != 0 {
+				assert.Equal(t, tt.want, messages(r.data(packet(t, tt.after))))
+			}
+
+			r.nacks(start)
+			due, _ := r.nacks(start.Add(nackInterval))
+			assert.Equal(t, tt.wantNacks, due)
+		})
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -21,14 +23,34 @@ import (
 // ChatMessage represents a chat message from the server
 // Matches the server's hub.go Message struct exactly
 type ChatMessage struct {
-	Type      string    `json:"type"`      // "message", "join", "leave", "history", "error"
-	UserID    string    `json:"user_id"`
-	Username  string    `json:"username"`
-	MangaID   string    `json:"manga_id"`
-	Content   string    `json:"content"`
-	Timestamp time.Time `json:"timestamp"`
+	Type      string         `json:"type"`      // "message", "join", "leave", "history", "presence", "typing_start", "typing_stop", "message_edited", "message_deleted", "reaction", "error"
+	ID        string         `json:"id,omitempty"`
+	UserID    string         `json:"user_id"`
+	Username  string         `json:"username"`
+	MangaID   string         `json:"manga_id"`
+	Content   string         `json:"content"`
+	Timestamp time.Time      `json:"timestamp"`
+	Users     []ChatMember   `json:"users,omitempty"` // Room members, for "presence"
+	EditedAt  *time.Time     `json:"edited_at,omitempty"`
+	Reactions map[string]int `json:"reactions,omitempty"` // emoji -> count
+	ReplyTo   string         `json:"reply_to,omitempty"`  // ID of the quoted message
+	Mentions  []string       `json:"mentions,omitempty"`  // Mentioned user IDs
+	Deleted   bool           `json:"-"`                   // Set by a later "message_deleted"
 }
 
+// ChatMember is a user connected to the current room
+type ChatMember struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Status   string `json:"status"` // "online", "away"
+}
+
+const (
+	chatTypingInterval = 2 * time.Second // Between typing_start sends while typing
+	chatTypingExpiry   = 6 * time.Second // Typing indicator shown this long without a refresh
+	chatMaxVisible     = 12              // Messages shown at once
+)
+
 // ChatRoom represents a manga chat room
 type ChatRoom struct {
 	ID    string
@@ -43,6 +65,7 @@ type ChatModel struct {
 
 	// Connection state
 	conn       *websocket.Conn
+	writeMu    *sync.Mutex // Serializes writes from concurrent commands
 	connected  bool
 	connecting bool
 	// connGen increments every time we (re)connect; used to ignore stale reads.
@@ -55,6 +78,17 @@ type ChatModel struct {
 	rooms            []ChatRoom
 	roomsLoaded      bool
 
+	// Presence
+	userID     string               // Own user, hidden from typing indicators
+	members    []ChatMember         // From the latest "presence" message
+	typing     map[string]time.Time // user ID -> indicator expiry
+	typists    map[string]string    // user ID -> username
+	typingSent time.Time            // Last typing_start we sent; zero when stopped
+
+	// Scrollback
+	historyLoading bool // A history_request is in flight
+	historyDone    bool // The server has no older messages
+
 	// UI state
 	messageInput  textinput.Model
 	inputFocused  bool
@@ -87,6 +121,9 @@ func NewChatModel(apiClient *api.Client, wsURL, token string) ChatModel {
 		messageInput: input,
 		messages:     make([]ChatMessage, 0),
 		rooms:        make([]ChatRoom, 0),
+		writeMu:      &sync.Mutex{},
+		typing:       make(map[string]time.Time),
+		typists:      make(map[string]string),
 	}
 }
 
@@ -138,20 +175,95 @@ func (m ChatModel) Update(msg tea.Msg) (ChatModel, tea.Cmd) {
 
 	case ChatMessageReceivedMsg:
 		if msg.Gen != m.connGen { return m, nil }
+
+		switch msg.Type {
+		case "presence":
+			m.members = msg.Users
+			return m, m.listenForMessages(msg.Gen)
+
+		case "typing_start":
+			if msg.UserID == m.userID {
+				return m, m.listenForMessages(msg.Gen)
+			}
+			m.typing[msg.UserID] = time.Now().Add(chatTypingExpiry)
+			m.typists[msg.UserID] = msg.Username
+			expire := tea.Tick(chatTypingExpiry, func(time.Time) tea.Msg {
+				return ChatTypingExpiredMsg{Gen: msg.Gen}
+			})
+			return m, tea.Batch(m.listenForMessages(msg.Gen), expire)
+
+		case "typing_stop":
+			m.clearTyping(msg.UserID)
+			return m, m.listenForMessages(msg.Gen)
+
+		case "history_page":
+			// Older messages go above the oldest one we have
+			m.historyLoading = false
+			m.historyDone = !msg.HasMore
+			at := m.oldestChatMessage()
+			if at < 0 {
+				at = len(m.messages)
+			}
+			older := append(msg.History, m.messages[at:]...)
+			m.messages = append(m.messages[:at:at], older...)
+			return m, m.listenForMessages(msg.Gen)
+
+		case "error":
+			// Possibly the answer to our history_request
+			m.historyLoading = false
+
+		case "message_edited", "message_deleted", "reaction":
+			// Update the message in place; ones older than our list are ignored
+			if i := m.findMessage(msg.ID); i >= 0 {
+				switch msg.Type {
+				case "message_edited":
+					m.messages[i].Content = msg.Content
+					m.messages[i].EditedAt = msg.EditedAt
+					m.messages[i].Mentions = msg.Mentions
+				case "message_deleted":
+					m.messages[i].Deleted = true
+					m.messages[i].Content = ""
+					m.messages[i].Reactions = nil
+				case "reaction":
+					m.messages[i].Reactions = msg.Reactions
+				}
+			}
+			return m, m.listenForMessages(msg.Gen)
+
+		case "message", "leave":
+			// Their message arrived or they left: no longer typing
+			m.clearTyping(msg.UserID)
+		}
+
 		// Add the received message to our list
 		m.messages = append(m.messages, ChatMessage{
 			Type:      msg.Type,
+			ID:        msg.ID,
 			UserID:    msg.UserID,
 			Username:  msg.Username,
 			MangaID:   msg.MangaID,
 			Content:   msg.Content,
 			Timestamp: msg.Timestamp,
+			EditedAt:  msg.EditedAt,
+			Reactions: msg.Reactions,
+			ReplyTo:   msg.ReplyTo,
+			Mentions:  msg.Mentions,
 		})
 		// Auto-scroll to latest
 		m.scrollOffset = 0
 		// Continue listening
 		return m, m.listenForMessages(msg.Gen)
 
+	case ChatTypingExpiredMsg:
+		if msg.Gen != m.connGen { return m, nil }
+		now := time.Now()
+		for userID, until := range m.typing {
+			if !now.Before(until) {
+				m.clearTyping(userID)
+			}
+		}
+		return m, nil
+
 	case ChatRoomsLoadedMsg:
 		m.rooms = msg.Rooms
 		m.roomsLoaded = true
@@ -234,6 +346,10 @@ func (m ChatModel) handleKeyPress(msg tea.KeyMsg) (ChatModel, tea.Cmd) {
 		if m.inputFocused && m.messageInput.Value() != "" && m.connected {
 			content := m.messageInput.Value()
 			m.messageInput.SetValue("")
+			if strings.HasPrefix(content, "/") {
+				return m.runCommand(content)
+			}
+			m.typingSent = time.Time{} // The server stops our indicator with the message
 			return m, m.sendMessage(content)
 		}
 		return m, nil
@@ -251,10 +367,13 @@ func (m ChatModel) handleKeyPress(msg tea.KeyMsg) (ChatModel, tea.Cmd) {
 		return m, nil
 
 	case key.Matches(msg, key.NewBinding(key.WithKeys("pgup"))):
-		// Scroll up
+		// Scroll up, loading older messages once past the top
 		if m.scrollOffset < len(m.messages)-1 {
 			m.scrollOffset++
 		}
+		if len(m.messages)-chatMaxVisible-m.scrollOffset <= 0 {
+			return m, m.loadOlder()
+		}
 		return m, nil
 
 	case key.Matches(msg, key.NewBinding(key.WithKeys("pgdown"))):
@@ -268,9 +387,166 @@ func (m ChatModel) handleKeyPress(msg tea.KeyMsg) (ChatModel, tea.Cmd) {
 	// Pass to text input
 	var cmd tea.Cmd
 	m.messageInput, cmd = m.messageInput.Update(msg)
+	return m, tea.Batch(cmd, m.updateTyping())
+}
+
+// updateTyping tells the room whether we are typing, sending typing_start at
+// most every chatTypingInterval and typing_stop once the input is cleared
+func (m *ChatModel) updateTyping() tea.Cmd {
+	if !m.connected || !m.inputFocused {
+		return nil
+	}
+
+	if m.messageInput.Value() == "" {
+		if m.typingSent.IsZero() {
+			return nil
+		}
+		m.typingSent = time.Time{}
+		return m.sendTyping(false)
+	}
+
+	if time.Since(m.typingSent) < chatTypingInterval {
+		return nil
+	}
+	m.typingSent = time.Now()
+	return m.sendTyping(true)
+}
+
+// runCommand handles /reply, /edit, /delete and /react typed into the input
+func (m ChatModel) runCommand(input string) (ChatModel, tea.Cmd) {
+	name, arg, _ := strings.Cut(strings.TrimSpace(input), " ")
+	arg = strings.TrimSpace(arg)
+
+	var cmd tea.Cmd
+	var problem string
+	switch name {
+	case "/reply":
+		i := m.lastChatMessage()
+		switch {
+		case arg == "":
+			problem = "Usage: /reply <text>"
+		case i < 0:
+			problem = "No message to reply to"
+		default:
+			cmd = m.sendFrame(map[string]string{"content": arg, "reply_to": m.messages[i].ID})
+		}
+
+	case "/edit":
+		i := m.lastOwnMessage()
+		switch {
+		case arg == "":
+			problem = "Usage: /edit <new text>"
+		case i < 0:
+			problem = "You have no message to edit"
+		default:
+			cmd = m.sendFrame(map[string]string{"type": "edit", "id": m.messages[i].ID, "content": arg})
+		}
+
+	case "/delete":
+		if i := m.lastOwnMessage(); i >= 0 {
+			cmd = m.sendFrame(map[string]string{"type": "delete", "id": m.messages[i].ID})
+		} else {
+			problem = "You have no message to delete"
+		}
+
+	case "/react":
+		i := m.lastChatMessage()
+		switch {
+		case arg == "":
+			problem = "Usage: /react <emoji>"
+		case i < 0:
+			problem = "No message to react to"
+		default:
+			cmd = m.sendFrame(map[string]string{"type": "react", "id": m.messages[i].ID, "emoji": arg})
+		}
+
+	default:
+		problem = "Unknown command " + name + " (try /reply, /edit, /delete or /react)"
+	}
+
+	// Stop our typing indicator, which the server only clears on a message
+	if !m.typingSent.IsZero() {
+		m.typingSent = time.Time{}
+		cmd = tea.Batch(cmd, m.sendTyping(false))
+	}
+
+	if problem != "" {
+		m.messages = append(m.messages, ChatMessage{
+			Type:      "system",
+			Username:  "System",
+			Content:   problem,
+			Timestamp: time.Now(),
+		})
+		m.scrollOffset = 0
+	}
 	return m, cmd
 }
 
+// loadOlder asks the server for the messages before our oldest one
+func (m *ChatModel) loadOlder() tea.Cmd {
+	if !m.connected || m.historyLoading || m.historyDone {
+		return nil
+	}
+	i := m.oldestChatMessage()
+	if i < 0 {
+		return nil
+	}
+	m.historyLoading = true
+	return m.sendFrame(map[string]string{"type": "history_request", "before": m.messages[i].ID})
+}
+
+// oldestChatMessage returns the index of the oldest stored chat message, or -1
+func (m ChatModel) oldestChatMessage() int {
+	for i, msg := range m.messages {
+		if msg.ID != "" && (msg.Type == "message" || msg.Type == "history") {
+			return i
+		}
+	}
+	return -1
+}
+
+// findMessage returns the index of the chat message with the given ID, or -1
+func (m ChatModel) findMessage(id string) int {
+	if id == "" {
+		return -1
+	}
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if m.messages[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastChatMessage returns the index of the newest chat message, or -1
+func (m ChatModel) lastChatMessage() int {
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		msg := m.messages[i]
+		if msg.ID != "" && !msg.Deleted && (msg.Type == "message" || msg.Type == "history") {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastOwnMessage returns the index of our newest chat message, or -1
+func (m ChatModel) lastOwnMessage() int {
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		msg := m.messages[i]
+		if msg.ID != "" && !msg.Deleted && msg.UserID == m.userID && m.userID != "" &&
+			(msg.Type == "message" || msg.Type == "history") {
+			return i
+		}
+	}
+	return -1
+}
+
+// clearTyping removes a user's typing indicator
+func (m *ChatModel) clearTyping(userID string) {
+	delete(m.typing, userID)
+	delete(m.typists, userID)
+}
+
 // startConnect updates UI state before dialing.
 func (m ChatModel) startConnect(reason string) (ChatModel, tea.Cmd) {
 	m.connGen++
@@ -278,6 +554,12 @@ func (m ChatModel) startConnect(reason string) (ChatModel, tea.Cmd) {
 	m.connecting = true
 	m.connected = false
 	m.lastError = nil
+	m.members = nil
+	m.typing = make(map[string]time.Time)
+	m.typists = make(map[string]string)
+	m.typingSent = time.Time{}
+	m.historyLoading = false
+	m.historyDone = false
 	if reason != "" {
 		m.messages = append(m.messages, ChatMessage{
 			Type:      "system",
@@ -309,6 +591,10 @@ func (m ChatModel) View() string {
 	b.WriteString(m.renderMessages())
 	b.WriteString("\n")
 
+	// Who is typing
+	b.WriteString(m.renderTyping())
+	b.WriteString("\n")
+
 	// Divider
 	dividerWidth := min(m.width-4, 70)
 	b.WriteString(styles.RenderDivider(dividerWidth))
@@ -348,12 +634,67 @@ func (m ChatModel) renderHeader() string {
 		b.WriteString(styles.ErrorStyle.Render("○ Disconnected"))
 	}
 
-	// User count (if we had it)
+	// Members online
+	if m.connected && len(m.members) > 0 {
+		b.WriteString("  ")
+		b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("👥 %d online", len(m.members))))
+	}
 	b.WriteString("\n")
 
+	if m.connected && len(m.members) > 0 {
+		b.WriteString(m.renderMembers())
+		b.WriteString("\n")
+	}
+
 	return b.String()
 }
 
+// renderMembers lists the room's members, away ones dimmed
+func (m ChatModel) renderMembers() string {
+	const maxShown = 8
+
+	names := make([]string, 0, maxShown)
+	for i, member := range m.members {
+		if i == maxShown {
+			names = append(names, styles.HelpStyle.Render(fmt.Sprintf("+%d more", len(m.members)-maxShown)))
+			break
+		}
+		if member.Status == "away" {
+			names = append(names, styles.HelpStyle.Render(member.Username+" (away)"))
+		} else {
+			names = append(names, styles.SuccessStyle.Render("● ")+styles.MetaKeyStyle.Render(member.Username))
+		}
+	}
+
+	return "  " + strings.Join(names, "  ")
+}
+
+// renderTyping renders "X is typing…" for other members
+func (m ChatModel) renderTyping() string {
+	// Expiry ticks only arrive while this view is active, so check here too
+	now := time.Now()
+	names := make([]string, 0, len(m.typists))
+	for userID, name := range m.typists {
+		if now.Before(m.typing[userID]) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var text string
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		text = names[0] + " is typing…"
+	case 2:
+		text = names[0] + " and " + names[1] + " are typing…"
+	default:
+		text = "Several people are typing…"
+	}
+	return styles.HelpStyle.Render("  " + text)
+}
+
 // renderMessages renders the message list with proper formatting
 func (m ChatModel) renderMessages() string {
 	if len(m.messages) == 0 {
@@ -363,8 +704,7 @@ func (m ChatModel) renderMessages() string {
 	var b strings.Builder
 
 	// Calculate visible messages (show last N)
-	maxVisible := 12
-	startIdx := len(m.messages) - maxVisible - m.scrollOffset
+	startIdx := len(m.messages) - chatMaxVisible - m.scrollOffset
 	if startIdx < 0 {
 		startIdx = 0
 	}
@@ -377,9 +717,16 @@ func (m ChatModel) renderMessages() string {
 	}
 
 	// Scroll indicator
-	if startIdx > 0 {
+	switch {
+	case startIdx > 0:
 		b.WriteString(styles.HelpStyle.Render("  ↑ " + fmt.Sprintf("%d more messages", startIdx)))
 		b.WriteString("\n")
+	case m.historyLoading:
+		b.WriteString(styles.HelpStyle.Render("  ↑ Loading older messages…"))
+		b.WriteString("\n")
+	case m.historyDone:
+		b.WriteString(styles.HelpStyle.Render("  ━━━ Beginning of chat ━━━"))
+		b.WriteString("\n")
 	}
 
 	for i := startIdx; i < endIdx; i++ {
@@ -430,19 +777,106 @@ func (m ChatModel) renderSingleMessage(msg ChatMessage) string {
 
 	case "history":
 		// Historical message (slightly dimmed)
-		b.WriteString("  ")
+		b.WriteString(m.renderReplyQuote(msg))
+		b.WriteString(m.renderMentionMark(msg))
 		b.WriteString(styles.HelpStyle.Render("[" + timeStr + "] "))
 		b.WriteString(styles.MetaKeyStyle.Render(msg.Username))
-		b.WriteString(styles.HelpStyle.Render(": " + msg.Content))
+		if msg.Deleted {
+			b.WriteString(styles.HelpStyle.Render(": (message deleted)"))
+		} else {
+			b.WriteString(styles.HelpStyle.Render(": " + msg.Content))
+		}
+		b.WriteString(renderMessageExtras(msg))
 
 	default: // "message" or regular
 		// Regular chat message
-		b.WriteString("  ")
+		b.WriteString(m.renderReplyQuote(msg))
+		b.WriteString(m.renderMentionMark(msg))
 		b.WriteString(styles.HelpStyle.Render("[" + timeStr + "] "))
 		b.WriteString(styles.HighlightStyle.Render(msg.Username))
-		b.WriteString(styles.CardContentStyle.Render(": " + msg.Content))
+		switch {
+		case msg.Deleted:
+			b.WriteString(styles.HelpStyle.Render(": (message deleted)"))
+		case m.mentionsMe(msg):
+			b.WriteString(styles.WarningStyle.Render(": " + msg.Content))
+		default:
+			b.WriteString(styles.CardContentStyle.Render(": " + msg.Content))
+		}
+		b.WriteString(renderMessageExtras(msg))
+	}
+
+	return b.String()
+}
+
+// mentionsMe reports whether a message mentions (or replies to) the current user
+func (m ChatModel) mentionsMe(msg ChatMessage) bool {
+	if m.userID == "" || msg.Deleted {
+		return false
+	}
+	for _, userID := range msg.Mentions {
+		if userID == m.userID {
+			return true
+		}
+	}
+	return false
+}
+
+// renderMentionMark indents a message, marking ones that mention us
+func (m ChatModel) renderMentionMark(msg ChatMessage) string {
+	if m.mentionsMe(msg) {
+		return styles.WarningStyle.Render("@ ")
+	}
+	return "  "
+}
+
+// renderReplyQuote renders the start of the message a reply quotes
+func (m ChatModel) renderReplyQuote(msg ChatMessage) string {
+	if msg.ReplyTo == "" || msg.Deleted {
+		return ""
+	}
+
+	const maxQuote = 40
+	quote := "an earlier message"
+	if i := m.findMessage(msg.ReplyTo); i >= 0 {
+		quoted := m.messages[i]
+		text := quoted.Content
+		if quoted.Deleted {
+			text = "(message deleted)"
+		}
+		if runes := []rune(text); len(runes) > maxQuote {
+			text = string(runes[:maxQuote]) + "…"
+		}
+		quote = quoted.Username + ": " + text
+	}
+	return styles.HelpStyle.Render("    ↪ "+quote) + "\n"
+}
+
+// renderMessageExtras renders the edited marker and reaction counts
+func renderMessageExtras(msg ChatMessage) string {
+	if msg.Deleted {
+		return ""
+	}
+
+	var b strings.Builder
+	if msg.EditedAt != nil {
+		b.WriteString(styles.HelpStyle.Render(" (edited)"))
 	}
 
+	emojis := make([]string, 0, len(msg.Reactions))
+	for emoji, count := range msg.Reactions {
+		if count > 0 {
+			emojis = append(emojis, emoji)
+		}
+	}
+	if len(emojis) > 0 {
+		sort.Strings(emojis)
+		counts := make([]string, len(emojis))
+		for i, emoji := range emojis {
+			counts[i] = fmt.Sprintf("%s %d", emoji, msg.Reactions[emoji])
+		}
+		b.WriteString("\n      ")
+		b.WriteString(styles.HelpStyle.Render(strings.Join(counts, "  ")))
+	}
 	return b.String()
 }
 
@@ -508,6 +942,7 @@ func (m ChatModel) renderHelp() string {
 		"Tab rooms",
 		"Ctrl+R reconnect",
 		"PgUp/PgDn scroll",
+		"/reply /edit /delete /react",
 	}
 
 	return styles.HelpStyle.Render(strings.Join(parts, " • "))
@@ -563,16 +998,23 @@ func (m ChatModel) connect(gen int64) tea.Cmd {
 
 // sendMessage sends a chat message through WebSocket
 func (m ChatModel) sendMessage(content string) tea.Cmd {
+	// Server expects: {"content": "message text"}
+	return m.sendFrame(map[string]string{
+		"content": content,
+	})
+}
+
+// sendFrame sends a client frame: a message, or an edit, delete or react
+// naming a message by "id"
+func (m ChatModel) sendFrame(payload map[string]string) tea.Cmd {
 	gen := m.connGen
 	return func() tea.Msg {
 		if m.conn == nil {
 			return ChatErrorMsg{Gen: gen, Err: fmt.Errorf("not connected")}
 		}
 
-		// Server expects: {"content": "message text"}
-		payload := map[string]string{
-			"content": content,
-		}
+		m.writeMu.Lock()
+		defer m.writeMu.Unlock()
 
 		// Server enforces max 1KB per message; validate before sending.
 		data, err := json.Marshal(payload)
@@ -593,6 +1035,29 @@ func (m ChatModel) sendMessage(content string) tea.Cmd {
 	}
 }
 
+// sendTyping sends typing_start or typing_stop. Failures are ignored: the
+// indicator is cosmetic and a broken connection shows up on the next read.
+func (m ChatModel) sendTyping(active bool) tea.Cmd {
+	conn := m.conn
+	msgType := "typing_stop"
+	if active {
+		msgType = "typing_start"
+	}
+	return func() tea.Msg {
+		if conn == nil {
+			return nil
+		}
+		data, err := json.Marshal(map[string]string{"type": msgType})
+		if err != nil {
+			return nil
+		}
+		m.writeMu.Lock()
+		defer m.writeMu.Unlock()
+		_ = conn.WriteMessage(websocket.TextMessage, data)
+		return nil
+	}
+}
+
 // listenForMessages reads messages from WebSocket
 func (m ChatModel) listenForMessages(gen int64) tea.Cmd {
 	return func() tea.Msg {
@@ -603,12 +1068,20 @@ func (m ChatModel) listenForMessages(gen int64) tea.Cmd {
 		// Read message from server
 		// Server sends: {type, user_id, username, manga_id, content, timestamp}
 		var msg struct {
-			Type      string    `json:"type"`
-			UserID    string    `json:"user_id"`
-			Username  string    `json:"username"`
-			MangaID   string    `json:"manga_id"`
-			Content   string    `json:"content"`
-			Timestamp time.Time `json:"timestamp"`
+			Type      string         `json:"type"`
+			ID        string         `json:"id"`
+			UserID    string         `json:"user_id"`
+			Username  string         `json:"username"`
+			MangaID   string         `json:"manga_id"`
+			Content   string         `json:"content"`
+			Timestamp time.Time      `json:"timestamp"`
+			Users     []ChatMember   `json:"users"`
+			EditedAt  *time.Time     `json:"edited_at"`
+			Reactions map[string]int `json:"reactions"`
+			ReplyTo   string         `json:"reply_to"`
+			Mentions  []string       `json:"mentions"`
+			Messages  []ChatMessage  `json:"messages"` // For "history_page", oldest first
+			HasMore   bool           `json:"has_more"`
 		}
 
 		if err := m.conn.ReadJSON(&msg); err != nil {
@@ -639,6 +1112,14 @@ func (m ChatModel) listenForMessages(gen int64) tea.Cmd {
 			MangaID:   msg.MangaID,
 			Content:   content,
 			Timestamp: timestamp,
+			Users:     msg.Users,
+			ID:        msg.ID,
+			EditedAt:  msg.EditedAt,
+			Reactions: msg.Reactions,
+			ReplyTo:   msg.ReplyTo,
+			Mentions:  msg.Mentions,
+			History:   msg.Messages,
+			HasMore:   msg.HasMore,
 		}
 	}
 }
@@ -683,6 +1164,12 @@ func (m *ChatModel) SetToken(token string) {
 	m.token = token
 }
 
+// SetUserID sets the logged-in user, whose own typing is not shown and whose
+// mentions are highlighted
+func (m *ChatModel) SetUserID(userID string) {
+	m.userID = userID
+}
+
 // Helper function
 func min(a, b int) int {
 	if a < b {
@@ -732,8 +1219,19 @@ type ChatMessageReceivedMsg struct {
 	MangaID   string
 	Content   string
 	Timestamp time.Time
+	Users     []ChatMember // For "presence"
+	ID        string       // Chat message ID; the target of edits, deletes and reactions
+	EditedAt  *time.Time
+	Reactions map[string]int
+	ReplyTo   string
+	Mentions  []string // User IDs; highlighted when they include ours
+	History   []ChatMessage // For "history_page", oldest first
+	HasMore   bool          // For "history_page": older messages remain
 }
 
+// ChatTypingExpiredMsg asks the view to drop stale typing indicators
+type ChatTypingExpiredMsg struct{ Gen int64 }
+
 // ChatErrorMsg carries an error
 type ChatErrorMsg struct {
 	Gen int64
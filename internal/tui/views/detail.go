@@ -30,6 +30,7 @@ type DetailModel struct {
 	// Current manga
 	mangaID       string
 	manga         *models.Manga
+	activity      *models.StatsTimeSeries
 	
 	// Comments
 	comments      []models.Comment
@@ -76,15 +77,16 @@ func (m *DetailModel) SetManga(mangaID string) tea.Cmd {
 	m.mangaID = mangaID
 	m.loading = true
 	m.manga = nil
+	m.activity = nil
 	m.comments = nil
 	m.selectedTab = TabInfo
-	return tea.Batch(m.loadManga(), m.loadComments())
+	return tea.Batch(m.loadManga(), m.loadComments(), m.loadActivity())
 }
 
 // Init initializes the model
 func (m DetailModel) Init() tea.Cmd {
 	if m.mangaID != "" {
-		return tea.Batch(m.loadManga(), m.loadComments())
+		return tea.Batch(m.loadManga(), m.loadComments(), m.loadActivity())
 	}
 	return nil
 }
@@ -159,7 +161,7 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 				
 			case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
 				m.loading = true
-				return m, tea.Batch(m.loadManga(), m.loadComments())
+				return m, tea.Batch(m.loadManga(), m.loadComments(), m.loadActivity())
 				
 			case key.Matches(msg, key.NewBinding(key.WithKeys("n", "pgdown"))):
 				if m.selectedTab == TabComments && m.hasMoreComments() {
@@ -176,6 +178,13 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 		m.manga = msg.Manga
 		return m, nil
 
+	case TimeSeriesLoadedMsg:
+		// Ignore series for a previously viewed manga
+		if msg.Series.MangaID == m.mangaID {
+			m.activity = msg.Series
+		}
+		return m, nil
+
 	case CommentsLoadedMsg:
 		m.loading = false
 		m.comments = msg.Comments
@@ -266,6 +275,14 @@ func (m DetailModel) renderInfo() string {
 	b.WriteString(styles.RenderKeyValue("Status", m.renderStatus(m.manga.Status)))
 	b.WriteString("\n\n")
 
+	// Activity over time
+	if m.activity != nil {
+		b.WriteString(styles.MetaKeyStyle.Render(fmt.Sprintf("Activity (%d days):", m.activity.Days)))
+		b.WriteString("\n")
+		b.WriteString(renderTimeSeries(m.activity))
+		b.WriteString("\n\n")
+	}
+
 	// Description
 	b.WriteString(styles.MetaKeyStyle.Render("Description:"))
 	b.WriteString("\n")
@@ -388,6 +405,19 @@ func (m DetailModel) loadComments() tea.Cmd {
 	}
 }
 
+// loadActivity loads the manga's daily activity series
+func (m DetailModel) loadActivity() tea.Cmd {
+	mangaID := m.mangaID
+	return func() tea.Msg {
+		ctx := context.Background()
+		series, err := m.apiClient.GetMangaTimeSeries(ctx, mangaID, 30)
+		if err != nil {
+			return DetailErrorMsg{Err: err}
+		}
+		return TimeSeriesLoadedMsg{Series: series}
+	}
+}
+
 // submitComment submits a new comment
 func (m DetailModel) submitComment() tea.Cmd {
 	return func() tea.Msg {
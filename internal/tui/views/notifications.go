@@ -0,0 +1,168 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"mangahub/internal/tui/styles"
+	"mangahub/internal/tui/udp"
+)
+
+// maxNotifications bounds the notifications kept in the panel
+const maxNotifications = 100
+
+// NotificationsModel lists UDP notifications received this session
+type NotificationsModel struct {
+	notifications []udp.Notification // Newest first
+	cursor        int
+
+	// Window size
+	width  int
+	height int
+}
+
+// NewNotificationsModel creates an empty notifications panel
+func NewNotificationsModel() NotificationsModel {
+	return NotificationsModel{}
+}
+
+// Init initializes the panel
+func (m NotificationsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Add records a received notification
+func (m *NotificationsModel) Add(notification udp.Notification) {
+	if notification.Timestamp.IsZero() {
+		notification.Timestamp = time.Now()
+	}
+	m.notifications = append([]udp.Notification{notification}, m.notifications...)
+	if len(m.notifications) > maxNotifications {
+		m.notifications = m.notifications[:maxNotifications]
+	}
+	// Keep the cursor on the same notification
+	if m.cursor > 0 {
+		m.cursor++
+		m.clampCursor()
+	}
+}
+
+// Update handles messages
+func (m NotificationsModel) Update(msg tea.Msg) (NotificationsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("j", "down"))):
+			m.cursor++
+			m.clampCursor()
+			return m, nil
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("k", "up"))):
+			m.cursor--
+			m.clampCursor()
+			return m, nil
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+			m.notifications = nil
+			m.cursor = 0
+			return m, nil
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			if m.cursor < len(m.notifications) {
+				if mangaID := NotificationMangaID(m.notifications[m.cursor]); mangaID != "" {
+					return m, func() tea.Msg {
+						return SelectMangaMsg{MangaID: mangaID}
+					}
+				}
+			}
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the panel
+func (m NotificationsModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(styles.TitleStyle.Render("🔔 Notifications"))
+	b.WriteString("\n\n")
+
+	if len(m.notifications) == 0 {
+		b.WriteString(styles.InfoStyle.Render("No notifications yet"))
+	}
+
+	for i, n := range m.notifications {
+		if i >= 15 { // Limit display
+			break
+		}
+
+		prefix := "  "
+		style := styles.ListItemStyle
+		if i == m.cursor {
+			prefix = "▸ "
+			style = styles.ListItemSelectedStyle
+		}
+
+		when := styles.ListItemDescStyle.Render(n.Timestamp.Local().Format("15:04:05"))
+		line := fmt.Sprintf("%s%s %s %s", prefix, when, NotificationIcon(n.Type),
+			styles.Truncate(n.Message, 60))
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.HelpStyle.Render("↑/↓ navigate • Enter open manga • c clear"))
+
+	return b.String()
+}
+
+// clampCursor keeps cursor in bounds
+func (m *NotificationsModel) clampCursor() {
+	max := len(m.notifications) - 1
+	if max < 0 {
+		max = 0
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	if m.cursor > max {
+		m.cursor = max
+	}
+}
+
+// NotificationIcon returns the icon for a notification type
+func NotificationIcon(notificationType string) string {
+	switch notificationType {
+	case "manga_update":
+		return "📖"
+	case "system":
+		return "📢"
+	default:
+		return "🔔"
+	}
+}
+
+// NotificationMangaID returns the manga a notification links to, if any
+func NotificationMangaID(n udp.Notification) string {
+	if n.Type == "manga_update" && n.MangaID != nil {
+		return *n.MangaID
+	}
+	return ""
+}
+
+// Messages
+
+// NotificationReceivedMsg carries a notification from the UDP listener
+type NotificationReceivedMsg struct {
+	Notification udp.Notification
+}
@@ -20,16 +20,20 @@ type StatsModel struct {
 	// Data
 	userStats     *models.UserStatistics
 	leaderboard   []models.RankedManga
+	userBoard     *models.LeaderboardResponse
+	activity      *models.StatsTimeSeries
 	
 	// State
 	loading       bool
 	err           error
-	selectedTab   int // 0 = my stats, 1 = leaderboard
+	selectedTab   int // 0 = my stats, 1 = top manga, 2 = user leaderboards
 	cursor        int
 	
-	// Leaderboard category
+	// User leaderboard category and time window
 	category      string
 	categories    []string
+	window        string
+	windows       []string
 	
 	// Window size
 	width         int
@@ -41,8 +45,10 @@ func NewStatsModel(apiClient *api.Client, userID string) StatsModel {
 	return StatsModel{
 		apiClient:  apiClient,
 		userID:     userID,
-		category:   "weekly",
-		categories: []string{"weekly"},
+		category:   models.LeaderboardCommenters,
+		categories: models.LeaderboardCategories,
+		window:     models.LeaderboardWeekly,
+		windows:    models.LeaderboardWindows,
 	}
 }
 
@@ -53,7 +59,7 @@ func (m *StatsModel) SetUserID(userID string) {
 
 // Init initializes and loads data
 func (m StatsModel) Init() tea.Cmd {
-	return tea.Batch(m.loadUserStats(), m.loadLeaderboard())
+	return tea.Batch(m.loadUserStats(), m.loadLeaderboard(), m.loadUserLeaderboard(), m.loadActivity())
 }
 
 // Update handles messages
@@ -67,31 +73,43 @@ func (m StatsModel) Update(msg tea.Msg) (StatsModel, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("tab"))):
-			m.selectedTab = (m.selectedTab + 1) % 2
+			m.selectedTab = (m.selectedTab + 1) % 3
 			m.cursor = 0
 			return m, nil
 			
 		case key.Matches(msg, key.NewBinding(key.WithKeys("j", "down"))):
-			if m.selectedTab == 1 {
+			if count := m.listLength(); m.selectedTab > 0 && m.cursor < count-1 {
 				m.cursor++
-				if m.cursor >= len(m.leaderboard) {
-					m.cursor = len(m.leaderboard) - 1
-				}
 			}
 			return m, nil
 			
 		case key.Matches(msg, key.NewBinding(key.WithKeys("k", "up"))):
-			if m.selectedTab == 1 {
+			if m.selectedTab > 0 && m.cursor > 0 {
 				m.cursor--
-				if m.cursor < 0 {
-					m.cursor = 0
-				}
+			}
+			return m, nil
+			
+		case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+			if m.selectedTab == 2 {
+				m.category = nextOption(m.categories, m.category)
+				m.cursor = 0
+				m.loading = true
+				return m, m.loadUserLeaderboard()
+			}
+			return m, nil
+			
+		case key.Matches(msg, key.NewBinding(key.WithKeys("w"))):
+			if m.selectedTab == 2 {
+				m.window = nextOption(m.windows, m.window)
+				m.cursor = 0
+				m.loading = true
+				return m, m.loadUserLeaderboard()
 			}
 			return m, nil
 			
 		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
 			m.loading = true
-			return m, tea.Batch(m.loadUserStats(), m.loadLeaderboard())
+			return m, tea.Batch(m.loadUserStats(), m.loadLeaderboard(), m.loadUserLeaderboard(), m.loadActivity())
 		}
 
 	case UserStatsLoadedMsg:
@@ -104,6 +122,22 @@ func (m StatsModel) Update(msg tea.Msg) (StatsModel, tea.Cmd) {
 		m.leaderboard = msg.Entries
 		return m, nil
 
+	case TimeSeriesLoadedMsg:
+		// Only the global series belongs here
+		if msg.Series.MangaID == "" {
+			m.activity = msg.Series
+		}
+		return m, nil
+
+	case UserLeaderboardLoadedMsg:
+		// Ignore stale responses after the category or window changed
+		if msg.Leaderboard.Category != m.category || msg.Leaderboard.Window != m.window {
+			return m, nil
+		}
+		m.loading = false
+		m.userBoard = msg.Leaderboard
+		return m, nil
+
 	case StatsErrorMsg:
 		m.loading = false
 		m.err = msg.Err
@@ -122,16 +156,15 @@ func (m StatsModel) View() string {
 	b.WriteString("\n\n")
 
 	// Tabs
-	myStatsTab := styles.TabStyle.Render("📊 My Stats")
-	leaderboardTab := styles.TabStyle.Render("🏆 Leaderboard")
-	
-	if m.selectedTab == 0 {
-		myStatsTab = styles.TabActiveStyle.Render("📊 My Stats")
-	} else {
-		leaderboardTab = styles.TabActiveStyle.Render("🏆 Leaderboard")
+	tabs := []string{"📊 My Stats", "🏆 Top Manga", "👥 Leaderboards"}
+	for i, tab := range tabs {
+		if i == m.selectedTab {
+			b.WriteString(styles.TabActiveStyle.Render(tab))
+		} else {
+			b.WriteString(styles.TabStyle.Render(tab))
+		}
+		b.WriteString(" ")
 	}
-	
-	b.WriteString(myStatsTab + " " + leaderboardTab)
 	b.WriteString("\n")
 	b.WriteString(styles.RenderDivider(40))
 	b.WriteString("\n\n")
@@ -152,17 +185,23 @@ func (m StatsModel) View() string {
 	}
 
 	// Content based on tab
-	if m.selectedTab == 0 {
+	switch m.selectedTab {
+	case 0:
 		b.WriteString(m.renderMyStats())
-	} else {
+	case 1:
 		b.WriteString(m.renderLeaderboard())
+	case 2:
+		b.WriteString(m.renderUserLeaderboard())
 	}
 
 	// Help
 	b.WriteString("\n\n")
-	if m.selectedTab == 1 {
-		b.WriteString(styles.HelpStyle.Render("↑/↓ navigate • c change category • Tab switch • r refresh"))
-	} else {
+	switch m.selectedTab {
+	case 1:
+		b.WriteString(styles.HelpStyle.Render("↑/↓ navigate • Tab switch • r refresh"))
+	case 2:
+		b.WriteString(styles.HelpStyle.Render("↑/↓ navigate • c category • w window • Tab switch • r refresh"))
+	default:
 		b.WriteString(styles.HelpStyle.Render("Tab switch • r refresh"))
 	}
 
@@ -219,21 +258,16 @@ func (m StatsModel) renderMyStats() string {
 	return b.String()
 }
 
-// renderLeaderboard renders the leaderboard
+// renderLeaderboard renders community activity and the top manga leaderboard
 func (m StatsModel) renderLeaderboard() string {
 	var b strings.Builder
 
-	// Category selector
-	b.WriteString(styles.MetaKeyStyle.Render("Category: "))
-	for _, cat := range m.categories {
-		if cat == m.category {
-			b.WriteString(styles.BadgePrimaryStyle.Render(cat))
-		} else {
-			b.WriteString(styles.TabStyle.Render(cat))
-		}
-		b.WriteString(" ")
+	if m.activity != nil {
+		b.WriteString(styles.MetaKeyStyle.Render(fmt.Sprintf("Community activity (%d days)", m.activity.Days)))
+		b.WriteString("\n")
+		b.WriteString(renderTimeSeries(m.activity))
+		b.WriteString("\n\n")
 	}
-	b.WriteString("\n\n")
 
 	if len(m.leaderboard) == 0 {
 		b.WriteString(styles.InfoStyle.Render("No leaderboard data available"))
@@ -254,16 +288,7 @@ func (m StatsModel) renderLeaderboard() string {
 			style = styles.ListItemSelectedStyle
 		}
 
-		// Rank with medal for top 3
-		rankStr := fmt.Sprintf("#%d", entry.Rank)
-		switch entry.Rank {
-		case 1:
-			rankStr = "🥇"
-		case 2:
-			rankStr = "🥈"
-		case 3:
-			rankStr = "🥉"
-		}
+		rankStr := rankLabel(entry.Rank)
 
 		username := styles.ListItemTitleStyle.Render(entry.Manga.Title)
 		score := styles.MetaValueStyle.Render(fmt.Sprintf("%d pts", entry.Stats.WeeklyScore))
@@ -276,6 +301,169 @@ func (m StatsModel) renderLeaderboard() string {
 	return b.String()
 }
 
+// renderUserLeaderboard renders top users for the selected category and window
+func (m StatsModel) renderUserLeaderboard() string {
+	var b strings.Builder
+
+	b.WriteString(renderOptions("Category: ", m.categories, m.category))
+	b.WriteString(renderOptions("Window:   ", m.windows, m.window))
+	b.WriteString("\n")
+
+	if m.userBoard == nil || len(m.userBoard.Entries) == 0 {
+		b.WriteString(styles.InfoStyle.Render("No leaderboard data available"))
+		return b.String()
+	}
+
+	unit := "comments"
+	switch m.userBoard.Category {
+	case models.LeaderboardLikedCommenters:
+		unit = "likes"
+	case models.LeaderboardChatters:
+		unit = "messages"
+	}
+
+	for i, entry := range m.userBoard.Entries {
+		prefix := "  "
+		style := styles.ListItemStyle
+		if i == m.cursor {
+			prefix = "▸ "
+			style = styles.ListItemSelectedStyle
+		}
+
+		username := styles.ListItemTitleStyle.Render(entry.Username)
+		if entry.UserID == m.userID {
+			username += styles.BadgePrimaryStyle.Render(" you")
+		}
+		score := styles.MetaValueStyle.Render(fmt.Sprintf("%d %s", entry.Score, unit))
+
+		line := fmt.Sprintf("%s%s %s - %s", prefix, rankLabel(entry.Rank), username, score)
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderTimeSeries renders one sparkline per counter, oldest day first, and
+// whether activity is heating up or cooling down
+func renderTimeSeries(series *models.StatsTimeSeries) string {
+	if series == nil || len(series.Points) == 0 {
+		return styles.InfoStyle.Render("No activity recorded yet")
+	}
+
+	rows := []struct {
+		label string
+		value func(models.DailyStats) int
+	}{
+		{"Comments", func(p models.DailyStats) int { return p.CommentCount }},
+		{"Chats", func(p models.DailyStats) int { return p.ChatCount }},
+		{"Likes", func(p models.DailyStats) int { return p.LikeCount }},
+		{"Score", func(p models.DailyStats) int { return p.Score }},
+	}
+
+	var b strings.Builder
+	var scores []int
+	for _, row := range rows {
+		values := make([]int, len(series.Points))
+		total := 0
+		for i, point := range series.Points {
+			values[i] = row.value(point)
+			total += values[i]
+		}
+		scores = values // The trend follows the last row, Score
+
+		b.WriteString(styles.MetaKeyStyle.Render(fmt.Sprintf("%-9s", row.label)))
+		b.WriteString(" ")
+		b.WriteString(styles.RenderSparkline(values))
+		b.WriteString(" ")
+		b.WriteString(styles.MetaValueStyle.Render(fmt.Sprintf("%d", total)))
+		b.WriteString("\n")
+	}
+	b.WriteString(renderTrend(scores))
+
+	return b.String()
+}
+
+// renderTrend compares the last 7 days of values with the 7 days before them
+func renderTrend(values []int) string {
+	sum := func(from, to int) int {
+		total := 0
+		for i := from; i < to; i++ {
+			if i >= 0 {
+				total += values[i]
+			}
+		}
+		return total
+	}
+	n := len(values)
+	recent, previous := sum(n-7, n), sum(n-14, n-7)
+
+	switch {
+	case recent > previous && previous > 0:
+		return styles.SuccessStyle.Render(fmt.Sprintf("▲ Heating up (+%d%% week over week)", (recent-previous)*100/previous))
+	case recent > previous:
+		return styles.SuccessStyle.Render("▲ Heating up")
+	case recent < previous:
+		return styles.WarningStyle.Render(fmt.Sprintf("▼ Cooling down (-%d%% week over week)", (previous-recent)*100/previous))
+	}
+	return styles.InfoStyle.Render("● Steady")
+}
+
+// renderOptions renders a labelled row of options with the current one highlighted
+func renderOptions(label string, options []string, current string) string {
+	var b strings.Builder
+	b.WriteString(styles.MetaKeyStyle.Render(label))
+	for _, opt := range options {
+		if opt == current {
+			b.WriteString(styles.BadgePrimaryStyle.Render(opt))
+		} else {
+			b.WriteString(styles.TabStyle.Render(opt))
+		}
+		b.WriteString(" ")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// rankLabel returns a medal for the top 3 ranks, "#N" otherwise
+func rankLabel(rank int) string {
+	switch rank {
+	case 1:
+		return "🥇"
+	case 2:
+		return "🥈"
+	case 3:
+		return "🥉"
+	}
+	return fmt.Sprintf("#%d", rank)
+}
+
+// nextOption returns the option after current, wrapping around
+func nextOption(options []string, current string) string {
+	for i, opt := range options {
+		if opt == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
+
+// listLength returns the number of rows in the current tab's list
+func (m StatsModel) listLength() int {
+	switch m.selectedTab {
+	case 1:
+		if len(m.leaderboard) > 10 {
+			return 10
+		}
+		return len(m.leaderboard)
+	case 2:
+		if m.userBoard != nil {
+			return len(m.userBoard.Entries)
+		}
+	}
+	return 0
+}
+
 // loadUserStats loads user statistics
 func (m StatsModel) loadUserStats() tea.Cmd {
 	return func() tea.Msg {
@@ -304,6 +492,31 @@ func (m StatsModel) loadLeaderboard() tea.Cmd {
 	}
 }
 
+// loadUserLeaderboard loads the user leaderboard for the selected category and window
+func (m StatsModel) loadUserLeaderboard() tea.Cmd {
+	category, window := m.category, m.window
+	return func() tea.Msg {
+		ctx := context.Background()
+		leaderboard, err := m.apiClient.GetLeaderboard(ctx, category, window, 10)
+		if err != nil {
+			return StatsErrorMsg{Err: err}
+		}
+		return UserLeaderboardLoadedMsg{Leaderboard: leaderboard}
+	}
+}
+
+// loadActivity loads the global daily activity series
+func (m StatsModel) loadActivity() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		series, err := m.apiClient.GetGlobalTimeSeries(ctx, 30)
+		if err != nil {
+			return StatsErrorMsg{Err: err}
+		}
+		return TimeSeriesLoadedMsg{Series: series}
+	}
+}
+
 // Messages
 
 // UserStatsLoadedMsg is sent when user stats are loaded
@@ -316,6 +529,17 @@ type LeaderboardLoadedMsg struct {
 	Entries []models.RankedManga
 }
 
+// UserLeaderboardLoadedMsg is sent when a user leaderboard is loaded
+type UserLeaderboardLoadedMsg struct {
+	Leaderboard *models.LeaderboardResponse
+}
+
+// TimeSeriesLoadedMsg is sent when a daily stats series is loaded.
+// Series.MangaID is empty for the global series.
+type TimeSeriesLoadedMsg struct {
+	Series *models.StatsTimeSeries
+}
+
 // StatsErrorMsg is sent on stats errors
 type StatsErrorMsg struct {
 	Err error
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"mangahub/pkg/config"
+)
+
+// RedisPubSub publishes and subscribes on one Redis pub/sub channel. It
+// satisfies repository.ChatBackplane.
+type RedisPubSub struct {
+	client  *redis.Client
+	channel string
+}
+
+// NewRedisPubSub creates a pub/sub client for channel
+func NewRedisPubSub(cfg *config.RedisConfig, channel string) (*RedisPubSub, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Password: cfg.Password,
+		DB:       cfg.DB,
+		PoolSize: cfg.PoolSize,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("redis ping failed: %w", err)
+	}
+
+	return &RedisPubSub{client: client, channel: channel}, nil
+}
+
+// Publish sends a payload to every subscriber
+func (r *RedisPubSub) Publish(ctx context.Context, payload []byte) error {
+	if err := r.client.Publish(ctx, r.channel, payload).Err(); err != nil {
+		return fmt.Errorf("redis publish failed: %w", err)
+	}
+	return nil
+}
+
+// Subscribe calls ready once subscribed, then handle with every payload,
+// until ctx ends or the connection fails
+func (r *RedisPubSub) Subscribe(ctx context.Context, ready func(), handle func(payload []byte)) error {
+	sub := r.client.Subscribe(ctx, r.channel)
+	defer sub.Close()
+
+	// Wait for the subscription to be confirmed
+	if _, err := sub.Receive(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("redis subscribe failed: %w", err)
+	}
+
+	ready()
+	for {
+		msg, err := sub.ReceiveMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("redis receive failed: %w", err)
+		}
+		handle([]byte(msg.Payload))
+	}
+}
+
+// Close closes the Redis connection
+func (r *RedisPubSub) Close() error {
+	return r.client.Close()
+}
@@ -17,6 +17,9 @@ type Config struct {
 	TCP       TCPConfig
 	UDP       UDPConfig
 	GRPC      GRPCConfig
+	Scheduler SchedulerConfig
+	Outbox    OutboxConfig
+	Scoring   ScoringConfig
 	WebSocket WebSocketConfig
 	Logging   LoggingConfig
 	Redis     RedisConfig
@@ -56,21 +59,58 @@ type JWTConfig struct {
 }
 
 type TCPConfig struct {
-	Host           string `mapstructure:"host"`
-	Port           int    `mapstructure:"port"`
-	MaxConnections int    `mapstructure:"max_connections"`
-	BufferSize     int    `mapstructure:"buffer_size"`
+	Host           string        `mapstructure:"host"`
+	Port           int           `mapstructure:"port"`
+	MaxConnections int           `mapstructure:"max_connections"`
+	BufferSize     int           `mapstructure:"buffer_size"`
+	BatchSize      int           `mapstructure:"batch_size"`     // Flush after this many buffered events
+	FlushInterval  time.Duration `mapstructure:"flush_interval"` // Flush at least this often
+	DedupeWindow   time.Duration `mapstructure:"dedupe_window"`  // Processed event IDs are kept this long to skip replays
+	ClientPool     int           `mapstructure:"client_pool"`    // Persistent connections per stats client
+	RetryQueue     int           `mapstructure:"retry_queue"`    // Undelivered events kept for retry
+	SharedSecret   string        `mapstructure:"shared_secret"`  // Signs welcome nonces; empty allows unauthenticated legacy frames
+	MaxFrameSize   int           `mapstructure:"max_frame_size"` // Largest typed frame in bytes
 }
 
 type UDPConfig struct {
-	Host       string `mapstructure:"host"`
-	Port       int    `mapstructure:"port"`
-	BufferSize int    `mapstructure:"buffer_size"`
+	Host           string        `mapstructure:"host"`
+	Port           int           `mapstructure:"port"`
+	BufferSize     int           `mapstructure:"buffer_size"`
+	Mode           string        `mapstructure:"mode"`            // unicast (subscribers), broadcast or multicast
+	MulticastGroup string        `mapstructure:"multicast_group"` // host:port of the group in multicast mode
+	SubscriberTTL  time.Duration `mapstructure:"subscriber_ttl"`  // Subscribers without a heartbeat are dropped after this
+	MaxSubscribers int           `mapstructure:"max_subscribers"`
 }
 
 type GRPCConfig struct {
-	Host string `mapstructure:"host"`
-	Port int    `mapstructure:"port"`
+	Host        string `mapstructure:"host"`
+	Port        int    `mapstructure:"port"`
+	RequireAuth bool   `mapstructure:"require_auth"` // Reject anonymous calls to public read RPCs
+	Gateway     bool   `mapstructure:"gateway"`      // Serve the REST/JSON gateway under /gateway on the HTTP port
+}
+
+type SchedulerConfig struct {
+	Enabled         bool    `mapstructure:"enabled"`          // false = jobs only run when triggered by an admin
+	DecaySchedule   string  `mapstructure:"decay_schedule"`   // Cron expression or descriptor, e.g. "@hourly"
+	RebuildSchedule string  `mapstructure:"rebuild_schedule"` // Full stats rebuild, e.g. "0 3 * * *"
+}
+
+// OutboxConfig tunes delivery of cross-protocol side effects from the outbox
+type OutboxConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"` // Fallback poll when no NOTIFY arrives
+	BatchSize    int           `mapstructure:"batch_size"`    // Events claimed per round
+	MaxAttempts  int           `mapstructure:"max_attempts"`  // Attempts before an event is dead-lettered
+	MaxBackoff   time.Duration `mapstructure:"max_backoff"`   // Upper bound for the retry delay
+	Retention    time.Duration `mapstructure:"retention"`     // How long delivered events are kept
+}
+
+// ScoringConfig weights manga activity for trending and weekly scores
+type ScoringConfig struct {
+	CommentWeight float64       `mapstructure:"comment_weight"`
+	LikeWeight    float64       `mapstructure:"like_weight"`
+	ChatWeight    float64       `mapstructure:"chat_weight"`
+	UpdateWeight  float64       `mapstructure:"update_weight"`
+	HalfLife      time.Duration `mapstructure:"half_life"` // Age at which an event counts half
 }
 
 type WebSocketConfig struct {
@@ -81,6 +121,7 @@ type WebSocketConfig struct {
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	PingPeriod       time.Duration `mapstructure:"ping_period"`
 	MaxMessageSize   int64         `mapstructure:"max_message_size"`
+	Backplane        string        `mapstructure:"backplane"` // Shares rooms across instances: postgres, redis or none
 }
 
 type LoggingConfig struct {
@@ -201,8 +242,11 @@ func bindEnvVars() {
 	// Protocol ports (for local dev - Railway only exposes one port)
 	viper.BindEnv("grpc.port", "GRPC_PORT")
 	viper.BindEnv("tcp.port", "TCP_PORT")
+	viper.BindEnv("tcp.shared_secret", "TCP_SHARED_SECRET")
 	viper.BindEnv("udp.port", "UDP_PORT")
+	viper.BindEnv("udp.mode", "UDP_MODE")
 	viper.BindEnv("websocket.port", "WS_PORT")
+	viper.BindEnv("websocket.backplane", "WS_BACKPLANE")
 }
 
 func setDefaults() {
@@ -237,15 +281,46 @@ func setDefaults() {
 	viper.SetDefault("tcp.port", 9090)
 	viper.SetDefault("tcp.max_connections", 100)
 	viper.SetDefault("tcp.buffer_size", 4096)
+	viper.SetDefault("tcp.batch_size", 100)
+	viper.SetDefault("tcp.flush_interval", "250ms")
+	viper.SetDefault("tcp.dedupe_window", "24h")
+	viper.SetDefault("tcp.client_pool", 4)
+	viper.SetDefault("tcp.retry_queue", 1000)
+	viper.SetDefault("tcp.max_frame_size", 65536)
 
 	// UDP defaults
 	viper.SetDefault("udp.host", "localhost")
 	viper.SetDefault("udp.port", 9091)
 	viper.SetDefault("udp.buffer_size", 2048)
+	viper.SetDefault("udp.mode", "unicast")
+	viper.SetDefault("udp.multicast_group", "239.255.77.77:9091")
+	viper.SetDefault("udp.subscriber_ttl", "90s")
+	viper.SetDefault("udp.max_subscribers", 10000)
 
 	// gRPC defaults
 	viper.SetDefault("grpc.host", "localhost")
 	viper.SetDefault("grpc.port", 9092)
+	viper.SetDefault("grpc.require_auth", false)
+	viper.SetDefault("grpc.gateway", true)
+
+	// Scheduler defaults
+	viper.SetDefault("scheduler.enabled", true)
+	viper.SetDefault("scheduler.decay_schedule", "@hourly")
+	viper.SetDefault("scheduler.rebuild_schedule", "0 3 * * *")
+
+	// Outbox defaults
+	viper.SetDefault("outbox.poll_interval", "5s")
+	viper.SetDefault("outbox.batch_size", 100)
+	viper.SetDefault("outbox.max_attempts", 10)
+	viper.SetDefault("outbox.max_backoff", "5m")
+	viper.SetDefault("outbox.retention", "24h")
+
+	// Scoring defaults
+	viper.SetDefault("scoring.comment_weight", 1.0)
+	viper.SetDefault("scoring.like_weight", 1.0)
+	viper.SetDefault("scoring.chat_weight", 2.0)
+	viper.SetDefault("scoring.update_weight", 5.0)
+	viper.SetDefault("scoring.half_life", "72h")
 
 	// WebSocket defaults
 	viper.SetDefault("websocket.host", "localhost")
@@ -255,6 +330,7 @@ func setDefaults() {
 	viper.SetDefault("websocket.handshake_timeout", "10s")
 	viper.SetDefault("websocket.ping_period", "54s")
 	viper.SetDefault("websocket.max_message_size", 512000)
+	viper.SetDefault("websocket.backplane", "postgres")
 
 	// Logging defaults
 	viper.SetDefault("logging.level", "info")
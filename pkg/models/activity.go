@@ -11,6 +11,10 @@ const (
 	ActivityTypeMangaUpdate = "manga_update"
 )
 
+// ScoreEventLike is the stats event type for a comment like. Likes are scored
+// but are not activity_feed rows.
+const ScoreEventLike = "like"
+
 // Activity represents an activity feed entry - EXACTLY matches schema.sql
 type Activity struct {
 	ID        string    `json:"id" db:"id"`
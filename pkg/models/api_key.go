@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKeyScope represents a permission granted to an API key
+type APIKeyScope string
+
+const (
+	ScopeMangaWrite        APIKeyScope = "manga:write"
+	ScopeNotificationsSend APIKeyScope = "notifications:send"
+	ScopeStatsRead         APIKeyScope = "stats:read"
+)
+
+// APIKey represents a long-lived key for scripts and service-to-service access.
+// Only the SHA-256 hash of the key is stored; the plaintext is shown once on creation.
+type APIKey struct {
+	ID         string     `json:"id" db:"id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	KeyHash    string     `json:"-" db:"key_hash"`
+	Scopes     []string   `json:"scopes" db:"scopes"`
+	CreatedBy  string     `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// CreateAPIKeyRequest represents a request to issue a new API key
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required"`
+	Scopes []string `json:"scopes" validate:"required,min=1"`
+}
+
+// CreateAPIKeyResponse carries the plaintext key, which is never retrievable again
+type CreateAPIKeyResponse struct {
+	Key    string  `json:"key"`
+	APIKey *APIKey `json:"api_key"`
+}
+
+// IsValidAPIKeyScope validates a scope against the known scopes
+func IsValidAPIKeyScope(scope string) bool {
+	switch APIKeyScope(scope) {
+	case ScopeMangaWrite, ScopeNotificationsSend, ScopeStatsRead:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasScope reports whether the key grants the given scope
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRevoked reports whether the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
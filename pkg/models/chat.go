@@ -1,6 +1,8 @@
 package models
 
 import (
+	"regexp"
+	"strings"
 	"time"
 	"github.com/gorilla/websocket"
 )
@@ -12,12 +14,20 @@ type ChatMessage struct {
 	UserID    string    `json:"user_id" db:"user_id"`
 	Content   string    `json:"content" db:"content"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty" db:"edited_at"`
+	ReplyTo   *string    `json:"reply_to,omitempty" db:"reply_to"` // Quoted message in the same room
 }
 
 // SendChatMessageRequest - includes manga_id for per-manga rooms
 type SendChatMessageRequest struct {
 	MangaID string `json:"manga_id" validate:"required"` // Can also be derived from connection
 	Content string `json:"content" validate:"required,min=1,max=5000"`
+	ReplyTo string `json:"reply_to,omitempty"` // ID of a message in the same room
+}
+
+// EditChatMessageRequest replaces a message's content
+type EditChatMessageRequest struct {
+	Content string `json:"content" validate:"required,min=1,max=5000"`
 }
 
 // ChatUser - minimal user info (SPEC.md schema compliant)
@@ -33,6 +43,34 @@ type ChatMessageResponse struct {
 	User      ChatUser  `json:"user"`
 	Content   string    `json:"content"`
 	CreatedAt time.Time `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	Reactions map[string]int `json:"reactions,omitempty"` // emoji -> count
+	ReplyTo   *string        `json:"reply_to,omitempty"`
+	Mentions  []string       `json:"mentions,omitempty"` // Mentioned user IDs
+}
+
+// ChatMentionResponse is a message that mentions (or replies to) a user
+type ChatMentionResponse struct {
+	ChatMessageResponse
+	MangaTitle string `json:"manga_title"`
+}
+
+// ChatMentionListResponse is a user's mentions, newest first
+type ChatMentionListResponse struct {
+	Data    []ChatMentionResponse `json:"data"`
+	Total   int                   `json:"total"`
+	Limit   int                   `json:"limit"`
+	Offset  int                   `json:"offset"`
+	HasMore bool                  `json:"has_more"`
+}
+
+// ChatReactionResponse is the result of toggling a reaction
+type ChatReactionResponse struct {
+	MessageID string         `json:"message_id"`
+	MangaID   string         `json:"manga_id"`
+	Emoji     string         `json:"emoji"`
+	Added     bool           `json:"added"`     // false when the reaction was removed
+	Reactions map[string]int `json:"reactions"` // emoji -> count after the change
 }
 
 // ChatHistoryResponse represents paginated chat history
@@ -44,6 +82,19 @@ type ChatHistoryResponse struct {
 	HasMore bool                 `json:"has_more"`
 }
 
+// ChatHistoryCursor pages back through a room's history: messages older than
+// BeforeID, or else than Before. Neither set means the newest messages.
+type ChatHistoryCursor struct {
+	BeforeID string     `json:"before,omitempty"`
+	Before   *time.Time `json:"before_time,omitempty"`
+}
+
+// ChatHistoryPage is one page of history, newest first
+type ChatHistoryPage struct {
+	Data    []ChatMessageResponse `json:"data"`
+	HasMore bool                  `json:"has_more"` // Older messages remain
+}
+
 // ChatRoomInfo - for listing active chat rooms
 type ChatRoomInfo struct {
 	MangaID      string `json:"manga_id"`
@@ -97,4 +148,26 @@ type ChatActivityEvent struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
-const MaxChatMessageLength = 5000
\ No newline at end of file
+const MaxChatMessageLength = 5000
+
+// MaxChatEmojiLength limits a reaction, in bytes (emoji sequences can be long)
+const MaxChatEmojiLength = 32
+
+// mentionPattern matches @username (usernames are alphanumeric, 3-50
+// characters) at the start of the text or after a non-word character, so
+// e-mail addresses are not mentions
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9]{3,50})\b`)
+
+// ParseMentions returns the distinct usernames mentioned in content, lowercased
+func ParseMentions(content string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		name := strings.ToLower(match[1])
+		if !seen[name] {
+			seen[name] = true
+			usernames = append(usernames, name)
+		}
+	}
+	return usernames
+}
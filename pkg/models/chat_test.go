@@ -0,0 +1,35 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{name: "none", content: "no mentions here", want: nil},
+		{name: "start of text", content: "@alice hi", want: []string{"alice"}},
+		{name: "after punctuation", content: "hi (@alice), @bob!", want: []string{"alice", "bob"}},
+		{name: "lowercased and distinct", content: "@Alice @ALICE @alice", want: []string{"alice"}},
+		{name: "order of first mention", content: "@bob @alice @bob", want: []string{"bob", "alice"}},
+		{name: "e-mail address", content: "mail me at reader@example.com", want: nil},
+		{name: "double at", content: "@@alice", want: nil},
+		{name: "too short", content: "@al", want: nil},
+		{name: "longest username", content: "@" + strings.Repeat("a", 50), want: []string{strings.Repeat("a", 50)}},
+		{name: "too long", content: "@" + strings.Repeat("a", 51), want: nil},
+		{name: "underscore is not a username character", content: "@alice_b", want: nil},
+		{name: "multi-line", content: "first line\n@alice", want: []string{"alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseMentions(tt.content))
+		})
+	}
+}
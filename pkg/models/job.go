@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// Names of the background jobs run by the scheduler
+const (
+	JobScoreDecay   = "score_decay"
+	JobStatsRebuild = "stats_rebuild"
+)
+
+// JobStatus represents the outcome of a job run
+type JobStatus string
+
+const (
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusSkipped   JobStatus = "skipped" // another replica held the lock
+)
+
+// JobRun records the most recent run of a scheduled job
+type JobRun struct {
+	JobName     string     `json:"job_name" db:"job_name"`
+	Status      JobStatus  `json:"status" db:"status"`
+	TriggeredBy string     `json:"triggered_by" db:"triggered_by"` // "schedule" or the admin user ID
+	Instance    string     `json:"instance" db:"instance"`
+	StartedAt   time.Time  `json:"started_at" db:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+	DurationMs  int64      `json:"duration_ms" db:"duration_ms"`
+	Error       string     `json:"error,omitempty" db:"error"`
+}
+
+// JobInfo describes a scheduled job and its last run
+type JobInfo struct {
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule"`
+	NextRun  *time.Time `json:"next_run,omitempty"`
+	LastRun  *JobRun    `json:"last_run,omitempty"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Outbox destinations: where the relay delivers an event
+const (
+	OutboxStats        = "stats"        // TCP stats aggregator
+	OutboxNotification = "notification" // UDP notification broadcaster
+	OutboxChat         = "chat"         // WebSocket room fan-out
+)
+
+// OutboxStatus is the delivery state of an outbox event
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusDelivered OutboxStatus = "delivered"
+	OutboxStatusDead      OutboxStatus = "dead" // gave up after the max attempts
+)
+
+// OutboxEvent is a side effect recorded in the transaction that caused it
+type OutboxEvent struct {
+	ID          string          `json:"id" db:"id"`
+	Destination string          `json:"destination" db:"destination"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	Status      OutboxStatus    `json:"status" db:"status"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	LastError   *string         `json:"last_error,omitempty" db:"last_error"`
+	AvailableAt time.Time       `json:"available_at" db:"available_at"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// OutboxStatsPayload is a stats event for the TCP aggregator
+type OutboxStatsPayload struct {
+	Type      string    `json:"type"` // activity_feed.type, or ScoreEventLike
+	MangaID   string    `json:"manga_id"`
+	UserID    *string   `json:"user_id,omitempty"`
+	EventTime time.Time `json:"event_time"`
+}
+
+// OutboxNotificationPayload is a UDP broadcast
+type OutboxNotificationPayload struct {
+	Type    string  `json:"type"`
+	MangaID *string `json:"manga_id,omitempty"`
+	Title   string  `json:"title"`
+	Message string  `json:"message"`
+}
+
+// Chat outbox events other than a new message (OutboxChatPayload.Event)
+const (
+	ChatEventEdited   = "message_edited"
+	ChatEventDeleted  = "message_deleted"
+	ChatEventReaction = "reaction"
+)
+
+// OutboxChatPayload is a change to a chat message for the manga's WebSocket
+// room: a new message, or the ChatEvent* in Event. For an edit, UserID is the
+// author; for a reaction, the reacting user.
+type OutboxChatPayload struct {
+	Event     string         `json:"event,omitempty"` // Empty for a new message
+	MessageID string         `json:"message_id"`
+	MangaID   string         `json:"manga_id"`
+	UserID    string         `json:"user_id"`
+	Username  string         `json:"username"`
+	Content   string         `json:"content"`
+	CreatedAt time.Time      `json:"created_at"`
+	EditedAt  *time.Time     `json:"edited_at,omitempty"`
+	Emoji     string         `json:"emoji,omitempty"`
+	Added     bool           `json:"added,omitempty"`     // Reaction added rather than removed
+	Reactions map[string]int `json:"reactions,omitempty"` // Counts per emoji after a reaction
+	ReplyTo   *string        `json:"reply_to,omitempty"`
+	Mentions  []string       `json:"mentions,omitempty"` // Mentioned user IDs, for a new or edited message
+}
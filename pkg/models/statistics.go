@@ -29,12 +29,51 @@ type TrendingManga struct {
 	Title       string `json:"title"`
 	ActivityCount int  `json:"activity_count"`
 	Timeframe   string `json:"timeframe"` // "24h", "7d", "30d"
+	Score       float64 `json:"score"`    // Time-decayed trending score
+}
+
+// Trending windows
+const (
+	TrendingWindowDay   = "24h"
+	TrendingWindowWeek  = "7d"
+	TrendingWindowMonth = "30d"
+)
+
+// TrendingWindows lists the valid trending windows, shortest first
+var TrendingWindows = []string{TrendingWindowDay, TrendingWindowWeek, TrendingWindowMonth}
+
+// ScoringFormula weights manga activity for trending and weekly scores.
+// Each event is worth its weight when it happens and half that after every
+// HalfLife of age.
+type ScoringFormula struct {
+	CommentWeight float64       `json:"comment_weight"`
+	LikeWeight    float64       `json:"like_weight"`
+	ChatWeight    float64       `json:"chat_weight"`
+	UpdateWeight  float64       `json:"update_weight"`
+	HalfLife      time.Duration `json:"half_life"`
+}
+
+// TrendingEntry is one ranked manga in a trending window
+type TrendingEntry struct {
+	Manga         Manga   `json:"manga"`
+	Score         float64 `json:"score"`
+	ActivityCount int     `json:"activity_count"`
+	Rank          int     `json:"rank"`
+}
+
+// TrendingResponse is the trending ranking for a time window
+type TrendingResponse struct {
+	Window string          `json:"window"`
+	Data   []TrendingEntry `json:"data"`
 }
 
 // Notification represents a broadcast notification - EXACTLY matches schema.sql
 type Notification struct {
 	ID        string    `json:"id" db:"id"`
 	Message   string    `json:"message" db:"message"`
+	Type      string    `json:"type" db:"type"`
+	MangaID   *string   `json:"manga_id,omitempty" db:"manga_id"`
+	Title     string    `json:"title,omitempty" db:"title"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
@@ -63,6 +102,26 @@ type RankedMangaResponse struct {
     HasMore bool          `json:"has_more"`
 }
 
+// Leaderboard categories
+const (
+	LeaderboardCommenters      = "commenters"       // Comments posted
+	LeaderboardLikedCommenters = "liked_commenters" // Likes received on comments
+	LeaderboardChatters        = "chatters"         // Chat messages sent
+)
+
+// Leaderboard windows
+const (
+	LeaderboardWeekly  = "weekly"
+	LeaderboardMonthly = "monthly"
+	LeaderboardAllTime = "all_time"
+)
+
+// LeaderboardCategories and LeaderboardWindows list the valid values, in display order
+var (
+	LeaderboardCategories = []string{LeaderboardCommenters, LeaderboardLikedCommenters, LeaderboardChatters}
+	LeaderboardWindows    = []string{LeaderboardWeekly, LeaderboardMonthly, LeaderboardAllTime}
+)
+
 // LeaderboardEntry represents a user leaderboard row
 type LeaderboardEntry struct {
 	UserID   string `json:"user_id"`
@@ -72,6 +131,31 @@ type LeaderboardEntry struct {
 	Category string `json:"category"`
 }
 
+// LeaderboardResponse is one leaderboard for a category and time window
+type LeaderboardResponse struct {
+	Category string             `json:"category"`
+	Window   string             `json:"window"`
+	Entries  []LeaderboardEntry `json:"entries"`
+}
+
+// DailyStats is one manga's activity for a day - matches manga_stats_daily.
+// Global series leave MangaID empty and sum every manga.
+type DailyStats struct {
+	MangaID      string `json:"manga_id,omitempty" db:"manga_id"`
+	Day          string `json:"day" db:"day"` // YYYY-MM-DD
+	CommentCount int    `json:"comment_count" db:"comment_count"`
+	ChatCount    int    `json:"chat_count" db:"chat_count"`
+	LikeCount    int    `json:"like_count" db:"like_count"`
+	Score        int    `json:"score" db:"score"` // Undecayed scoring points
+}
+
+// StatsTimeSeries is a day-by-day series, oldest first, with no gaps
+type StatsTimeSeries struct {
+	MangaID string       `json:"manga_id,omitempty"` // Empty for the global series
+	Days    int          `json:"days"`
+	Points  []DailyStats `json:"points"`
+}
+
 // UserStatistics represents aggregated stats for a user
 type UserStatistics struct {
 	UserID        string  `json:"user_id"`
@@ -83,11 +167,13 @@ type UserStatistics struct {
 	TopGenres     []Genre `json:"top_genres"`
 }
 
-// StatsUpdate represents partial updates to manga stats
+// StatsUpdate is a set of counter increments for one manga and day, applied
+// atomically to manga_stats and manga_stats_daily by BatchUpdateStats
 type StatsUpdate struct {
 	MangaID      string `json:"manga_id"`
-	CommentCount *int   `json:"comment_count,omitempty"`
-	LikeCount    *int   `json:"like_count,omitempty"`
-	ChatCount    *int   `json:"chat_count,omitempty"`
-	WeeklyScore  *int   `json:"weekly_score,omitempty"`
+	Day          string `json:"day"` // YYYY-MM-DD row in manga_stats_daily
+	CommentCount int    `json:"comment_count"`
+	LikeCount    int    `json:"like_count"`
+	ChatCount    int    `json:"chat_count"`
+	WeeklyScore  int    `json:"weekly_score"` // Also added to the day's score
 }